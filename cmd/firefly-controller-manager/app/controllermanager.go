@@ -63,6 +63,7 @@ import (
 	fireflyctrlmgrconfig "github.com/carlory/firefly/pkg/controller/apis/config"
 	fireflyversioned "github.com/carlory/firefly/pkg/generated/clientset/versioned"
 	fireflyinformers "github.com/carlory/firefly/pkg/generated/informers/externalversions"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
 )
 
 func init() {
@@ -161,6 +162,7 @@ func Run(c *config.CompletedConfig, stopCh <-chan struct{}) error {
 	c.EventBroadcaster.StartStructuredLogging(0)
 	c.EventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: c.Client.CoreV1().Events("")})
 	defer c.EventBroadcaster.Shutdown()
+	clientutil.SetDriftEventRecorder(c.EventRecorder)
 
 	if cfgz, err := configz.New(ConfigzName); err == nil {
 		cfgz.Set(c.ComponentConfig)
@@ -354,6 +356,10 @@ func NewControllerInitializers() map[string]InitFunc {
 	controllers := map[string]InitFunc{}
 	controllers["karmada"] = startKarmadaController
 	controllers["clusterpedia"] = startClusterpediaController
+	controllers["karmadabackup"] = startKarmadaBackupController
+	controllers["karmadarestore"] = startKarmadaRestoreController
+	controllers["membercluster"] = startMemberClusterController
+	controllers["karmadaaccess"] = startKarmadaAccessController
 	return controllers
 }
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/controller-manager/controller"
 
 	"github.com/carlory/firefly/pkg/controller/clusterpedia"
@@ -30,6 +31,8 @@ func startKarmadaController(ctx context.Context, controllerContext ControllerCon
 	ctrl, err := karmada.NewKarmadaController(
 		controllerContext.ClientBuilder.ClientOrDie("firefly-karmada-controller"),
 		controllerContext.ClientBuilder.FireflyClientOrDie("firefly-karmada-controller"),
+		dynamic.NewForConfigOrDie(controllerContext.ClientBuilder.ConfigOrDie("firefly-karmada-controller")),
+		controllerContext.ClientBuilder.ConfigOrDie("firefly-karmada-controller"),
 		controllerContext.FireflyInformerFactory.Install().V1alpha1().Karmadas(),
 	)
 	if err != nil {
@@ -43,6 +46,7 @@ func startClusterpediaController(ctx context.Context, controllerContext Controll
 	ctrl, err := clusterpedia.NewClusterpediaController(
 		controllerContext.ClientBuilder.ClientOrDie("firefly-clusterpedia-controller"),
 		controllerContext.ClientBuilder.FireflyClientOrDie("firefly-clusterpedia-controller"),
+		controllerContext.ClientBuilder.ConfigOrDie("firefly-clusterpedia-controller"),
 		controllerContext.FireflyInformerFactory.Install().V1alpha1().Clusterpedias(),
 	)
 	if err != nil {
@@ -51,3 +55,62 @@ func startClusterpediaController(ctx context.Context, controllerContext Controll
 	go ctrl.Run(ctx, 1)
 	return nil, true, nil
 }
+
+func startKarmadaBackupController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	ctrl, err := karmada.NewKarmadaBackupController(
+		controllerContext.ClientBuilder.ClientOrDie("firefly-karmada-backup-controller"),
+		controllerContext.ClientBuilder.FireflyClientOrDie("firefly-karmada-backup-controller"),
+		controllerContext.FireflyInformerFactory.Install().V1alpha1().KarmadaBackups(),
+		controllerContext.FireflyInformerFactory.Install().V1alpha1().Karmadas(),
+		controllerContext.KubeInformerFactory.Batch().V1().Jobs(),
+	)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to start the karmada backup controller: %v", err)
+	}
+	go ctrl.Run(ctx, 1)
+	return nil, true, nil
+}
+
+func startKarmadaRestoreController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	ctrl, err := karmada.NewKarmadaRestoreController(
+		controllerContext.ClientBuilder.ClientOrDie("firefly-karmada-restore-controller"),
+		controllerContext.ClientBuilder.FireflyClientOrDie("firefly-karmada-restore-controller"),
+		controllerContext.FireflyInformerFactory.Install().V1alpha1().KarmadaRestores(),
+		controllerContext.FireflyInformerFactory.Install().V1alpha1().KarmadaBackups(),
+		controllerContext.FireflyInformerFactory.Install().V1alpha1().Karmadas(),
+	)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to start the karmada restore controller: %v", err)
+	}
+	go ctrl.Run(ctx, 1)
+	return nil, true, nil
+}
+
+func startMemberClusterController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	ctrl, err := karmada.NewMemberClusterController(
+		controllerContext.ClientBuilder.ClientOrDie("firefly-member-cluster-controller"),
+		controllerContext.ClientBuilder.FireflyClientOrDie("firefly-member-cluster-controller"),
+		controllerContext.FireflyInformerFactory.Install().V1alpha1().MemberClusters(),
+		controllerContext.FireflyInformerFactory.Install().V1alpha1().Karmadas(),
+	)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to start the member cluster controller: %v", err)
+	}
+	go ctrl.Run(ctx, 1)
+	return nil, true, nil
+}
+
+func startKarmadaAccessController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	ctrl, err := karmada.NewKarmadaAccessController(
+		controllerContext.ClientBuilder.ClientOrDie("firefly-karmada-access-controller"),
+		controllerContext.ClientBuilder.FireflyClientOrDie("firefly-karmada-access-controller"),
+		controllerContext.FireflyInformerFactory.Install().V1alpha1().KarmadaAccesses(),
+		controllerContext.FireflyInformerFactory.Install().V1alpha1().Karmadas(),
+		controllerContext.ComponentConfig.KarmadaAccessController.AllowedSystemSubjects,
+	)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to start the karmada access controller: %v", err)
+	}
+	go ctrl.Run(ctx, 1)
+	return nil, true, nil
+}
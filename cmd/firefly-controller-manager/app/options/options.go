@@ -51,6 +51,8 @@ const (
 type FireflyControllerManagerOptions struct {
 	Generic *cmoptions.GenericControllerManagerConfigurationOptions
 
+	KarmadaAccessController *fireflyctrlmgrconfig.KarmadaAccessControllerConfiguration
+
 	SecureServing  *apiserveroptions.SecureServingOptionsWithLoopback
 	Authentication *apiserveroptions.DelegatingAuthenticationOptions
 	Authorization  *apiserveroptions.DelegatingAuthorizationOptions
@@ -71,6 +73,8 @@ func NewFireflyControllerManagerOptions() (*FireflyControllerManagerOptions, err
 	s := FireflyControllerManagerOptions{
 		Generic: cmoptions.NewGenericControllerManagerConfigurationOptions(&componentConfig.Generic),
 
+		KarmadaAccessController: &componentConfig.KarmadaAccessController,
+
 		SecureServing:  apiserveroptions.NewSecureServingOptions().WithLoopback(),
 		Authentication: apiserveroptions.NewDelegatingAuthenticationOptions(),
 		Authorization:  apiserveroptions.NewDelegatingAuthorizationOptions(),
@@ -106,6 +110,9 @@ func (s *FireflyControllerManagerOptions) Flags(allControllers []string, disable
 	fss := cliflag.NamedFlagSets{}
 	s.Generic.AddFlags(&fss, allControllers, disabledByDefaultControllers)
 
+	fss.FlagSet("karmada access controller").StringSliceVar(&s.KarmadaAccessController.AllowedSystemSubjects, "karmada-access-allowed-system-subjects", s.KarmadaAccessController.AllowedSystemSubjects,
+		"The users and groups with the system: prefix which a KarmadaAccess can be issued for, e.g. system:monitoring. The system:masters group is never allowed.")
+
 	s.SecureServing.AddFlags(fss.FlagSet("secure serving"))
 	s.Authentication.AddFlags(fss.FlagSet("authentication"))
 	s.Authorization.AddFlags(fss.FlagSet("authorization"))
@@ -125,6 +132,7 @@ func (s *FireflyControllerManagerOptions) ApplyTo(c *fireflycontrollerconfig.Con
 	if err := s.Generic.ApplyTo(&c.ComponentConfig.Generic); err != nil {
 		return err
 	}
+	c.ComponentConfig.KarmadaAccessController = *s.KarmadaAccessController
 	if err := s.SecureServing.ApplyTo(&c.SecureServing, &c.LoopbackClientConfig); err != nil {
 		return err
 	}
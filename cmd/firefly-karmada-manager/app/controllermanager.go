@@ -66,6 +66,7 @@ import (
 	fireflyinformers "github.com/carlory/firefly/pkg/generated/informers/externalversions"
 	fireflyctrlmgrconfig "github.com/carlory/firefly/pkg/karmada/controller/apis/config"
 	karmadafireflyinformers "github.com/carlory/firefly/pkg/karmada/generated/informers/externalversions"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
 )
 
 func init() {
@@ -164,6 +165,7 @@ func Run(c *config.CompletedConfig, stopCh <-chan struct{}) error {
 	c.EventBroadcaster.StartStructuredLogging(0)
 	c.EventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: c.KarmadaKubeClient.CoreV1().Events("")})
 	defer c.EventBroadcaster.Shutdown()
+	clientutil.SetDriftEventRecorder(c.EventRecorder)
 
 	if cfgz, err := configz.New(ConfigzName); err == nil {
 		cfgz.Set(c.ComponentConfig)
@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilpointer "k8s.io/utils/pointer"
 )
 
@@ -79,4 +82,8 @@ func SetDefaults_Clusterpedia(obj *Clusterpedia) {
 	if synchro.Replicas == nil {
 		synchro.Replicas = utilpointer.Int32(1)
 	}
+
+	if monitoring := obj.Spec.Monitoring; monitoring != nil && monitoring.Interval == nil {
+		monitoring.Interval = &metav1.Duration{Duration: 30 * time.Second}
+	}
 }
@@ -10,6 +10,7 @@ import (
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:resource:path=clusterpedias
+// +kubebuilder:subresource:status
 
 // Clusterpedia is a specification for a Clusterpedia resource
 type Clusterpedia struct {
@@ -60,6 +61,29 @@ type ClusterpediaSpec struct {
 	// +optional
 	ImageRepository string `json:"imageRepository,omitempty"`
 
+	// ImageOverrides maps the name of a component to the full reference of its image, which may
+	// be pinned by a digest. The override of a component takes precedence over the ImageMeta of
+	// the component. The names are clusterpedia-apiserver, clusterpedia-controller-manager,
+	// clusterpedia-clustersynchro-manager, clusterpedia-internalstorage-postgres and
+	// clusterpedia-internalstorage-mysql.
+	// +optional
+	ImageOverrides map[string]string `json:"imageOverrides,omitempty"`
+
+	// ImagePullPolicy is the default pull policy of the images of all the components.
+	// If empty, the images are pulled if they are not present.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// ImagePullSecrets are the secrets used to pull the images of all the components,
+	// e.g. from a private registry.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Placement is the default placement of the pods of all the components of the clusterpedia.
+	// Each constraint can be overridden by the placement of a component.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
 	// FeatureGates enabled by the user.
 	// If you don't know that a feature gate should be applied to which components, you can
 	// use this field to enable or disable the feature gate for all the components of the clusterpedia instance.
@@ -67,6 +91,23 @@ type ClusterpediaSpec struct {
 	// Note: the clusterpedia community doesn't support this field now. Please use component-specific feature gate settings.
 	// +optional
 	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// Paused stops the reconciliation of the clusterpedia, so the objects of the clusterpedia can be
+	// changed by hand without being reverted. The deletion of the clusterpedia is still handled.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Plan computes the changes which the reconciliation of the spec would make to the objects of
+	// the clusterpedia and reports them in status.plan, without applying them. The reconciliation
+	// is paused while it is set.
+	// +optional
+	Plan bool `json:"plan,omitempty"`
+
+	// Monitoring enables the monitoring of the components of the clusterpedia by the prometheus operator.
+	// The PodMonitors and PrometheusRules of the clusterpedia are created in its namespace when the
+	// monitoring.coreos.com CRDs are installed in the host cluster.
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
 }
 
 // ControlplaneProvider represents where the clusterpedia crds will be deployed on.
@@ -111,6 +152,11 @@ type LocalPostgres struct {
 	// ImageMeta allows to customize the container used for postgres
 	// If empty, `docker.io/library/postgres:10` will be used by default.
 	ImageMeta `json:",inline"`
+
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the clusterpedia.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
 }
 
 //MySQL holds settings to clusterpedia-storage-mysql component of the clusterpeida.
@@ -138,6 +184,16 @@ type ClusterpediaAPIServerComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the clusterpedia.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// ExtraArgs is an extra set of flags to pass to the clusterpedia-apiserver component or
 	// override. A key in this map is the flag name as it appears on the command line except
 	// without leading dash(es).
@@ -174,6 +230,16 @@ type ClusterpediaControllerManagerComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the clusterpedia.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// ExtraArgs is an extra set of flags to pass to the clusterpedia-controller-manager component or
 	// override. A key in this map is the flag name as it appears on the command line except
 	// without leading dash(es).
@@ -210,6 +276,16 @@ type ClusterSynchroManagerComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the clusterpedia.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// ExtraArgs is an extra set of flags to pass to the clustersynchro-manager component or
 	// override. A key in this map is the flag name as it appears on the command line except
 	// without leading dash(es).
@@ -248,8 +324,20 @@ type ClusterpediaStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Plan is the summary of the pending changes which is computed when spec.plan is set.
+	// +optional
+	Plan *PlanStatus `json:"plan,omitempty"`
 }
 
+// These are valid conditions of a clusterpedia.
+const (
+	// ClusterpediaConditionPaused means the reconciliation of the clusterpedia is paused by spec.paused or spec.plan.
+	ClusterpediaConditionPaused = "Paused"
+	// ClusterpediaConditionMonitoring means the monitoring objects of the clusterpedia are created.
+	ClusterpediaConditionMonitoring = "Monitoring"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ClusterpediaList is a list of Clusterpedia resources
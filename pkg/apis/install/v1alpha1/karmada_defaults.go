@@ -17,6 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	utilpointer "k8s.io/utils/pointer"
 )
@@ -37,13 +41,91 @@ func SetDefaults_Karmada(obj *Karmada) {
 		obj.Spec.KubernetesVersion = "v1.21.7"
 	}
 	if obj.Spec.KarmadaVersion == "" {
-		obj.Spec.KarmadaVersion = "v1.2.0"
+		obj.Spec.KarmadaVersion = "v1.3.0"
 	}
 
 	if obj.Spec.ImageRepository == "" {
 		obj.Spec.ImageRepository = "ghcr.io/carlory"
 	}
 
+	if obj.Spec.Etcd.External == nil {
+		if obj.Spec.Etcd.Local == nil {
+			obj.Spec.Etcd.Local = &LocalEtcd{}
+		}
+		if obj.Spec.Etcd.Local.Replicas == nil {
+			obj.Spec.Etcd.Local.Replicas = utilpointer.Int32(1)
+		}
+		if obj.Spec.Etcd.Local.DataVolumeDeletionPolicy == "" {
+			obj.Spec.Etcd.Local.DataVolumeDeletionPolicy = DataVolumeDeletionPolicyRetain
+		}
+	}
+
+	if audit := obj.Spec.APIServer.KubeAPIServer.Audit; audit != nil {
+		if log := audit.Log; log != nil {
+			if log.VolumeDeletionPolicy == "" {
+				log.VolumeDeletionPolicy = DataVolumeDeletionPolicyRetain
+			}
+			if log.MaxAge == nil {
+				log.MaxAge = utilpointer.Int32(30)
+			}
+			if log.MaxBackups == nil {
+				log.MaxBackups = utilpointer.Int32(10)
+			}
+			if log.MaxSize == nil {
+				log.MaxSize = utilpointer.Int32(100)
+			}
+			if log.Format == "" {
+				log.Format = "json"
+			}
+		}
+		if webhook := audit.Webhook; webhook != nil {
+			if webhook.Mode == "" {
+				webhook.Mode = "batch"
+			}
+			if webhook.InitialBackoff == nil {
+				webhook.InitialBackoff = &metav1.Duration{Duration: 10 * time.Second}
+			}
+		}
+	}
+
+	if authn := obj.Spec.APIServer.KubeAPIServer.Authentication; authn != nil {
+		if oidc := authn.OIDC; oidc != nil {
+			if oidc.UsernameClaim == "" {
+				oidc.UsernameClaim = "sub"
+			}
+			if oidc.UsernamePrefix == "" {
+				oidc.UsernamePrefix = "oidc:"
+			}
+			if oidc.GroupsClaim != "" && oidc.GroupsPrefix == "" {
+				oidc.GroupsPrefix = "oidc:"
+			}
+		}
+		if webhook := authn.Webhook; webhook != nil {
+			if webhook.CacheTTL == nil {
+				webhook.CacheTTL = &metav1.Duration{Duration: 2 * time.Minute}
+			}
+			if webhook.Version == "" {
+				webhook.Version = "v1"
+			}
+		}
+	}
+
+	certificates := &obj.Spec.Certificates
+	if certificates.Validity == nil {
+		certificates.Validity = &metav1.Duration{Duration: 365 * 24 * time.Hour}
+	}
+	if certificates.RenewBefore == nil {
+		certificates.RenewBefore = &metav1.Duration{Duration: 30 * 24 * time.Hour}
+	}
+	if issuer := certificates.IssuerRef; issuer != nil {
+		if issuer.Kind == "" {
+			issuer.Kind = "Issuer"
+		}
+		if issuer.Group == "" {
+			issuer.Group = "cert-manager.io"
+		}
+	}
+
 	network := &obj.Spec.Networking
 	if network.DNSDomain == "" {
 		network.DNSDomain = "cluster.local"
@@ -56,6 +138,9 @@ func SetDefaults_Karmada(obj *Karmada) {
 	if apiServer.KubeAPIServer.Replicas == nil {
 		apiServer.KubeAPIServer.Replicas = utilpointer.Int32(1)
 	}
+	if apiServer.KubeAPIServer.ServiceType == "" {
+		apiServer.KubeAPIServer.ServiceType = corev1.ServiceTypeClusterIP
+	}
 	if apiServer.KarmadaAggregratedAPIServer.Replicas == nil {
 		apiServer.KarmadaAggregratedAPIServer.Replicas = utilpointer.Int32(1)
 	}
@@ -92,4 +177,8 @@ func SetDefaults_Karmada(obj *Karmada) {
 	if scheduler.KarmadaSchedulerEstimator.Replicas == nil {
 		scheduler.KarmadaSchedulerEstimator.Replicas = utilpointer.Int32(1)
 	}
+
+	if monitoring := obj.Spec.Monitoring; monitoring != nil && monitoring.Interval == nil {
+		monitoring.Interval = &metav1.Duration{Duration: 30 * time.Second}
+	}
 }
@@ -19,10 +19,15 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.karmadaVersion"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Karmada is a specification for a Karmada resource
 type Karmada struct {
@@ -51,18 +56,24 @@ type KarmadaSpec struct {
 	Networking Networking `json:"networking,omitempty"`
 
 	// KubernetesVersion is the target version of the kube-apiserver component.
+	// Changing it upgrades the running components step by step, see status.upgrade.
 	// +optional
 	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
 
-	// KarmadaVersion is the target version of the karmada.
+	// KarmadaVersion is the target version of the karmada. Karmada v1.3 is supported, the older versions
+	// are installed with the crds of karmada v1.3 so that they can be upgraded.
+	// Changing it upgrades the running components step by step, see status.upgrade.
+	// Defaults to v1.3.0.
 	// +optional
 	KarmadaVersion string `json:"karmadaVersion,omitempty"`
 
 	// ControlPlaneEndpoint sets a stable IP address or DNS name for the control plane; it
 	// can be a valid IP address or a RFC-1123 DNS subdomain, both with optional TCP port.
-	// In case the ControlPlaneEndpoint is not specified, the AdvertiseAddress + BindPort
-	// are used; in case the ControlPlaneEndpoint is specified but without a TCP port,
-	// the BindPort is used.
+	// In case the ControlPlaneEndpoint is not specified, the address of the karmada-apiserver
+	// service is used according to its service type; in case the ControlPlaneEndpoint is
+	// specified but without a TCP port, the secure port 5443 is used.
+	// The endpoint is added to the SANs of the karmada-apiserver certificate and is used as the
+	// server of the external kubeconfig of the karmada.
 	// Possible usages are:
 	// e.g. In a cluster with more than one control plane instances, this field should be
 	// assigned the address of the external load balancer in front of the
@@ -88,6 +99,10 @@ type KarmadaSpec struct {
 	// +optional
 	Scheduler SchedulerComponent `json:"scheduler,omitempty"`
 
+	// Certificates holds configuration for the certificates of the karmada.
+	// +optional
+	Certificates Certificates `json:"certificates,omitempty"`
+
 	// ImageRepository sets the container registry to pull images from.
 	// If empty, `ghcr.io/carlory` will be used by default.
 	// +optional
@@ -99,6 +114,31 @@ type KarmadaSpec struct {
 	// +optional
 	KubeImageRepository string `json:"kubeImageRepository,omitempty"`
 
+	// ImageOverrides maps the name of a component to the full reference of its image, which may
+	// be pinned by a digest, e.g. `karmada-apiserver: registry.local/kube-apiserver@sha256:...`.
+	// The override of a component takes precedence over the ImageMeta of the component.
+	// The names are etcd, karmada-apiserver, karmada-aggregated-apiserver, karmada-webhook,
+	// karmada-kube-controller-manager, karmada-controller-manager, firefly-karmada-manager,
+	// karmada-scheduler, karmada-descheduler and karmada-scheduler-estimator.
+	// +optional
+	ImageOverrides map[string]string `json:"imageOverrides,omitempty"`
+
+	// ImagePullPolicy is the default pull policy of the images of all the components.
+	// If empty, the images are pulled if they are not present, except the firefly-karmada-manager
+	// image which is always pulled.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// ImagePullSecrets are the secrets used to pull the images of all the components,
+	// e.g. from a private registry.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Placement is the default placement of the pods of all the components of the karmada.
+	// Each constraint can be overridden by the placement of a component.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
 	// FeatureGates enabled by the user.
 	// If you don't know that a feature gate should be applied to which components, you can
 	// use this field to enable or disable the feature gate for all the components of the karmada instance.
@@ -109,6 +149,23 @@ type KarmadaSpec struct {
 	// More info: https://github.com/karmada-io/karmada/blob/master/pkg/features/features.go
 	// +optional
 	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// Paused stops the reconciliation of the karmada, so the objects of the karmada can be changed
+	// by hand without being reverted. The deletion of the karmada is still handled.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Plan computes the changes which the reconciliation of the spec would make to the objects of
+	// the karmada and reports them in status.plan, without applying them. The reconciliation is
+	// paused while it is set.
+	// +optional
+	Plan bool `json:"plan,omitempty"`
+
+	// Monitoring enables the monitoring of the components of the karmada by the prometheus operator.
+	// The ServiceMonitors, PodMonitors and PrometheusRules of the karmada are created in its namespace
+	// when the monitoring.coreos.com CRDs are installed in the host cluster.
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
 }
 
 // Etcd contains elements describing Etcd configuration.
@@ -129,11 +186,38 @@ type LocalEtcd struct {
 	// ImageMeta allows to customize the container used for etcd
 	ImageMeta `json:",inline"`
 
+	// Replicas is the number of members of the etcd cluster. An odd number of members
+	// is recommended so that the cluster can tolerate (replicas-1)/2 failed members.
+	// Members are added or removed one at a time when the replicas changes.
+	// Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the karmada.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the etcd members. It only takes effect when there is more than one member. Unless MinAvailable or
+	// MaxUnavailable is set, a quorum of the members, i.e. more than half of them, must be available.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// DataVolume is the volume etcd will place its data.
 	// If empty, etcd will use an emptyDir.
+	// Each member of the etcd cluster gets its own persistent volume claim created from the template.
+	// The template can't be changed once the etcd cluster has been created.
 	// +optional
 	DataVolume *corev1.PersistentVolumeClaimTemplate `json:"dataVolume"`
 
+	// DataVolumeDeletionPolicy describes what happens to the persistent volume claims of the etcd
+	// members when the karmada is deleted. Valid values are Retain and Delete.
+	// Defaults to Retain.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +optional
+	DataVolumeDeletionPolicy DataVolumeDeletionPolicy `json:"dataVolumeDeletionPolicy,omitempty"`
+
 	// ServerCertSANs sets extra Subject Alternative Names for the etcd server signing cert.
 	// +optional
 	ServerCertSANs []string `json:"serverCertSANs,omitempty"`
@@ -143,6 +227,17 @@ type LocalEtcd struct {
 	PeerCertSANs []string `json:"peerCertSANs,omitempty"`
 }
 
+// DataVolumeDeletionPolicy describes what happens to the data volumes of the etcd cluster
+// when the karmada is deleted.
+type DataVolumeDeletionPolicy string
+
+const (
+	// DataVolumeDeletionPolicyRetain means the persistent volume claims are kept when the karmada is deleted.
+	DataVolumeDeletionPolicyRetain DataVolumeDeletionPolicy = "Retain"
+	// DataVolumeDeletionPolicyDelete means the persistent volume claims are deleted with the karmada.
+	DataVolumeDeletionPolicyDelete DataVolumeDeletionPolicy = "Delete"
+)
+
 // ExternalEtcd describes an external etcd cluster.
 // Firefly has no knowledge of where certificate files live and they must be supplied.
 type ExternalEtcd struct {
@@ -150,16 +245,89 @@ type ExternalEtcd struct {
 	Endpoints []string `json:"endpoints"`
 
 	// CAData is an SSL Certificate Authority file used to secure etcd communication.
-	// Required if using a TLS connection.
-	CAData []byte `json:"caData"`
+	// Required if using a TLS connection and SecretRef is not set.
+	// +optional
+	CAData []byte `json:"caData,omitempty"`
 
 	// CertData is an SSL certification file used to secure etcd communication.
-	// Required if using a TLS connection.
-	CertData []byte `json:"certData"`
+	// Required if using a TLS connection and SecretRef is not set.
+	// +optional
+	CertData []byte `json:"certData,omitempty"`
 
 	// KeyData is an SSL key file used to secure etcd communication.
-	// Required if using a TLS connection.
-	KeyData []byte `json:"keyData"`
+	// Required if using a TLS connection and SecretRef is not set.
+	// +optional
+	KeyData []byte `json:"keyData,omitempty"`
+
+	// SecretRef references a secret in the namespace of the karmada which holds the
+	// certificates used to secure etcd communication. The secret is expected to contain
+	// the `ca.crt`, `tls.crt` and `tls.key` keys. Data in the secret takes precedence
+	// over CAData, CertData and KeyData.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// Certificates contains elements describing the lifecycle of the certificates of the karmada.
+// The CA certificates are generated once and persisted in the karmada-cert secret, and the leaf
+// certificates are issued again when they are about to expire or their subjects are changed.
+type Certificates struct {
+	// Validity is the duration for which the leaf certificates are valid.
+	// Defaults to 8760h (365 days).
+	// +optional
+	Validity *metav1.Duration `json:"validity,omitempty"`
+
+	// RenewBefore is the duration before the expiry of a leaf certificate at which it's renewed.
+	// The components using the renewed certificates are restarted one by one.
+	// Defaults to 720h (30 days).
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// CA references the secrets which hold existing certificate authorities. The leaf certificates
+	// of an authority are signed by the referenced CA instead of a generated self-signed one.
+	// Mutually exclusive with IssuerRef.
+	// +optional
+	CA *CertificateAuthorities `json:"ca,omitempty"`
+
+	// IssuerRef references a cert-manager Issuer or ClusterIssuer from which the leaf certificates
+	// are requested. The certificate authorities are taken from the `ca.crt` of the issued secrets,
+	// and the leaf certificates are renewed by cert-manager. Because the CA keys are not available,
+	// the kube-controller-manager doesn't sign certificate signing requests.
+	// Mutually exclusive with CA.
+	// +optional
+	IssuerRef *IssuerReference `json:"issuerRef,omitempty"`
+}
+
+// CertificateAuthorities references the secrets which hold the certificate authorities of the karmada.
+// Each secret must be in the namespace of the karmada and contain the PEM encoded CA certificate and
+// private key under the `tls.crt` and `tls.key` keys. An authority which isn't referenced is generated.
+type CertificateAuthorities struct {
+	// Karmada references the CA which signs the certificates of the karmada-apiserver and its clients.
+	// +optional
+	Karmada *corev1.LocalObjectReference `json:"karmada,omitempty"`
+
+	// Etcd references the CA which signs the certificates of the local etcd and its clients.
+	// It's ignored if an external etcd is used.
+	// +optional
+	Etcd *corev1.LocalObjectReference `json:"etcd,omitempty"`
+
+	// FrontProxy references the CA which signs the client certificate of the front proxy.
+	// +optional
+	FrontProxy *corev1.LocalObjectReference `json:"frontProxy,omitempty"`
+}
+
+// IssuerReference references a cert-manager issuer.
+type IssuerReference struct {
+	// Name is the name of the issuer. An Issuer must be in the namespace of the karmada.
+	Name string `json:"name"`
+
+	// Kind is the kind of the issuer, either Issuer or ClusterIssuer.
+	// Defaults to Issuer.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group is the API group of the issuer. Defaults to "cert-manager.io".
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 // Networking contains elements describing cluster's networking configuration
@@ -194,6 +362,16 @@ type KubeAPIServerComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the karmada.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// ExtraArgs is an extra set of flags to pass to the kube-apiserver component or
 	// override. A key in this map is the flag name as it appears on the command line except
 	// without leading dash(es).
@@ -214,6 +392,29 @@ type KubeAPIServerComponent struct {
 	// +optional
 	CertSANs []string `json:"certSANs,omitempty"`
 
+	// ServiceType determines how the kube-apiserver component is exposed.
+	// Valid values are ClusterIP, NodePort and LoadBalancer. Defaults to ClusterIP.
+	// - ClusterIP: the component is only reachable from the host cluster.
+	// - NodePort: the component is reachable through the node port of any node of the host cluster.
+	// - LoadBalancer: the component is reachable through the address of the load balancer.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// NodeAddresses are the addresses of the nodes which the node port of the kube-apiserver component is
+	// reached through. They're added to the SANs of the API Server signing cert, and the first of them is
+	// used as the server of the external kubeconfig if the ControlPlaneEndpoint isn't set. If it's empty,
+	// an address of the nodes of the host cluster is used as the server instead. It isn't added to the SANs,
+	// the external kubeconfig verifies the certificate with the DNS name of the service.
+	// Only used if the ServiceType is NodePort.
+	// +optional
+	NodeAddresses []string `json:"nodeAddresses,omitempty"`
+
+	// ServiceAnnotations is an extra set of annotations to add to the service of the kube-apiserver
+	// component, e.g. to configure the load balancer.
+	// +optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+
 	// Compute Resources required by this component.
 	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
 	// +optional
@@ -223,6 +424,174 @@ type KubeAPIServerComponent struct {
 	// More info: https://kubernetes.io/docs/reference/command-line-tools-reference/kube-apiserver/
 	// +optional
 	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// Audit configures the audit logging of the kube-apiserver component, which records the requests
+	// made to the karmada, e.g. who changed the propagation policies. Audit logging is disabled if
+	// no backend is set.
+	// +optional
+	Audit *Audit `json:"audit,omitempty"`
+
+	// Authentication configures the authenticators of the kube-apiserver component besides the client
+	// certificates, so that the users can log in to the karmada with the tokens of an identity provider.
+	// +optional
+	Authentication *Authentication `json:"authentication,omitempty"`
+}
+
+// Authentication describes the token authenticators of the kube-apiserver component.
+// The authenticated users have no permissions until they are granted with RBAC in the karmada.
+// More info: https://kubernetes.io/docs/reference/access-authn-authz/authentication/
+type Authentication struct {
+	// OIDC authenticates the users with the ID tokens of an OpenID Connect provider.
+	// +optional
+	OIDC *OIDCAuthentication `json:"oidc,omitempty"`
+
+	// Webhook authenticates the bearer tokens with a remote service.
+	// +optional
+	Webhook *WebhookTokenAuthentication `json:"webhook,omitempty"`
+}
+
+// OIDCAuthentication describes the OpenID Connect token authenticator of the kube-apiserver component.
+type OIDCAuthentication struct {
+	// IssuerURL is the URL of the provider, only the https scheme is accepted.
+	// +kubebuilder:validation:Pattern=`^https://`
+	IssuerURL string `json:"issuerURL"`
+
+	// ClientID is the client ID which all the tokens must be issued for.
+	ClientID string `json:"clientID"`
+
+	// UsernameClaim is the claim of the token to use as the user name. Defaults to sub.
+	// +optional
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+
+	// UsernamePrefix is prepended to the user names to prevent clashes with the existing names,
+	// such as system: users. Set it to "-" to disable the prefixing. Defaults to "oidc:".
+	// +optional
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+
+	// GroupsClaim is the claim of the token to use as the groups of the user.
+	// +optional
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// GroupsPrefix is prepended to the group names to prevent clashes with the existing names,
+	// such as system: groups. Defaults to "oidc:" if the groups claim is set.
+	// +optional
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+
+	// RequiredClaims are the claims which must be present in the tokens with the matching values.
+	// +optional
+	RequiredClaims map[string]string `json:"requiredClaims,omitempty"`
+
+	// SigningAlgs are the signing algorithms of the tokens which are accepted. Defaults to RS256.
+	// +optional
+	SigningAlgs []string `json:"signingAlgs,omitempty"`
+
+	// CASecretRef selects the key of a secret in the namespace of the karmada which holds the CA
+	// certificate that the serving certificate of the provider is verified with.
+	// If empty, the system trust roots are used.
+	// +optional
+	CASecretRef *corev1.SecretKeySelector `json:"caSecretRef,omitempty"`
+}
+
+// WebhookTokenAuthentication describes the webhook token authenticator of the kube-apiserver component.
+type WebhookTokenAuthentication struct {
+	// ConfigSecretRef references a secret in the namespace of the karmada which holds the kubeconfig
+	// of the remote service under its kubeconfig key.
+	ConfigSecretRef corev1.LocalObjectReference `json:"configSecretRef"`
+
+	// CacheTTL is the duration to cache the responses of the remote service. Defaults to 2m.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+
+	// Version is the API version of the TokenReview objects sent to and expected from the remote
+	// service. Valid values are v1 and v1beta1. Defaults to v1.
+	// +kubebuilder:validation:Enum=v1;v1beta1
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// Audit describes the audit logging of the kube-apiserver component.
+// More info: https://kubernetes.io/docs/tasks/debug/debug-cluster/audit/
+type Audit struct {
+	// Policy defines which events are recorded and what data they include.
+	// If empty, firefly uses a policy suited to multi-cluster operations: the changes of the karmada
+	// policies, cluster objects and RBAC rules are recorded with their request and response bodies,
+	// the other changes only with their metadata, and the read-only requests aren't recorded.
+	// +optional
+	Policy *AuditPolicy `json:"policy,omitempty"`
+
+	// Log writes the events to files on a volume mounted into the pods of the kube-apiserver component.
+	// +optional
+	Log *AuditLogBackend `json:"log,omitempty"`
+
+	// Webhook sends the events to a remote API.
+	// +optional
+	Webhook *AuditWebhookBackend `json:"webhook,omitempty"`
+}
+
+// AuditPolicy is the source of an audit policy. Exactly one of its fields must be set.
+type AuditPolicy struct {
+	// Inline is an audit.k8s.io/v1 Policy in YAML.
+	// +optional
+	Inline string `json:"inline,omitempty"`
+
+	// ConfigMapRef selects the key of a ConfigMap in the namespace of the karmada which holds
+	// an audit.k8s.io/v1 Policy in YAML.
+	// +optional
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+}
+
+// AuditLogBackend describes the log backend of the audit logging.
+type AuditLogBackend struct {
+	// Volume is the volume the audit logs are written to.
+	// If empty, an emptyDir is used and the logs are lost when the pods are deleted.
+	// The persistent volume claim created from the template is shared by the pods of the
+	// kube-apiserver component, each of which writes its own file, so it must support the
+	// ReadWriteMany access mode if the component has more than one replica.
+	// The template can't be changed once the claim has been created.
+	// +optional
+	Volume *corev1.PersistentVolumeClaimTemplate `json:"volume,omitempty"`
+
+	// VolumeDeletionPolicy describes what happens to the persistent volume claim of the audit logs
+	// when the karmada is deleted. Valid values are Retain and Delete.
+	// Defaults to Retain.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +optional
+	VolumeDeletionPolicy DataVolumeDeletionPolicy `json:"volumeDeletionPolicy,omitempty"`
+
+	// MaxAge is the maximum number of days to retain the rotated log files. Defaults to 30.
+	// +optional
+	MaxAge *int32 `json:"maxAge,omitempty"`
+
+	// MaxBackups is the maximum number of the rotated log files to retain. Defaults to 10.
+	// +optional
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+
+	// MaxSize is the maximum size in megabytes of a log file before it gets rotated. Defaults to 100.
+	// +optional
+	MaxSize *int32 `json:"maxSize,omitempty"`
+
+	// Format of the saved audits. Valid values are json and legacy. Defaults to json.
+	// +kubebuilder:validation:Enum=json;legacy
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+// AuditWebhookBackend describes the webhook backend of the audit logging.
+type AuditWebhookBackend struct {
+	// ConfigSecretRef references a secret in the namespace of the karmada which holds the kubeconfig
+	// of the remote API under its kubeconfig key.
+	ConfigSecretRef corev1.LocalObjectReference `json:"configSecretRef"`
+
+	// Mode is the strategy for sending the events. Valid values are batch, blocking and blocking-strict.
+	// Defaults to batch, so that a slow or unavailable webhook doesn't block the requests to the karmada.
+	// +kubebuilder:validation:Enum=batch;blocking;blocking-strict
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// InitialBackoff is the amount of time to wait before retrying the first failed request.
+	// Defaults to 10s.
+	// +optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
 }
 
 // KarmadaAggregratedAPIServerComponent holds settings to karmada-aggregated-apiserver component of the karmada.
@@ -235,6 +604,16 @@ type KarmadaAggregratedAPIServerComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the karmada.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// ExtraArgs is an extra set of flags to pass to the karmada-aggregated-apiserver component or
 	// override. A key in this map is the flag name as it appears on the command line except
 	// without leading dash(es).
@@ -273,6 +652,16 @@ type KarmadaWebhookComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the karmada.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// ExtraArgs is an extra set of flags to pass to the karmada-webhook component or
 	// override. A key in this map is the flag name as it appears on the command line except
 	// without leading dash(es).
@@ -319,6 +708,16 @@ type KubeControllerManagerComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the karmada.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// A list of controllers to enable. '*' enables all on-by-default controllers,
 	// 'foo' enables the controller named 'foo', '-foo' disables the controller named
 	// 'foo'.
@@ -393,6 +792,16 @@ type KarmadaControllerManagerComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the karmada.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// A list of controllers to enable. '*' enables all on-by-default controllers,
 	// 'foo' enables the controller named 'foo', '-foo' disables the controller named
 	// 'foo'.
@@ -440,6 +849,16 @@ type FireflyKarmadaManagerComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the karmada.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// A list of controllers to enable. '*' enables all on-by-default controllers,
 	// 'foo' enables the controller named 'foo', '-foo' disables the controller named
 	// 'foo'.
@@ -481,6 +900,16 @@ type KarmadaSchedulerComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the karmada.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// ExtraArgs is an extra set of flags to pass to the karmada-scheduler component or override.
 	// A key in this map is the flag name as it appears on the command line except without
 	// leading dash(es).
@@ -519,6 +948,16 @@ type KarmadaDeschedulerComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the karmada.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// ExtraArgs is an extra set of flags to pass to the karmada-descheduler component or override.
 	// A key in this map is the flag name as it appears on the command line except without
 	// leading dash(es).
@@ -551,6 +990,16 @@ type KarmadaSchedulerEstimatorComponent struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Placement describes how the pods of the component are scheduled. The constraints which
+	// aren't set fall back to the default placement of the karmada.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// PodDisruptionBudget describes the PodDisruptionBudget which limits the voluntary disruptions of
+	// the pods of the component. It only takes effect when the component has more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// ExtraArgs is an extra set of flags to pass to the karmada-scheduler-estimator component or override.
 	// A key in this map is the flag name as it appears on the command line except without
 	// leading dash(es).
@@ -573,6 +1022,64 @@ type KarmadaSchedulerEstimatorComponent struct {
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
+// Placement describes how the pods of a component are scheduled onto the nodes of the host cluster.
+type Placement struct {
+	// NodeSelector must match the labels of a node for the pods to be scheduled onto it.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow the pods to be scheduled onto the nodes with matching taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity sets the scheduling constraints of the pods.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the pods.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// TopologySpreadConstraints describes how the pods are spread across the topology domains.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// PodDisruptionBudgetPolicy describes the PodDisruptionBudget of a replicated component.
+type PodDisruptionBudgetPolicy struct {
+	// Enable indicates whether the PodDisruptionBudget is created for the component.
+	// Defaults to true.
+	// +optional
+	Enable *bool `json:"enable,omitempty"`
+
+	// MinAvailable is the number or percentage of the pods which must be available after an eviction.
+	// It is mutually exclusive with MaxUnavailable.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of the pods which can be unavailable after an eviction.
+	// It is mutually exclusive with MinAvailable. Defaults to 1 if neither is set.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// Monitoring describes how the components of an instance are monitored by the prometheus operator.
+type Monitoring struct {
+	// Labels are added to the ServiceMonitors, PodMonitors and PrometheusRules, so that they are
+	// selected by a Prometheus.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Interval is the interval at which the metrics of the components are scraped.
+	// Defaults to 30s.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// DisableAlerts disables the PrometheusRule of the default alerts.
+	// +optional
+	DisableAlerts bool `json:"disableAlerts,omitempty"`
+}
+
 // ImageMeta allows to customize the image used for components.
 type ImageMeta struct {
 	// ImageRepository sets the container registry to pull images from.
@@ -589,6 +1096,16 @@ type ImageMeta struct {
 	// ImageName allows to specify a name for the image.
 	// +optional
 	ImageName string `json:"imageName,omitempty"`
+
+	// ImagePullPolicy is the pull policy of the image.
+	// if not set, the ImagePullPolicy defined in Spec will be used instead.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// ImagePullSecrets are the secrets used to pull the image, in addition to the
+	// ImagePullSecrets defined in Spec.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
 
 // KarmadaStatus is the status for a Karmada resource
@@ -602,8 +1119,185 @@ type KarmadaStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Certificates is the list of the leaf certificates of the karmada and their expiry.
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
+	// KarmadaVersion is the version of karmada which all the karmada components are running.
+	// +optional
+	KarmadaVersion string `json:"karmadaVersion,omitempty"`
+
+	// KubernetesVersion is the version of kubernetes which all the kubernetes components are running.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// Upgrade is the progress of the latest upgrade of the karmada.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// Plan is the summary of the pending changes which is computed when spec.plan is set.
+	// +optional
+	Plan *PlanStatus `json:"plan,omitempty"`
+}
+
+// PlanStatus is the summary of the changes which the reconciliation of the spec would make.
+type PlanStatus struct {
+	// ObservedGeneration is the generation of the spec which the plan was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Changes is the list of the objects which would be created, updated or deleted.
+	// +optional
+	Changes []PlannedChange `json:"changes,omitempty"`
+
+	// Message explains why the plan is incomplete, e.g. a component which doesn't exist yet is
+	// needed to compute the changes of the following components.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PlannedChange describes a change of an object which would be made by the reconciliation.
+type PlannedChange struct {
+	// Action is the action which would be taken on the object.
+	Action PlannedAction `json:"action"`
+
+	// Server is the address of the API server which serves the object, e.g. the karmada-apiserver.
+	// It is empty for the objects of the host cluster.
+	// +optional
+	Server string `json:"server,omitempty"`
+
+	// Resource is the resource of the object, e.g. deployments.apps.
+	Resource string `json:"resource"`
+
+	// Namespace is the namespace of the object, empty for the cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the object.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Fields is the list of the paths of the fields which would be changed by an update.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+}
+
+// PlannedAction is an action which would be taken on an object.
+type PlannedAction string
+
+// These are valid planned actions.
+const (
+	// PlannedActionCreate means the object would be created.
+	PlannedActionCreate PlannedAction = "Create"
+	// PlannedActionUpdate means the object would be updated.
+	PlannedActionUpdate PlannedAction = "Update"
+	// PlannedActionDelete means the object would be deleted.
+	PlannedActionDelete PlannedAction = "Delete"
+)
+
+// UpgradePhase is the phase of an upgrade of the karmada.
+type UpgradePhase string
+
+// These are valid phases of an upgrade.
+const (
+	// UpgradePhaseProgressing means the components are being upgraded to the target versions step by step.
+	UpgradePhaseProgressing UpgradePhase = "Progressing"
+	// UpgradePhaseSucceeded means all the components are running the target versions.
+	UpgradePhaseSucceeded UpgradePhase = "Succeeded"
+	// UpgradePhaseRollingBack means a step of the upgrade failed and the upgraded components are being
+	// rolled back to the previous versions in the reverse order.
+	UpgradePhaseRollingBack UpgradePhase = "RollingBack"
+	// UpgradePhaseRolledBack means all the components are running the previous versions again.
+	UpgradePhaseRolledBack UpgradePhase = "RolledBack"
+	// UpgradePhaseFailed means the preflight checks of the upgrade failed and nothing was upgraded.
+	UpgradePhaseFailed UpgradePhase = "Failed"
+)
+
+// UpgradeStep is a group of components which are upgraded together.
+type UpgradeStep string
+
+// These are the steps of an upgrade in the order they are executed. The etcd cluster isn't versioned
+// by the karmada and kubernetes versions, so it isn't upgraded.
+const (
+	// UpgradeStepKubeAPIServer upgrades the karmada-apiserver component.
+	UpgradeStepKubeAPIServer UpgradeStep = "KubeAPIServer"
+	// UpgradeStepAggregatedAPIServer upgrades the karmada-aggregated-apiserver and karmada-webhook
+	// components, and the karmada crds.
+	UpgradeStepAggregatedAPIServer UpgradeStep = "AggregatedAPIServer"
+	// UpgradeStepControllerManagers upgrades the controller manager components.
+	UpgradeStepControllerManagers UpgradeStep = "ControllerManagers"
+	// UpgradeStepSchedulers upgrades the scheduler components.
+	UpgradeStepSchedulers UpgradeStep = "Schedulers"
+)
+
+// UpgradeStatus describes the progress of an upgrade of the karmada.
+type UpgradeStatus struct {
+	// FromKarmadaVersion is the karmada version before the upgrade.
+	FromKarmadaVersion string `json:"fromKarmadaVersion"`
+
+	// FromKubernetesVersion is the kubernetes version before the upgrade.
+	FromKubernetesVersion string `json:"fromKubernetesVersion"`
+
+	// ToKarmadaVersion is the target karmada version of the upgrade.
+	ToKarmadaVersion string `json:"toKarmadaVersion"`
+
+	// ToKubernetesVersion is the target kubernetes version of the upgrade.
+	ToKubernetesVersion string `json:"toKubernetesVersion"`
+
+	// Phase is the phase of the upgrade.
+	Phase UpgradePhase `json:"phase"`
+
+	// Step is the step which is being upgraded or rolled back.
+	// +optional
+	Step UpgradeStep `json:"step,omitempty"`
+
+	// StepStartTime is the time at which the current step started.
+	// +optional
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
+
+	// Message is a human readable message indicating details about the upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
+// CertificateStatus describes the state of a leaf certificate of the karmada.
+type CertificateStatus struct {
+	// Name is the name of the certificate, e.g. apiserver.
+	Name string `json:"name"`
+
+	// NotAfter is the time at which the certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
+// These are valid conditions of a karmada.
+const (
+	// KarmadaConditionReady means all the components of the karmada are ready.
+	KarmadaConditionReady = "Ready"
+	// KarmadaConditionCertsReady means the certificates of the karmada have been generated.
+	KarmadaConditionCertsReady = "CertsReady"
+	// KarmadaConditionEtcdReady means the built-in etcd cluster is ready.
+	KarmadaConditionEtcdReady = "EtcdReady"
+	// KarmadaConditionKubeAPIServerReady means the karmada-apiserver component is ready.
+	KarmadaConditionKubeAPIServerReady = "KubeAPIServerReady"
+	// KarmadaConditionAggregatedAPIServerReady means the karmada-aggregated-apiserver component is ready.
+	KarmadaConditionAggregatedAPIServerReady = "AggregatedAPIServerReady"
+	// KarmadaConditionCRDsReady means the karmada crds have been installed into the karmada-apiserver.
+	KarmadaConditionCRDsReady = "CRDsReady"
+	// KarmadaConditionWebhookReady means the karmada-webhook component is ready.
+	KarmadaConditionWebhookReady = "WebhookReady"
+	// KarmadaConditionControllerManagersReady means all the controller manager components are ready.
+	KarmadaConditionControllerManagersReady = "ControllerManagersReady"
+	// KarmadaConditionSchedulerReady means all the scheduler components are ready.
+	KarmadaConditionSchedulerReady = "SchedulerReady"
+	// KarmadaConditionUpgrading means the components of the karmada are being upgraded or rolled back.
+	KarmadaConditionUpgrading = "Upgrading"
+	// KarmadaConditionPaused means the reconciliation of the karmada is paused by spec.paused or spec.plan.
+	KarmadaConditionPaused = "Paused"
+	// KarmadaConditionMonitoring means the monitoring objects of the karmada are created.
+	KarmadaConditionMonitoring = "Monitoring"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // KarmadaList is a list of Karmada resources
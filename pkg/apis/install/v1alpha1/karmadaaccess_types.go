@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Karmada",type="string",JSONPath=".spec.karmadaName"
+// +kubebuilder:printcolumn:name="User",type="string",JSONPath=".status.user"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Expires",type="date",JSONPath=".status.expirationTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// KarmadaAccess issues a kubeconfig of a Karmada for a user or a group, with a client certificate which is
+// valid for a limited time, and binds RBAC roles to them in the karmada-apiserver.
+type KarmadaAccess struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of the KarmadaAccess.
+	// +optional
+	Spec KarmadaAccessSpec `json:"spec"`
+	// Most recently observed status of the KarmadaAccess.
+	// +optional
+	Status KarmadaAccessStatus `json:"status"`
+}
+
+// KarmadaAccessSpec is the spec for a KarmadaAccess resource
+type KarmadaAccessSpec struct {
+	// KarmadaName is the name of the karmada in the same namespace which the access is issued for.
+	// The certificates of the karmada must be signed by its own CAs rather than a cert-manager issuer.
+	KarmadaName string `json:"karmadaName"`
+
+	// User is the name of the user, it's the common name of the client certificate.
+	// Defaults to karmada-access:<namespace>:<name>. A user with the system: prefix is not allowed
+	// unless the controller manager allows it by --karmada-access-allowed-system-subjects.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// Groups are the groups of the user, they are the organizations of the client certificate.
+	// The groups with the system: prefix are not allowed unless the controller manager allows them by
+	// --karmada-access-allowed-system-subjects. The system:masters group is never allowed, as it bypasses
+	// the authorization.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Roles are the RBAC roles which are bound to the user and the groups in the karmada-apiserver.
+	// +optional
+	Roles []AccessRole `json:"roles,omitempty"`
+
+	// TTL is the duration for which the client certificate is valid. Changing the spec of the access
+	// starts a new TTL. Defaults to 24h.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// ExpirationPolicy is what happens when the TTL expires. Revoke deletes the kubeconfig and the role
+	// bindings, Rotate issues a new certificate for another TTL before the current one expires.
+	// Defaults to Revoke.
+	// +kubebuilder:validation:Enum=Revoke;Rotate
+	// +optional
+	ExpirationPolicy AccessExpirationPolicy `json:"expirationPolicy,omitempty"`
+
+	// SecretName is the name of the secret in the same namespace which the kubeconfig is written into,
+	// under its kubeconfig key. Defaults to <name>-kubeconfig.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// AccessRole is a RBAC role which is bound to the user and the groups of an access.
+type AccessRole struct {
+	// Kind is the kind of the role, ClusterRole or Role. Defaults to ClusterRole.
+	// +kubebuilder:validation:Enum=ClusterRole;Role
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the role.
+	Name string `json:"name"`
+
+	// Namespace is the namespace in which the role is bound. A ClusterRole is bound in all the namespaces
+	// if it isn't set, a Role must set it.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AccessExpirationPolicy is what happens when the TTL of an access expires.
+type AccessExpirationPolicy string
+
+const (
+	// AccessExpirationPolicyRevoke deletes the kubeconfig and the role bindings of the access.
+	AccessExpirationPolicyRevoke AccessExpirationPolicy = "Revoke"
+	// AccessExpirationPolicyRotate issues a new certificate before the current one expires.
+	AccessExpirationPolicyRotate AccessExpirationPolicy = "Rotate"
+)
+
+// KarmadaAccessStatus is the status for a KarmadaAccess resource
+type KarmadaAccessStatus struct {
+	// observedGeneration is the most recent generation observed for this KarmadaAccess. It corresponds to the
+	// KarmadaAccess's generation, which is updated on mutation by the API Server.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the latest available observations of an access's current state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// User is the name of the user of the issued certificate.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// SecretName is the name of the secret which holds the kubeconfig.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// ExpirationTime is the time when the TTL of the access expires.
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+}
+
+const (
+	// KarmadaAccessConditionReady means the kubeconfig of the access is issued and its roles are bound.
+	KarmadaAccessConditionReady = "Ready"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KarmadaAccessList is a list of KarmadaAccess resources
+type KarmadaAccessList struct {
+	metav1.TypeMeta `json:",inline"`
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+
+	Items []KarmadaAccess `json:"items"`
+}
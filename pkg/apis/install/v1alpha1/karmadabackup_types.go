@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Karmada",type="string",JSONPath=".spec.karmadaName"
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Last Successful",type="date",JSONPath=".status.lastSuccessfulTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// KarmadaBackup takes snapshots of the built-in etcd of a Karmada, once or on a schedule.
+type KarmadaBackup struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of the KarmadaBackup.
+	// +optional
+	Spec KarmadaBackupSpec `json:"spec"`
+	// Most recently observed status of the KarmadaBackup.
+	// +optional
+	Status KarmadaBackupStatus `json:"status"`
+}
+
+// KarmadaBackupSpec is the spec for a KarmadaBackup resource
+type KarmadaBackupSpec struct {
+	// KarmadaName is the name of the karmada in the same namespace whose etcd is backed up.
+	// The karmada must use the built-in etcd.
+	KarmadaName string `json:"karmadaName"`
+
+	// Schedule is the schedule in Cron format of the snapshots, e.g. "0 */6 * * *".
+	// If not set, a single snapshot is taken when the backup is created.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Suspend stops taking scheduled snapshots, the existing ones are kept.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Retention is the number of successful scheduled snapshots which are kept, the older ones
+	// are deleted from the storage. Defaults to 7.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Retention *int32 `json:"retention,omitempty"`
+
+	// Storage is where the snapshots are stored. The snapshots of the backup are stored under the
+	// <namespace>/<name> directory of the storage.
+	Storage BackupStorage `json:"storage"`
+}
+
+// BackupStorage is where the snapshots are stored. Exactly one of its fields must be set.
+type BackupStorage struct {
+	// PersistentVolumeClaim stores the snapshots on a persistent volume claim in the namespace of the backup.
+	// +optional
+	PersistentVolumeClaim *PersistentVolumeClaimStorage `json:"persistentVolumeClaim,omitempty"`
+
+	// S3 stores the snapshots in a bucket of an S3 compatible object storage.
+	// +optional
+	S3 *S3Storage `json:"s3,omitempty"`
+}
+
+// PersistentVolumeClaimStorage stores the snapshots on a persistent volume claim.
+type PersistentVolumeClaimStorage struct {
+	// ClaimName is the name of the persistent volume claim. It must be mountable by the pods of the
+	// backup and the etcd members when they are restored, so a restore of an etcd with more than one
+	// member fails unless the claim is ReadWriteMany or ReadOnlyMany.
+	ClaimName string `json:"claimName"`
+}
+
+// S3Storage stores the snapshots in a bucket of an S3 compatible object storage.
+type S3Storage struct {
+	// Endpoint is the url of the object storage, e.g. https://s3.amazonaws.com or http://minio.minio:9000.
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the name of the bucket, it must exist.
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the paths of the snapshots in the bucket.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Insecure skips the verification of the certificate of the endpoint.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// CredentialsSecretRef is the secret in the namespace of the backup which holds the credentials of the
+	// object storage in its AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Image is the image of the MinIO client which transfers the snapshots. Defaults to minio/mc.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// KarmadaBackupStatus is the status for a KarmadaBackup resource
+type KarmadaBackupStatus struct {
+	// observedGeneration is the most recent generation observed for this KarmadaBackup. It corresponds to the
+	// KarmadaBackup's generation, which is updated on mutation by the API Server.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastScheduleTime is the time when the latest snapshot was started.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the time when the latest successful snapshot was completed.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// Snapshots is the list of the retained snapshots and the latest failed one, oldest first.
+	// +optional
+	Snapshots []BackupSnapshot `json:"snapshots,omitempty"`
+}
+
+// BackupSnapshot is a snapshot of the etcd taken by a backup.
+type BackupSnapshot struct {
+	// Name is the name of the snapshot, its file in the storage is named <name>.db.
+	Name string `json:"name"`
+
+	// Phase is the phase of the snapshot.
+	Phase SnapshotPhase `json:"phase"`
+
+	// StartTime is the time when the snapshot was started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the snapshot was completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message is a human readable message indicating why the snapshot failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SnapshotPhase is the phase of a snapshot.
+type SnapshotPhase string
+
+const (
+	// SnapshotPhaseRunning means the snapshot is being taken.
+	SnapshotPhaseRunning SnapshotPhase = "Running"
+	// SnapshotPhaseSucceeded means the snapshot is stored in the storage.
+	SnapshotPhaseSucceeded SnapshotPhase = "Succeeded"
+	// SnapshotPhaseFailed means the snapshot couldn't be taken or stored.
+	SnapshotPhaseFailed SnapshotPhase = "Failed"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KarmadaBackupList is a list of KarmadaBackup resources
+type KarmadaBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+
+	Items []KarmadaBackup `json:"items"`
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".spec.backupName"
+// +kubebuilder:printcolumn:name="Snapshot",type="string",JSONPath=".status.snapshot"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// KarmadaRestore restores the built-in etcd of a Karmada from a snapshot of a KarmadaBackup.
+type KarmadaRestore struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of the KarmadaRestore.
+	// +optional
+	Spec KarmadaRestoreSpec `json:"spec"`
+	// Most recently observed status of the KarmadaRestore.
+	// +optional
+	Status KarmadaRestoreStatus `json:"status"`
+}
+
+// KarmadaRestoreSpec is the spec for a KarmadaRestore resource
+type KarmadaRestoreSpec struct {
+	// BackupName is the name of the backup in the same namespace whose snapshot is restored. The etcd
+	// of the karmada of the backup is restored.
+	BackupName string `json:"backupName"`
+
+	// Snapshot is the name of the snapshot which is restored, it must be a successful snapshot in the
+	// status of the backup. If not set, the latest successful snapshot is restored.
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+}
+
+// KarmadaRestoreStatus is the status for a KarmadaRestore resource
+type KarmadaRestoreStatus struct {
+	// Phase is the phase of the restore.
+	// +optional
+	Phase RestorePhase `json:"phase,omitempty"`
+
+	// Snapshot is the name of the snapshot which is restored.
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// StartTime is the time when the restore was started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the restore succeeded or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message is a human readable message indicating details about the phase of the restore.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RestorePhase is the phase of a restore.
+type RestorePhase string
+
+const (
+	// RestorePhasePending means the restore is waiting to be started.
+	RestorePhasePending RestorePhase = "Pending"
+	// RestorePhaseRestoring means the etcd is rebuilt from the snapshot.
+	RestorePhaseRestoring RestorePhase = "Restoring"
+	// RestorePhaseRestarting means the components of the karmada are restarted to use the restored data.
+	RestorePhaseRestarting RestorePhase = "Restarting"
+	// RestorePhaseSucceeded means the karmada is running with the restored data.
+	RestorePhaseSucceeded RestorePhase = "Succeeded"
+	// RestorePhaseFailed means the restore can't be done.
+	RestorePhaseFailed RestorePhase = "Failed"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KarmadaRestoreList is a list of KarmadaRestore resources
+type KarmadaRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+
+	Items []KarmadaRestore `json:"items"`
+}
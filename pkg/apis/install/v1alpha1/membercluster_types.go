@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Karmada",type="string",JSONPath=".spec.karmadaName"
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.syncMode"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.kubernetesVersion"
+// +kubebuilder:printcolumn:name="Joined",type="string",JSONPath=".status.conditions[?(@.type==\"Joined\")].status"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// MemberCluster joins a cluster to a Karmada as a member cluster.
+type MemberCluster struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of the MemberCluster.
+	// +optional
+	Spec MemberClusterSpec `json:"spec"`
+	// Most recently observed status of the MemberCluster.
+	// +optional
+	Status MemberClusterStatus `json:"status"`
+}
+
+// MemberClusterSpec is the spec for a MemberCluster resource
+type MemberClusterSpec struct {
+	// KarmadaName is the name of the karmada in the same namespace which the cluster joins.
+	KarmadaName string `json:"karmadaName"`
+
+	// ClusterName is the name of the cluster in the karmada. Defaults to the name of the MemberCluster.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// KubeconfigSecretRef is the secret in the same namespace which holds the kubeconfig of the cluster.
+	// The kubeconfig must be allowed to manage service accounts and RBAC in the cluster, as the
+	// credentials which karmada uses to access the cluster are created with it.
+	KubeconfigSecretRef KubeconfigSecretReference `json:"kubeconfigSecretRef"`
+
+	// Provider is the name of the cloud provider of the cluster.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// Region is the region of the cluster.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Zone is the zone of the cluster.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// SyncMode is how the resources of the karmada are synced to the cluster. In the Push mode, the
+	// karmada-controller-manager accesses the cluster with the credentials created by firefly. In the
+	// Pull mode, firefly deploys a karmada-agent into the cluster which pulls the resources from the
+	// karmada-apiserver, so the karmada-apiserver must be reachable from the cluster. Defaults to Push.
+	// +kubebuilder:validation:Enum=Push;Pull
+	// +optional
+	SyncMode ClusterSyncMode `json:"syncMode,omitempty"`
+
+	// Agent holds settings to the karmada-agent which is deployed into the cluster in the Pull mode.
+	// +optional
+	Agent KarmadaAgentComponent `json:"agent,omitempty"`
+}
+
+// ClusterSyncMode is the mode in which the resources of the karmada are synced to a member cluster.
+type ClusterSyncMode string
+
+const (
+	// ClusterSyncModePush means the karmada-controller-manager pushes the resources to the cluster.
+	ClusterSyncModePush ClusterSyncMode = "Push"
+	// ClusterSyncModePull means the karmada-agent in the cluster pulls the resources from the karmada.
+	ClusterSyncModePull ClusterSyncMode = "Pull"
+)
+
+// KarmadaAgentComponent holds settings to the karmada-agent of a member cluster. The image of the agent
+// follows the version of the karmada unless its tag is set. The image pull secrets of the karmada are
+// copied into the karmada-system namespace of the cluster, the ImagePullSecrets of the agent must
+// already exist in that namespace.
+type KarmadaAgentComponent struct {
+	// ImageMeta allows to customize the image used for the karmada-agent component
+	ImageMeta `json:",inline"`
+
+	// Number of desired pods. This is a pointer to distinguish between explicit
+	// zero and not specified. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Placement describes how the pods of the agent are scheduled in the member cluster. The default
+	// placement of the karmada doesn't apply to the agent, as it describes the nodes of the host cluster.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// ExtraArgs is an extra set of flags to pass to the karmada-agent component or override.
+	// A key in this map is the flag name as it appears on the command line except without
+	// leading dash(es).
+	//
+	// For supported flags, please see
+	// https://github.com/karmada-io/karmada/blob/master/cmd/agent/app/options/options.go
+	// for details.
+	// +optional
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+
+	// Compute Resources required by this component.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// KubeconfigSecretReference references a kubeconfig in a secret.
+type KubeconfigSecretReference struct {
+	// Name is the name of the secret.
+	Name string `json:"name"`
+
+	// Key is the key of the kubeconfig in the secret. Defaults to kubeconfig.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// MemberClusterStatus is the status for a MemberCluster resource
+type MemberClusterStatus struct {
+	// observedGeneration is the most recent generation observed for this MemberCluster. It corresponds to the
+	// MemberCluster's generation, which is updated on mutation by the API Server.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the latest available observations of a member cluster's current state. Besides the Joined
+	// condition, the conditions of the cluster in the karmada are mirrored.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ClusterID is the unique id of the cluster, the uid of its kube-system namespace.
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// KubernetesVersion is the kubernetes version of the cluster reported by the karmada.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// AgentVersion is the version of the karmada-agent which is running in the cluster in the Pull mode.
+	// +optional
+	AgentVersion string `json:"agentVersion,omitempty"`
+}
+
+const (
+	// MemberClusterConditionJoined means the cluster and its credentials are registered in the karmada.
+	MemberClusterConditionJoined = "Joined"
+	// MemberClusterConditionAgentReady means the karmada-agent of a cluster in the Pull mode is available.
+	MemberClusterConditionAgentReady = "AgentReady"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MemberClusterList is a list of MemberCluster resources
+type MemberClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+
+	Items []MemberCluster `json:"items"`
+}
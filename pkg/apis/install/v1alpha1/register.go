@@ -50,6 +50,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&KarmadaList{},
 		&Clusterpedia{},
 		&ClusterpediaList{},
+		&KarmadaBackup{},
+		&KarmadaBackupList{},
+		&KarmadaRestore{},
+		&KarmadaRestoreList{},
+		&MemberCluster{},
+		&MemberClusterList{},
+		&KarmadaAccess{},
+		&KarmadaAccessList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
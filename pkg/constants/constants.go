@@ -42,9 +42,58 @@ const (
 	KarmadaComponentWebhook = "karmada-webhook"
 	// KarmadaComponentSchedulerEstimator defines the name of the karmada-scheduler-estimator component
 	KarmadaComponentSchedulerEstimator = "karmada-scheduler-estimator"
+	// KarmadaComponentAgent defines the name of the karmada-agent component
+	KarmadaComponentAgent = "karmada-agent"
 	// FireflyComponentKarmadaManager defines the name of the karmada-karmada-manager component
 	FireflyComponentKarmadaManager = "firefly-karmada-manager"
 
+	// FieldManager is the name of the field manager which firefly applies objects with
+	FieldManager = "firefly"
+	// AppliedHashAnnotation records the hash of the configuration which firefly applied to an object
+	AppliedHashAnnotation = "install.firefly.io/applied-hash"
+
+	// CertificatesHashAnnotation records the hash of the certificates which the pods of a workload are started with
+	CertificatesHashAnnotation = "install.firefly.io/certificates-hash"
+	// AuditConfigHashAnnotation records the hash of the audit policy and the audit webhook kubeconfig which
+	// the pods of the kube-apiserver are started with
+	AuditConfigHashAnnotation = "install.firefly.io/audit-config-hash"
+	// AuthenticationConfigHashAnnotation records the hash of the OIDC CA and the authentication webhook kubeconfig
+	// which the pods of the kube-apiserver are started with
+	AuthenticationConfigHashAnnotation = "install.firefly.io/authentication-config-hash"
+	// RestartedAtAnnotation triggers a rolling restart of the pods of a workload when it's changed.
+	// It's the same annotation which is used by `kubectl rollout restart`.
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+	// NamingAnnotation records how the objects of a karmada are named. It's set by firefly when the karmada
+	// is first synced and never changed afterwards.
+	NamingAnnotation = "install.firefly.io/naming"
+	// NamingLegacy names the objects of a karmada after its components only, as firefly did before multiple
+	// karmadas could be installed in the same namespace. It's kept for those karmadas so that their etcd
+	// data and certificates are adopted instead of orphaned.
+	NamingLegacy = "legacy"
+	// NamingInstance prefixes the names of the objects of a karmada with the name of the karmada.
+	NamingInstance = "instance"
+
+	// RestoreAnnotation is set on a karmada to the name of the KarmadaRestore which restores its etcd,
+	// the reconciliation of the karmada is paused until the restore is done
+	RestoreAnnotation = "install.firefly.io/restore"
+	// BackupLabel is set on the jobs of a KarmadaBackup to its name
+	BackupLabel = "install.firefly.io/backup"
+
+	// KarmadaClusterNamespace is the namespace which holds the credentials of the member clusters, both in
+	// the karmada and in the member clusters
+	KarmadaClusterNamespace = "karmada-cluster"
+	// MemberClusterLabel is set on the objects created for a MemberCluster to the name of the cluster
+	MemberClusterLabel = "install.firefly.io/member-cluster"
+	// KarmadaAccessLabel is set on the role bindings of a KarmadaAccess in the karmada to the namespace of the access
+	KarmadaAccessLabel = "install.firefly.io/karmada-access"
+	// KarmadaLabel is set on the monitored services and the monitoring objects of a karmada to its name
+	KarmadaLabel = "install.firefly.io/karmada"
+	// ClusterpediaLabel is set on the monitored services and the monitoring objects of a clusterpedia to its name
+	ClusterpediaLabel = "install.firefly.io/clusterpedia"
+	// ComponentLabel is set on the monitored services of a karmada or a clusterpedia to the name of the component
+	ComponentLabel = "install.firefly.io/component"
+
 	// ClusterpediaSystemNamespace defines the leader selection namespace for clusterpedia components
 	ClusterpediaSystemNamespace = "clusterpedia-system"
 	// ClusterpediaComponentAPIServer defines the name of the clusterpedia-apiserver component
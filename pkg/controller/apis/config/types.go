@@ -27,4 +27,14 @@ type FireflyControllerManagerConfiguration struct {
 
 	// Generic holds configuration for a generic controller-manager
 	Generic cmconfig.GenericControllerManagerConfiguration
+
+	// KarmadaAccessController holds configuration for the karmada access controller
+	KarmadaAccessController KarmadaAccessControllerConfiguration
+}
+
+// KarmadaAccessControllerConfiguration contains elements describing the karmada access controller.
+type KarmadaAccessControllerConfiguration struct {
+	// AllowedSystemSubjects are the users and groups with the system: prefix which a KarmadaAccess can be
+	// issued for. The other users and groups with the prefix are rejected.
+	AllowedSystemSubjects []string
 }
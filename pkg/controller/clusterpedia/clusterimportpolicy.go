@@ -17,18 +17,16 @@ limitations under the License.
 package clusterpedia
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 
 	policyapi "github.com/clusterpedia-io/api/policy/v1alpha1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 
 	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
 )
 
 var gvr = schema.GroupVersionResource{Group: "policy.clusterpedia.io", Version: "v1alpha1", Resource: "clusterimportpolicies"}
@@ -70,20 +68,5 @@ func (ctrl *ClusterpediaController) applyPolicy(client dynamic.Interface, policy
 		return err
 	}
 
-	_, err = client.Resource(gvr).Create(context.TODO(), obj, metav1.CreateOptions{})
-	if err != nil {
-		if !errors.IsAlreadyExists(err) {
-			return err
-		}
-		old, err := client.Resource(gvr).Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-		obj.SetResourceVersion(old.GetResourceVersion())
-		_, err = client.Resource(gvr).Update(context.TODO(), obj, metav1.UpdateOptions{})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return clientutil.ApplyUnstructured(client.Resource(gvr), obj)
 }
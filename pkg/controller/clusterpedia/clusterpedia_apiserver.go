@@ -36,7 +36,6 @@ import (
 	"github.com/carlory/firefly/pkg/util"
 	clientutil "github.com/carlory/firefly/pkg/util/client"
 	maputil "github.com/carlory/firefly/pkg/util/map"
-	utilresource "github.com/carlory/firefly/pkg/util/resource"
 )
 
 // EnsureAPIServer ensures the clusterpedia-apiserver component.
@@ -73,6 +72,7 @@ func (ctrl *ClusterpediaController) EnsureAPIServerService(clusterpedia *install
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      componentName,
 			Namespace: clusterpedia.Namespace,
+			Labels:    clusterpediaComponentLabels(clusterpedia, componentName),
 		},
 		Spec: corev1.ServiceSpec{
 			Type:     corev1.ServiceTypeClusterIP,
@@ -91,7 +91,7 @@ func (ctrl *ClusterpediaController) EnsureAPIServerService(clusterpedia *install
 		},
 	}
 	controllerutil.SetOwnerReference(clusterpedia, svc, scheme.Scheme)
-	return clientutil.CreateOrUpdateService(ctrl.client, svc)
+	return clientutil.ApplyService(ctrl.client, svc)
 }
 
 // EnsureAPIServerDeployment ensures the clusterpedia-apiserver deployment exists.
@@ -106,6 +106,10 @@ func (ctrl *ClusterpediaController) EnsureAPIServerDeployment(clusterpedia *inst
 	if server.ImageTag != "" {
 		tag = server.ImageTag
 	}
+	imageName := "apiserver"
+	if server.ImageName != "" {
+		imageName = server.ImageName
+	}
 
 	defaultArgs := map[string]string{
 		// Port numbers from 0 to 1023 are reserved for common TCP/IP applications and are called well-known ports.
@@ -119,6 +123,10 @@ func (ctrl *ClusterpediaController) EnsureAPIServerDeployment(clusterpedia *inst
 		"storage-config":            "/etc/clusterpedia/storage/internalstorage-config.yaml",
 		"v":                         "3",
 	}
+	// The metrics are scraped by the prometheus without credentials of the control plane.
+	if clusterpedia.Spec.Monitoring != nil {
+		defaultArgs["authorization-always-allow-paths"] = "/healthz,/readyz,/livez,/metrics"
+	}
 	featureGates := maputil.MergeBoolMaps(clusterpedia.Spec.FeatureGates, server.FeatureGates)
 	for feature, enabled := range featureGates {
 		if defaultArgs["feature-gates"] == "" {
@@ -157,8 +165,8 @@ func (ctrl *ClusterpediaController) EnsureAPIServerDeployment(clusterpedia *inst
 					Containers: []corev1.Container{
 						{
 							Name:            "apiserver",
-							Image:           util.ComponentImageName(repository, "apiserver", tag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           util.ComponentImage(clusterpedia.Spec.ImageOverrides, componentName, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(server.ImagePullPolicy, clusterpedia.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
 							Command:         []string{"/usr/local/bin/apiserver"},
 							Args:            args,
 							Resources:       server.Resources,
@@ -247,16 +255,18 @@ func (ctrl *ClusterpediaController) EnsureAPIServerDeployment(clusterpedia *inst
 			},
 		},
 	}
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, server.ImagePullSecrets, clusterpedia.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, server.Placement, clusterpedia.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(clusterpedia, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.client, deployment)
+	if err := clientutil.ApplyDeployment(ctrl.client, deployment); err != nil {
+		return err
+	}
+	return ctrl.EnsurePodDisruptionBudget(clusterpedia, deployment.Name, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas, server.PodDisruptionBudget)
 }
 
 func (ctrl *ClusterpediaController) EnsureClusterpediaAPIService(clusterpedia *installv1alpha1.Clusterpedia) error {
-	kubeconfigSecretName, err := ctrl.KubeConfigSecretNameFromProvider(clusterpedia)
-	if err != nil {
-		return err
-	}
-	clientConfig, err := utilresource.GetClientConfigFromKubeConfigSecret(ctrl.client, clusterpedia.Namespace, kubeconfigSecretName, userAgentName)
+	clientConfig, err := ctrl.ClientConfigFromProvider(clusterpedia)
 	if err != nil {
 		return err
 	}
@@ -283,7 +293,7 @@ func (ctrl *ClusterpediaController) EnsureClusterpediaAPIService(clusterpedia *i
 			ExternalName: fmt.Sprintf("%s.%s.svc", constants.ClusterpediaComponentAPIServer, clusterpedia.Namespace),
 		},
 	}
-	if err = clientutil.CreateOrUpdateService(kubeClient, svc); err != nil {
+	if err = clientutil.ApplyService(kubeClient, svc); err != nil {
 		return err
 	}
 
@@ -307,15 +317,11 @@ func (ctrl *ClusterpediaController) EnsureClusterpediaAPIService(clusterpedia *i
 			VersionPriority: 100,
 		},
 	}
-	return clientutil.CreateOrUpdateAPIService(aaClient, apisvc)
+	return clientutil.ApplyAPIService(aaClient, apisvc)
 }
 
 func (ctrl *ClusterpediaController) RemoveClusterpediaAPIService(clusterpedia *installv1alpha1.Clusterpedia) error {
-	kubeconfigSecretName, err := ctrl.KubeConfigSecretNameFromProvider(clusterpedia)
-	if err != nil {
-		return err
-	}
-	clientConfig, err := utilresource.GetClientConfigFromKubeConfigSecret(ctrl.client, clusterpedia.Namespace, kubeconfigSecretName, userAgentName)
+	clientConfig, err := ctrl.ClientConfigFromProvider(clusterpedia)
 	if err != nil {
 		return err
 	}
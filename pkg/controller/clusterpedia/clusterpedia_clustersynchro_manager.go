@@ -56,6 +56,10 @@ func (ctrl *ClusterpediaController) EnsureClusterSynchroManagerDeployment(cluste
 	if manager.ImageTag != "" {
 		tag = manager.ImageTag
 	}
+	imageName := "clustersynchro-manager"
+	if manager.ImageName != "" {
+		imageName = manager.ImageName
+	}
 
 	defaultArgs := map[string]string{
 		"kubeconfig":                      "/etc/kubeconfig",
@@ -101,8 +105,8 @@ func (ctrl *ClusterpediaController) EnsureClusterSynchroManagerDeployment(cluste
 					Containers: []corev1.Container{
 						{
 							Name:            "manager",
-							Image:           util.ComponentImageName(repository, "clustersynchro-manager", tag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           util.ComponentImage(clusterpedia.Spec.ImageOverrides, componentName, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(manager.ImagePullPolicy, clusterpedia.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
 							Command:         []string{"/usr/local/bin/clustersynchro-manager"},
 							Args:            args,
 							Env: []corev1.EnvVar{
@@ -158,6 +162,12 @@ func (ctrl *ClusterpediaController) EnsureClusterSynchroManagerDeployment(cluste
 			},
 		},
 	}
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, manager.ImagePullSecrets, clusterpedia.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, manager.Placement, clusterpedia.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(clusterpedia, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.client, deployment)
+	if err := clientutil.ApplyDeployment(ctrl.client, deployment); err != nil {
+		return err
+	}
+	return ctrl.EnsurePodDisruptionBudget(clusterpedia, deployment.Name, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas, manager.PodDisruptionBudget)
 }
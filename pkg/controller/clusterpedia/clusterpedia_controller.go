@@ -26,8 +26,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -40,7 +42,9 @@ import (
 	fireflyclient "github.com/carlory/firefly/pkg/generated/clientset/versioned"
 	installinformers "github.com/carlory/firefly/pkg/generated/informers/externalversions/install/v1alpha1"
 	installlisters "github.com/carlory/firefly/pkg/generated/listers/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/metrics"
 	"github.com/carlory/firefly/pkg/scheme"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
 )
 
 const (
@@ -59,6 +63,7 @@ const (
 func NewClusterpediaController(
 	client clientset.Interface,
 	fireflyClient fireflyclient.Interface,
+	config *restclient.Config,
 	clusterpediaInformer installinformers.ClusterpediaInformer) (*ClusterpediaController, error) {
 	broadcaster := record.NewBroadcaster()
 	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "clusterpedia-controller"})
@@ -66,10 +71,18 @@ func NewClusterpediaController(
 	if client != nil && client.CoreV1().RESTClient().GetRateLimiter() != nil {
 		ratelimiter.RegisterMetricAndTrackRateLimiterUsage("clusterpedia_controller", client.CoreV1().RESTClient().GetRateLimiter())
 	}
+	metrics.Register()
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
 
 	ctrl := &ClusterpediaController{
 		client:              client,
 		fireflyClient:       fireflyClient,
+		dynamicClient:       dynamicClient,
+		config:              config,
 		clusterpediasLister: clusterpediaInformer.Lister(),
 		clusterpediasSynced: clusterpediaInformer.Informer().HasSynced,
 		queue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "clusterpedia"),
@@ -90,9 +103,16 @@ func NewClusterpediaController(
 type ClusterpediaController struct {
 	client           clientset.Interface
 	fireflyClient    fireflyclient.Interface
+	dynamicClient    dynamic.Interface
 	eventBroadcaster record.EventBroadcaster
 	eventRecorder    record.EventRecorder
 
+	// config is the config of the clients of the host cluster, from which the dry-run clients of a plan are built.
+	config *restclient.Config
+	// plan records the changes instead of applying them. It is only set on the copy of the controller which
+	// computes the plan of a clusterpedia.
+	plan *clientutil.Plan
+
 	clusterpediasLister installlisters.ClusterpediaLister
 	clusterpediasSynced cache.InformerSynced
 
@@ -148,7 +168,9 @@ func (ctrl *ClusterpediaController) processNextWorkItem(ctx context.Context) boo
 	}
 	defer ctrl.queue.Done(key)
 
+	startTime := time.Now()
 	err := ctrl.syncClusterpedia(ctx, key.(string))
+	metrics.ObserveReconcile("clusterpedia", metrics.PhaseSync, startTime, err)
 	ctrl.handleErr(err, key)
 
 	return true
@@ -275,36 +297,55 @@ func (ctrl *ClusterpediaController) syncClusterpedia(ctx context.Context, key st
 		}
 	}
 
-	klog.InfoS("Syncing clusterpedia", "clusterpedia", klog.KObj(clusterpedia))
-
-	if err := ctrl.EnsureNamespace(clusterpedia); err != nil {
-		return err
-	}
-
-	if err := ctrl.EnsureClusterpediaCRDs(clusterpedia); err != nil {
-		return err
-	}
-
-	if err := ctrl.EnsureInternalStorage(clusterpedia); err != nil {
-		return err
+	if clusterpedia.Spec.Paused || clusterpedia.Spec.Plan {
+		klog.InfoS("Clusterpedia is paused", "clusterpedia", klog.KObj(clusterpedia), "plan", clusterpedia.Spec.Plan)
+		old := clusterpedia.DeepCopy()
+		planErr := ctrl.pause(clusterpedia)
+		if err := ctrl.updateStatus(ctx, old, clusterpedia); err != nil {
+			return err
+		}
+		return planErr
 	}
 
-	if err := ctrl.EnsureAPIServer(clusterpedia); err != nil {
-		return err
-	}
+	klog.InfoS("Syncing clusterpedia", "clusterpedia", klog.KObj(clusterpedia))
 
-	if err := ctrl.EnsureControllerManager(clusterpedia); err != nil {
-		return err
+	old := clusterpedia.DeepCopy()
+	resume(clusterpedia)
+	syncErr := ctrl.reconcile(clusterpedia)
+	if syncErr == nil {
+		syncErr = ctrl.reconcileMonitoring(clusterpedia)
 	}
-
-	if err := ctrl.EnsureClusterSynchroManager(clusterpedia); err != nil {
-		return err
+	if err := ctrl.updateStatus(ctx, old, clusterpedia); err != nil {
+		klog.ErrorS(err, "Failed to update clusterpedia status", "clusterpedia", klog.KObj(clusterpedia))
+		if syncErr == nil {
+			syncErr = err
+		}
 	}
+	return syncErr
+}
 
-	if err := ctrl.EnsureClusterImportPolicy(clusterpedia); err != nil {
-		return err
+// reconcile ensures all the components of the clusterpedia in order.
+func (ctrl *ClusterpediaController) reconcile(clusterpedia *installv1alpha1.Clusterpedia) error {
+	phases := []struct {
+		name   string
+		ensure func(*installv1alpha1.Clusterpedia) error
+	}{
+		{name: "Namespace", ensure: ctrl.EnsureNamespace},
+		{name: "CRDs", ensure: ctrl.EnsureClusterpediaCRDs},
+		{name: "InternalStorage", ensure: ctrl.EnsureInternalStorage},
+		{name: "APIServer", ensure: ctrl.EnsureAPIServer},
+		{name: "ControllerManager", ensure: ctrl.EnsureControllerManager},
+		{name: "ClusterSynchroManager", ensure: ctrl.EnsureClusterSynchroManager},
+		{name: "ClusterImportPolicy", ensure: ctrl.EnsureClusterImportPolicy},
+	}
+	for _, p := range phases {
+		startTime := time.Now()
+		err := p.ensure(clusterpedia)
+		metrics.ObserveReconcile("clusterpedia", p.name, startTime, err)
+		if err != nil {
+			return err
+		}
 	}
-
 	return nil
 }
 
@@ -322,11 +363,7 @@ func (ctrl *ClusterpediaController) EnsureNamespace(clusterpedia *installv1alpha
 	if hasProvider {
 		ns.Name = constants.ClusterpediaSystemNamespace
 	}
-	_, err = kubeClient.CoreV1().Namespaces().Create(context.TODO(), ns, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return err
-	}
-	return nil
+	return clientutil.ApplyNamespace(kubeClient, ns)
 }
 
 func (ctrl *ClusterpediaController) deleteUnableGCResources(clusterpedia *installv1alpha1.Clusterpedia) error {
@@ -17,12 +17,14 @@ limitations under the License.
 package clusterpedia
 
 import (
+	"embed"
 	"fmt"
+	"io/fs"
 
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/cli-runtime/pkg/resource"
 
 	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
 	utilresource "github.com/carlory/firefly/pkg/util/resource"
 )
 
@@ -31,52 +33,46 @@ const (
 	userAgentName = "clusterpedia-controller"
 )
 
+// crds holds a bundle of the clusterpedia crds for each supported clusterpedia minor version.
+//
+//go:embed crds
+var crds embed.FS
+
+// clusterpediaCRDBundle returns the embedded crd bundles and the bundle of the clusterpedia version.
+func clusterpediaCRDBundle(clusterpedia *installv1alpha1.Clusterpedia) (fs.FS, string, error) {
+	bundles, err := fs.Sub(crds, "crds")
+	if err != nil {
+		return nil, "", err
+	}
+	bundle, err := utilresource.SelectBundle(bundles, clusterpedia.Spec.Version)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to select the crds of clusterpedia %s: %v", clusterpedia.Spec.Version, err)
+	}
+	return bundles, bundle, nil
+}
+
 func (ctrl *ClusterpediaController) EnsureClusterpediaCRDs(clusterpedia *installv1alpha1.Clusterpedia) error {
+	bundles, bundle, err := clusterpediaCRDBundle(clusterpedia)
+	if err != nil {
+		return err
+	}
 	builder, err := ctrl.NewResourceBuilder(clusterpedia)
 	if err != nil {
 		return err
 	}
-
-	result := builder.Unstructured().
-		FilenameParam(false, &resource.FilenameOptions{Recursive: false, Filenames: []string{"./pkg/controller/clusterpedia/crds"}}).
-		Flatten().Do()
-
-	return result.Visit(func(info *resource.Info, err error) error {
-		if err != nil {
-			return err
-		}
-		_, err1 := resource.NewHelper(info.Client, info.Mapping).Create(info.Namespace, true, info.Object)
-		if err1 != nil {
-			if !errors.IsAlreadyExists(err1) {
-				return err1
-			}
-		}
-		return nil
-	})
+	return utilresource.ApplyBundle(builder, bundles, bundle, constants.FieldManager)
 }
 
 func (ctrl *ClusterpediaController) RemoveClusterpediaCRDs(clusterpedia *installv1alpha1.Clusterpedia) error {
+	bundles, bundle, err := clusterpediaCRDBundle(clusterpedia)
+	if err != nil {
+		return err
+	}
 	builder, err := ctrl.NewResourceBuilder(clusterpedia)
 	if err != nil {
 		return err
 	}
-
-	result := builder.Unstructured().
-		FilenameParam(false, &resource.FilenameOptions{Recursive: false, Filenames: []string{"./pkg/controller/clusterpedia/crds"}}).
-		Flatten().Do()
-
-	return result.Visit(func(info *resource.Info, err error) error {
-		if err != nil {
-			return err
-		}
-		_, err1 := resource.NewHelper(info.Client, info.Mapping).Delete(info.Namespace, info.Name)
-		if err1 != nil {
-			if !errors.IsNotFound(err1) {
-				return err1
-			}
-		}
-		return nil
-	})
+	return utilresource.DeleteBundle(builder, bundles, bundle)
 }
 
 func (ctrl *ClusterpediaController) NewResourceBuilder(clusterpedia *installv1alpha1.Clusterpedia) (*resource.Builder, error) {
@@ -89,9 +85,9 @@ func (ctrl *ClusterpediaController) NewResourceBuilder(clusterpedia *installv1al
 		return nil, fmt.Errorf("unsupported without provider")
 	}
 
-	kubeconfigSecretName, err := ctrl.KubeConfigSecretNameFromProvider(clusterpedia)
+	clientConfig, err := ctrl.ClientConfigFromProvider(clusterpedia)
 	if err != nil {
 		return nil, err
 	}
-	return utilresource.NewBuilderFromKubeConfigSecret(ctrl.client, clusterpedia.Namespace, kubeconfigSecretName, userAgentName)
+	return utilresource.NewBuilder(clientConfig), nil
 }
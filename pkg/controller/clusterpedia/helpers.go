@@ -23,8 +23,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/scheme"
+	"github.com/carlory/firefly/pkg/util"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
 	utilresource "github.com/carlory/firefly/pkg/util/resource"
 )
 
@@ -62,11 +68,7 @@ func (ctrl *ClusterpediaController) GetControlplaneClient(clusterpedia *installv
 }
 
 func (ctrl *ClusterpediaController) GetControlplaneDynamicClientFromProvider(clusterpedia *installv1alpha1.Clusterpedia) (dynamic.Interface, error) {
-	kubeconfigSecretName, err := ctrl.KubeConfigSecretNameFromProvider(clusterpedia)
-	if err != nil {
-		return nil, err
-	}
-	clientConfig, err := utilresource.GetClientConfigFromKubeConfigSecret(ctrl.client, clusterpedia.Namespace, kubeconfigSecretName, userAgentName)
+	clientConfig, err := ctrl.ClientConfigFromProvider(clusterpedia)
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +77,15 @@ func (ctrl *ClusterpediaController) GetControlplaneDynamicClientFromProvider(clu
 
 // GetControlplaneClientFromProvider returns the client of the controlplane according to a provider.
 func (ctrl *ClusterpediaController) GetControlplaneClientFromProvider(clusterpedia *installv1alpha1.Clusterpedia) (kubernetes.Interface, error) {
+	clientConfig, err := ctrl.ClientConfigFromProvider(clusterpedia)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(clientConfig)
+}
+
+// ClientConfigFromProvider returns the client config of the controlplane according to a provider.
+func (ctrl *ClusterpediaController) ClientConfigFromProvider(clusterpedia *installv1alpha1.Clusterpedia) (*restclient.Config, error) {
 	kubeconfigSecretName, err := ctrl.KubeConfigSecretNameFromProvider(clusterpedia)
 	if err != nil {
 		return nil, err
@@ -83,7 +94,10 @@ func (ctrl *ClusterpediaController) GetControlplaneClientFromProvider(clusterped
 	if err != nil {
 		return nil, err
 	}
-	return kubernetes.NewForConfig(clientConfig)
+	if ctrl.plan != nil {
+		clientConfig = ctrl.plan.WrapConfig(clientConfig, clientConfig.Host)
+	}
+	return clientConfig, nil
 }
 
 // KubeConfigSecretNameFromProvider returns the name of a kubeconfig secret according to the given provider.
@@ -93,7 +107,23 @@ func (ctrl *ClusterpediaController) KubeConfigSecretNameFromProvider(clusterpedi
 		return "", fmt.Errorf("no provider found")
 	}
 	if provider.Karmada != nil {
-		return "karmada-kubeconfig", nil
+		karmada, err := ctrl.fireflyClient.InstallV1alpha1().Karmadas(clusterpedia.Namespace).Get(context.TODO(), provider.Karmada.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get the karmada %s: %v", provider.Karmada.Name, err)
+		}
+		return util.KarmadaKubeConfigSecretName(karmada), nil
 	}
 	return "", nil
 }
+
+// EnsurePodDisruptionBudget ensures the PodDisruptionBudget of a component exists when the component has more
+// than one replica, and removes it otherwise.
+func (ctrl *ClusterpediaController) EnsurePodDisruptionBudget(clusterpedia *installv1alpha1.Clusterpedia, componentName string, selector map[string]string, replicas *int32, policy *installv1alpha1.PodDisruptionBudgetPolicy) error {
+	pdb := util.PodDisruptionBudget(componentName, clusterpedia.Namespace, selector, replicas, policy)
+	if pdb == nil {
+		err := ctrl.client.PolicyV1().PodDisruptionBudgets(clusterpedia.Namespace).Delete(context.TODO(), componentName, metav1.DeleteOptions{})
+		return client.IgnoreNotFound(err)
+	}
+	controllerutil.SetOwnerReference(clusterpedia, pdb, scheme.Scheme)
+	return clientutil.ApplyPodDisruptionBudget(ctrl.client, pdb)
+}
@@ -17,8 +17,6 @@ limitations under the License.
 package clusterpedia
 
 import (
-	"fmt"
-
 	"github.com/MakeNowJust/heredoc"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -29,6 +27,7 @@ import (
 	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
 	"github.com/carlory/firefly/pkg/constants"
 	"github.com/carlory/firefly/pkg/scheme"
+	"github.com/carlory/firefly/pkg/util"
 	clientutil "github.com/carlory/firefly/pkg/util/client"
 )
 
@@ -77,7 +76,7 @@ func (ctrl *ClusterpediaController) EnsureMySQLService(clusterpedia *installv1al
 		},
 	}
 	controllerutil.SetOwnerReference(clusterpedia, svc, scheme.Scheme)
-	return clientutil.CreateOrUpdateService(ctrl.client, svc)
+	return clientutil.ApplyService(ctrl.client, svc)
 }
 
 // EnsureMySQLSecret ensures the clusterpedia-internalstorage-mysql secret exists.
@@ -96,7 +95,7 @@ func (ctrl *ClusterpediaController) EnsureMySQLSecret(clusterpedia *installv1alp
 		},
 	}
 	controllerutil.SetOwnerReference(clusterpedia, secret, scheme.Scheme)
-	return clientutil.CreateOrUpdateSecret(ctrl.client, secret)
+	return clientutil.ApplySecret(ctrl.client, secret)
 }
 
 // EnsureMySQLConfigMap ensures the clusterpedia-internalstorage-mysql configmap exists.
@@ -121,7 +120,7 @@ func (ctrl *ClusterpediaController) EnsureMySQLConfigMap(clusterpedia *installv1
 		},
 	}
 	controllerutil.SetOwnerReference(clusterpedia, cm, scheme.Scheme)
-	return clientutil.CreateOrUpdateConfigMap(ctrl.client, cm)
+	return clientutil.ApplyConfigMap(ctrl.client, cm)
 }
 
 // EnsureMySQLDeployment ensures the clusterpedia-internalstorage-mysql deployment exists.
@@ -156,8 +155,8 @@ func (ctrl *ClusterpediaController) EnsureMySQLDeployment(clusterpedia *installv
 					Containers: []corev1.Container{
 						{
 							Name:            "mysql",
-							Image:           fmt.Sprintf("%s/%s:%s", image.ImageRepository, image.ImageName, image.ImageTag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           util.ComponentImage(clusterpedia.Spec.ImageOverrides, componentName, image.ImageRepository, image.ImageName, image.ImageTag),
+							ImagePullPolicy: util.ImagePullPolicy(image.ImagePullPolicy, clusterpedia.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
 							Args: []string{
 								"--default-authentication-plugin=mysql_native_password",
 							},
@@ -204,6 +203,8 @@ func (ctrl *ClusterpediaController) EnsureMySQLDeployment(clusterpedia *installv
 			},
 		},
 	}
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, image.ImagePullSecrets, clusterpedia.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, image.Placement, clusterpedia.Spec.Placement)
 	controllerutil.SetOwnerReference(clusterpedia, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.client, deployment)
+	return clientutil.ApplyDeployment(ctrl.client, deployment)
 }
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpedia
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	"github.com/carlory/firefly/pkg/metrics"
+	"github.com/carlory/firefly/pkg/scheme"
+	"github.com/carlory/firefly/pkg/util"
+)
+
+const (
+	// clusterSynchroManagerMetricsPort is the port which the clusterpedia-clustersynchro-manager serves its
+	// metrics on. The clusterpedia-controller-manager doesn't serve metrics.
+	clusterSynchroManagerMetricsPort = 8081
+
+	reasonMonitoringCreated      = "MonitoringCreated"
+	reasonMonitoringCRDsNotFound = "MonitoringCRDsNotFound"
+	reasonReconcileError         = "ReconcileError"
+)
+
+// reconcileMonitoring creates the ServiceMonitors, PodMonitors and PrometheusRules of the clusterpedia when its
+// monitoring is enabled, and removes them otherwise.
+func (ctrl *ClusterpediaController) reconcileMonitoring(clusterpedia *installv1alpha1.Clusterpedia) error {
+	startTime := time.Now()
+	err := ctrl.ensureMonitoring(clusterpedia)
+	metrics.ObserveReconcile("clusterpedia", installv1alpha1.ClusterpediaConditionMonitoring, startTime, err)
+	if err != nil {
+		klog.ErrorS(err, "Failed to reconcile the monitoring of clusterpedia", "clusterpedia", klog.KObj(clusterpedia))
+		ctrl.setMonitoringCondition(clusterpedia, metav1.ConditionFalse, reasonReconcileError, err.Error())
+	}
+	return err
+}
+
+func (ctrl *ClusterpediaController) ensureMonitoring(clusterpedia *installv1alpha1.Clusterpedia) error {
+	installed, err := util.MonitoringInstalled(ctrl.dynamicClient, clusterpedia.Namespace)
+	if err != nil {
+		return err
+	}
+	monitoring := clusterpedia.Spec.Monitoring
+	if monitoring == nil {
+		meta.RemoveStatusCondition(&clusterpedia.Status.Conditions, installv1alpha1.ClusterpediaConditionMonitoring)
+		if !installed {
+			return nil
+		}
+		return util.ApplyMonitoringObjects(ctrl.dynamicClient, clusterpedia.Namespace, clusterpediaMonitoringLabels(clusterpedia), nil)
+	}
+	if !installed {
+		ctrl.setMonitoringCondition(clusterpedia, metav1.ConditionFalse, reasonMonitoringCRDsNotFound, "The monitoring.coreos.com CRDs of the prometheus operator are not installed")
+		return nil
+	}
+
+	objs := clusterpediaMonitoringObjects(clusterpedia)
+	for _, obj := range objs {
+		controllerutil.SetOwnerReference(clusterpedia, obj, scheme.Scheme)
+	}
+	if err := util.ApplyMonitoringObjects(ctrl.dynamicClient, clusterpedia.Namespace, clusterpediaMonitoringLabels(clusterpedia), objs); err != nil {
+		return err
+	}
+	ctrl.setMonitoringCondition(clusterpedia, metav1.ConditionTrue, reasonMonitoringCreated, fmt.Sprintf("%d monitoring objects are created", len(objs)))
+	return nil
+}
+
+// setMonitoringCondition sets the monitoring condition of the clusterpedia and records an event when it's changed.
+func (ctrl *ClusterpediaController) setMonitoringCondition(clusterpedia *installv1alpha1.Clusterpedia, status metav1.ConditionStatus, reason, message string) {
+	old := meta.FindStatusCondition(clusterpedia.Status.Conditions, installv1alpha1.ClusterpediaConditionMonitoring)
+	if old == nil || old.Status != status || old.Reason != reason {
+		eventType := corev1.EventTypeNormal
+		if status != metav1.ConditionTrue {
+			eventType = corev1.EventTypeWarning
+		}
+		ctrl.eventRecorder.Event(clusterpedia, eventType, reason, message)
+	}
+	meta.SetStatusCondition(&clusterpedia.Status.Conditions, metav1.Condition{
+		Type:               installv1alpha1.ClusterpediaConditionMonitoring,
+		Status:             status,
+		ObservedGeneration: clusterpedia.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// clusterpediaMonitoringLabels returns the labels of the monitoring objects of the clusterpedia.
+func clusterpediaMonitoringLabels(clusterpedia *installv1alpha1.Clusterpedia) map[string]string {
+	return map[string]string{constants.ClusterpediaLabel: clusterpedia.Name}
+}
+
+// clusterpediaMonitoringObjects returns the monitoring objects of the components of the clusterpedia.
+func clusterpediaMonitoringObjects(clusterpedia *installv1alpha1.Clusterpedia) []*unstructured.Unstructured {
+	monitoring := clusterpedia.Spec.Monitoring
+	interval := util.MonitoringInterval(monitoring)
+	ownerLabels := clusterpediaMonitoringLabels(clusterpedia)
+
+	apiServerName := constants.ClusterpediaComponentAPIServer
+	synchroManagerName := constants.ClusterpediaComponentClusterSynchroManager
+	objs := []*unstructured.Unstructured{
+		// The serving certificate of the clusterpedia-apiserver is self-signed, and its metrics path is
+		// allowed without authorization when the monitoring is enabled.
+		util.MonitoringObject("ServiceMonitor", apiServerName, clusterpedia.Namespace, monitoring, ownerLabels, map[string]interface{}{
+			"selector": util.MatchLabels(clusterpediaComponentLabels(clusterpedia, apiServerName)),
+			"endpoints": []interface{}{
+				map[string]interface{}{
+					"port":      "server",
+					"scheme":    "https",
+					"interval":  interval,
+					"tlsConfig": map[string]interface{}{"insecureSkipVerify": true},
+				},
+			},
+		}),
+		util.MonitoringObject("PodMonitor", synchroManagerName, clusterpedia.Namespace, monitoring, ownerLabels, map[string]interface{}{
+			"selector": util.MatchLabels(map[string]string{"app": synchroManagerName}),
+			"podMetricsEndpoints": []interface{}{
+				map[string]interface{}{
+					"targetPort": int64(clusterSynchroManagerMetricsPort),
+					"path":       "/metrics",
+					"interval":   interval,
+				},
+			},
+		}),
+	}
+
+	if !monitoring.DisableAlerts {
+		objs = append(objs, util.MonitoringObject("PrometheusRule", "clusterpedia-alerts", clusterpedia.Namespace, monitoring, ownerLabels, map[string]interface{}{
+			"groups": []interface{}{
+				map[string]interface{}{
+					"name": fmt.Sprintf("clusterpedia.%s.%s", clusterpedia.Namespace, clusterpedia.Name),
+					"rules": []interface{}{
+						util.AlertingRule("ClusterpediaAPIServerDown",
+							fmt.Sprintf(`absent(up{namespace=%q, job=%q} == 1)`, clusterpedia.Namespace, apiServerName),
+							"5m", "critical",
+							fmt.Sprintf("The clusterpedia-apiserver of the clusterpedia %s/%s is unavailable.", clusterpedia.Namespace, clusterpedia.Name)),
+					},
+				},
+			},
+		}))
+	}
+	return objs
+}
@@ -31,3 +31,12 @@ func GenerateDatabaseSecretName(clusterpedia *installv1alpha1.Clusterpedia) stri
 func GenerateDatabaseConfigMapName(clusterpedia *installv1alpha1.Clusterpedia) string {
 	return constants.ClusterpediaComponentInternalStorage
 }
+
+// clusterpediaComponentLabels returns the labels of the services of a component which is monitored, they select
+// the services in the ServiceMonitor of the component.
+func clusterpediaComponentLabels(clusterpedia *installv1alpha1.Clusterpedia, component string) map[string]string {
+	return map[string]string{
+		constants.ClusterpediaLabel: clusterpedia.Name,
+		constants.ComponentLabel:    component,
+	}
+}
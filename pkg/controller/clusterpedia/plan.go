@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpedia
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+)
+
+const (
+	reasonPaused   = "Paused"
+	reasonPlanning = "Planning"
+)
+
+// pause marks the reconciliation of the clusterpedia as paused. If a plan is requested, the changes which
+// the reconciliation would make are computed and reported in the status of the clusterpedia instead.
+func (ctrl *ClusterpediaController) pause(clusterpedia *installv1alpha1.Clusterpedia) error {
+	if !clusterpedia.Spec.Plan {
+		clusterpedia.Status.Plan = nil
+		ctrl.setPausedCondition(clusterpedia, reasonPaused, "The reconciliation is paused by spec.paused")
+		return nil
+	}
+
+	ctrl.setPausedCondition(clusterpedia, reasonPlanning, "The reconciliation is paused by spec.plan, see status.plan for the pending changes")
+	plan := clientutil.NewPlan()
+	planner, err := ctrl.planner(plan)
+	if err != nil {
+		return err
+	}
+	status := &installv1alpha1.PlanStatus{ObservedGeneration: clusterpedia.Generation}
+	// The computation stops at the first component which fails, as the following components may depend on it.
+	// The reconciliation sets the conditions in the status, which mustn't be persisted by a plan.
+	if err := planner.reconcile(clusterpedia.DeepCopy()); err != nil {
+		klog.ErrorS(err, "Failed to plan clusterpedia", "clusterpedia", klog.KObj(clusterpedia))
+		status.Message = fmt.Sprintf("Some changes can't be computed: %v", err)
+	}
+	status.Changes = plan.Changes()
+	clusterpedia.Status.Plan = status
+	return nil
+}
+
+// resume removes the paused condition and the plan from the status of the clusterpedia.
+func resume(clusterpedia *installv1alpha1.Clusterpedia) {
+	meta.RemoveStatusCondition(&clusterpedia.Status.Conditions, installv1alpha1.ClusterpediaConditionPaused)
+	clusterpedia.Status.Plan = nil
+}
+
+// setPausedCondition sets the paused condition of the clusterpedia and records an event when it's changed.
+func (ctrl *ClusterpediaController) setPausedCondition(clusterpedia *installv1alpha1.Clusterpedia, reason, message string) {
+	old := meta.FindStatusCondition(clusterpedia.Status.Conditions, installv1alpha1.ClusterpediaConditionPaused)
+	if old == nil || old.Reason != reason {
+		ctrl.eventRecorder.Event(clusterpedia, corev1.EventTypeNormal, reason, message)
+	}
+	meta.SetStatusCondition(&clusterpedia.Status.Conditions, metav1.Condition{
+		Type:               installv1alpha1.ClusterpediaConditionPaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: clusterpedia.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// updateStatus updates the status of the clusterpedia if it's changed.
+func (ctrl *ClusterpediaController) updateStatus(ctx context.Context, old, clusterpedia *installv1alpha1.Clusterpedia) error {
+	clusterpedia.Status.ObservedGeneration = clusterpedia.Generation
+	if equality.Semantic.DeepEqual(old.Status, clusterpedia.Status) {
+		return nil
+	}
+	_, err := ctrl.fireflyClient.InstallV1alpha1().Clusterpedias(clusterpedia.Namespace).UpdateStatus(ctx, clusterpedia, metav1.UpdateOptions{})
+	return err
+}
+
+// planner returns a copy of the controller whose clients record their changes in the plan instead of
+// applying them, and whose events are dropped.
+func (ctrl *ClusterpediaController) planner(plan *clientutil.Plan) (*ClusterpediaController, error) {
+	config := plan.WrapConfig(ctrl.config, "")
+	client, err := clientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	planner := *ctrl
+	planner.client = client
+	planner.dynamicClient = dynamicClient
+	planner.plan = plan
+	planner.eventRecorder = &record.FakeRecorder{}
+	return &planner, nil
+}
@@ -0,0 +1,597 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	batchinformers "k8s.io/client-go/informers/batch/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/component-base/metrics/prometheus/ratelimiter"
+	"k8s.io/klog/v2"
+	utilpointer "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	fireflyclient "github.com/carlory/firefly/pkg/generated/clientset/versioned"
+	installinformers "github.com/carlory/firefly/pkg/generated/informers/externalversions/install/v1alpha1"
+	installlisters "github.com/carlory/firefly/pkg/generated/listers/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/metrics"
+	"github.com/carlory/firefly/pkg/scheme"
+	"github.com/carlory/firefly/pkg/util"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+)
+
+const (
+	// defaultBackupRetention is the number of scheduled snapshots which are kept if the retention isn't set.
+	defaultBackupRetention = 7
+	// defaultS3ClientImage is the image of the MinIO client which transfers the snapshots to an object storage.
+	defaultS3ClientImage = "minio/mc"
+	// snapshotsPath is where the storage of the snapshots is mounted in the pods.
+	snapshotsPath = "/snapshots"
+)
+
+// NewKarmadaBackupController returns a new *KarmadaBackupController.
+func NewKarmadaBackupController(
+	client clientset.Interface,
+	fireflyClient fireflyclient.Interface,
+	backupInformer installinformers.KarmadaBackupInformer,
+	karmadaInformer installinformers.KarmadaInformer,
+	jobInformer batchinformers.JobInformer) (*KarmadaBackupController, error) {
+	broadcaster := record.NewBroadcaster()
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "karmada-backup-controller"})
+
+	if client != nil && client.CoreV1().RESTClient().GetRateLimiter() != nil {
+		ratelimiter.RegisterMetricAndTrackRateLimiterUsage("karmada_backup_controller", client.CoreV1().RESTClient().GetRateLimiter())
+	}
+	metrics.Register()
+
+	ctrl := &KarmadaBackupController{
+		client:           client,
+		fireflyClient:    fireflyClient,
+		backupsLister:    backupInformer.Lister(),
+		backupsSynced:    backupInformer.Informer().HasSynced,
+		karmadasLister:   karmadaInformer.Lister(),
+		karmadasSynced:   karmadaInformer.Informer().HasSynced,
+		jobsLister:       jobInformer.Lister(),
+		jobsSynced:       jobInformer.Informer().HasSynced,
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "karmadabackup"),
+		workerLoopPeriod: time.Second,
+		eventBroadcaster: broadcaster,
+		eventRecorder:    recorder,
+	}
+
+	backupInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ctrl.enqueue,
+		UpdateFunc: func(old, cur interface{}) { ctrl.enqueue(cur) },
+	})
+	karmadaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ctrl.enqueueKarmadaBackups,
+		UpdateFunc: func(old, cur interface{}) { ctrl.enqueueKarmadaBackups(cur) },
+	})
+	jobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ctrl.enqueueJobBackup,
+		UpdateFunc: func(old, cur interface{}) { ctrl.enqueueJobBackup(cur) },
+		DeleteFunc: ctrl.enqueueJobBackup,
+	})
+
+	return ctrl, nil
+}
+
+// KarmadaBackupController takes the snapshots of the etcd of the karmadas with jobs, and reports them
+// in the status of the backups.
+type KarmadaBackupController struct {
+	client           clientset.Interface
+	fireflyClient    fireflyclient.Interface
+	eventBroadcaster record.EventBroadcaster
+	eventRecorder    record.EventRecorder
+
+	backupsLister  installlisters.KarmadaBackupLister
+	backupsSynced  cache.InformerSynced
+	karmadasLister installlisters.KarmadaLister
+	karmadasSynced cache.InformerSynced
+	jobsLister     batchlisters.JobLister
+	jobsSynced     cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+
+	// workerLoopPeriod is the time between worker runs.
+	workerLoopPeriod time.Duration
+}
+
+// Run will not return until stopCh is closed. workers determines how many
+// backups will be handled in parallel.
+func (ctrl *KarmadaBackupController) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+
+	ctrl.eventBroadcaster.StartStructuredLogging(0)
+	ctrl.eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: ctrl.client.CoreV1().Events("")})
+	defer ctrl.eventBroadcaster.Shutdown()
+
+	defer ctrl.queue.ShutDown()
+
+	klog.Infof("Starting karmada backup controller")
+	defer klog.Infof("Shutting down karmada backup controller")
+
+	if !cache.WaitForNamedCacheSync("karmadabackup", ctx.Done(), ctrl.backupsSynced, ctrl.karmadasSynced, ctrl.jobsSynced) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, ctrl.worker, ctrl.workerLoopPeriod)
+	}
+	<-ctx.Done()
+}
+
+func (ctrl *KarmadaBackupController) worker(ctx context.Context) {
+	for ctrl.processNextWorkItem(ctx) {
+	}
+}
+
+func (ctrl *KarmadaBackupController) processNextWorkItem(ctx context.Context) bool {
+	key, quit := ctrl.queue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.queue.Done(key)
+
+	startTime := time.Now()
+	err := ctrl.syncBackup(ctx, key.(string))
+	metrics.ObserveReconcile("karmadabackup", metrics.PhaseSync, startTime, err)
+	if err == nil {
+		ctrl.queue.Forget(key)
+		return true
+	}
+	if ctrl.queue.NumRequeues(key) < maxRetries {
+		klog.V(2).InfoS("Error syncing karmada backup, retrying", "karmadaBackup", key, "err", err)
+		ctrl.queue.AddRateLimited(key)
+		return true
+	}
+	utilruntime.HandleError(err)
+	klog.V(2).InfoS("Dropping karmada backup out of the queue", "karmadaBackup", key, "err", err)
+	ctrl.queue.Forget(key)
+	return true
+}
+
+func (ctrl *KarmadaBackupController) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	ctrl.queue.Add(key)
+}
+
+// enqueueKarmadaBackups enqueues the backups of the karmada, as their jobs are built from its spec.
+func (ctrl *KarmadaBackupController) enqueueKarmadaBackups(obj interface{}) {
+	karmada := obj.(*installv1alpha1.Karmada)
+	backups, err := ctrl.backupsLister.KarmadaBackups(karmada.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, backup := range backups {
+		if backup.Spec.KarmadaName == karmada.Name {
+			ctrl.enqueue(backup)
+		}
+	}
+}
+
+// enqueueJobBackup enqueues the backup which the job takes a snapshot for.
+func (ctrl *KarmadaBackupController) enqueueJobBackup(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	job, ok := obj.(*batchv1.Job)
+	if !ok {
+		return
+	}
+	if name := job.Labels[constants.BackupLabel]; name != "" {
+		ctrl.queue.Add(job.Namespace + "/" + name)
+	}
+}
+
+func (ctrl *KarmadaBackupController) syncBackup(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	backup, err := ctrl.backupsLister.KarmadaBackups(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		klog.V(2).InfoS("Karmada backup has been deleted", "karmadaBackup", klog.KRef(namespace, name))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// The jobs are garbage collected with the backup.
+	if !backup.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	backup = backup.DeepCopy()
+
+	karmada, err := ctrl.karmadasLister.Karmadas(namespace).Get(backup.Spec.KarmadaName)
+	if err != nil {
+		return fmt.Errorf("failed to get karmada %s of the backup: %v", backup.Spec.KarmadaName, err)
+	}
+	if karmada.Spec.Etcd.External != nil {
+		ctrl.eventRecorder.Eventf(backup, corev1.EventTypeWarning, "ExternalEtcd", "The karmada %s uses an external etcd which can't be backed up", karmada.Name)
+		return nil
+	}
+	if err := validateBackupStorage(backup.Spec.Storage); err != nil {
+		ctrl.eventRecorder.Event(backup, corev1.EventTypeWarning, "InvalidStorage", err.Error())
+		return nil
+	}
+
+	klog.V(4).InfoS("Syncing karmada backup", "karmadaBackup", klog.KObj(backup))
+	if err := ctrl.ensureJobs(karmada, backup); err != nil {
+		return err
+	}
+
+	old := backup.DeepCopy()
+	if err := ctrl.updateSnapshots(backup); err != nil {
+		return err
+	}
+	backup.Status.ObservedGeneration = backup.Generation
+	if equality.Semantic.DeepEqual(old.Status, backup.Status) {
+		return nil
+	}
+	_, err = ctrl.fireflyClient.InstallV1alpha1().KarmadaBackups(backup.Namespace).UpdateStatus(ctx, backup, metav1.UpdateOptions{})
+	return err
+}
+
+// validateBackupStorage returns an error if not exactly one storage is set.
+func validateBackupStorage(storage installv1alpha1.BackupStorage) error {
+	if (storage.PersistentVolumeClaim == nil) == (storage.S3 == nil) {
+		return fmt.Errorf("exactly one of persistentVolumeClaim and s3 must be set in the storage")
+	}
+	return nil
+}
+
+// ensureJobs ensures the cronjob which takes the scheduled snapshots, or the job which takes the single
+// snapshot of a backup without schedule.
+func (ctrl *KarmadaBackupController) ensureJobs(karmada *installv1alpha1.Karmada, backup *installv1alpha1.KarmadaBackup) error {
+	template := batchv1.JobTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{constants.BackupLabel: backup.Name},
+		},
+		Spec: backupJobSpec(karmada, backup),
+	}
+
+	if backup.Spec.Schedule == "" {
+		err := ctrl.client.BatchV1().CronJobs(backup.Namespace).Delete(context.TODO(), backup.Name, metav1.DeleteOptions{})
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		// The template of a job is immutable, so the job is only created once.
+		if _, err := ctrl.jobsLister.Jobs(backup.Namespace).Get(backup.Name); !errors.IsNotFound(err) {
+			return err
+		}
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      backup.Name,
+				Namespace: backup.Namespace,
+				Labels:    template.Labels,
+			},
+			Spec: template.Spec,
+		}
+		controllerutil.SetOwnerReference(backup, job, scheme.Scheme)
+		_, err = ctrl.client.BatchV1().Jobs(backup.Namespace).Create(context.TODO(), job, metav1.CreateOptions{})
+		if err == nil {
+			ctrl.eventRecorder.Eventf(backup, corev1.EventTypeNormal, "SnapshotStarted", "Started snapshot %s", job.Name)
+		}
+		return client.IgnoreAlreadyExists(err)
+	}
+
+	cronJob := &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backup.Name,
+			Namespace: backup.Namespace,
+			Labels:    map[string]string{constants.BackupLabel: backup.Name},
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          backup.Spec.Schedule,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			Suspend:           utilpointer.Bool(backup.Spec.Suspend),
+			// The jobs of the successful snapshots are kept as long as their snapshots are retained.
+			SuccessfulJobsHistoryLimit: utilpointer.Int32(backupRetention(backup)),
+			FailedJobsHistoryLimit:     utilpointer.Int32(1),
+			JobTemplate:                template,
+		},
+	}
+	controllerutil.SetOwnerReference(backup, cronJob, scheme.Scheme)
+	return clientutil.ApplyCronJob(ctrl.client, cronJob)
+}
+
+// updateSnapshots reports the snapshots of the backup from its jobs, each job takes one snapshot named
+// after the job.
+func (ctrl *KarmadaBackupController) updateSnapshots(backup *installv1alpha1.KarmadaBackup) error {
+	jobs, err := ctrl.jobsLister.Jobs(backup.Namespace).List(labels.SelectorFromSet(labels.Set{constants.BackupLabel: backup.Name}))
+	if err != nil {
+		return err
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].CreationTimestamp.Equal(&jobs[j].CreationTimestamp) {
+			return jobs[i].Name < jobs[j].Name
+		}
+		return jobs[i].CreationTimestamp.Before(&jobs[j].CreationTimestamp)
+	})
+
+	snapshots := []installv1alpha1.BackupSnapshot{}
+	for _, job := range jobs {
+		if !metav1.IsControlledBy(job, backup) && !isScheduledBy(job, backup) {
+			continue
+		}
+		snapshot := installv1alpha1.BackupSnapshot{
+			Name:           job.Name,
+			Phase:          installv1alpha1.SnapshotPhaseRunning,
+			StartTime:      job.Status.StartTime,
+			CompletionTime: job.Status.CompletionTime,
+		}
+		for _, condition := range job.Status.Conditions {
+			if condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch condition.Type {
+			case batchv1.JobComplete:
+				snapshot.Phase = installv1alpha1.SnapshotPhaseSucceeded
+			case batchv1.JobFailed:
+				snapshot.Phase = installv1alpha1.SnapshotPhaseFailed
+				snapshot.Message = condition.Message
+				snapshot.CompletionTime = condition.LastTransitionTime.DeepCopy()
+			}
+		}
+		if old := findSnapshot(backup.Status.Snapshots, snapshot.Name); old == nil || old.Phase != snapshot.Phase {
+			switch snapshot.Phase {
+			case installv1alpha1.SnapshotPhaseSucceeded:
+				ctrl.eventRecorder.Eventf(backup, corev1.EventTypeNormal, "SnapshotSucceeded", "Snapshot %s is stored", snapshot.Name)
+			case installv1alpha1.SnapshotPhaseFailed:
+				ctrl.eventRecorder.Eventf(backup, corev1.EventTypeWarning, "SnapshotFailed", "Snapshot %s failed: %s", snapshot.Name, snapshot.Message)
+			}
+		}
+
+		if snapshot.StartTime != nil {
+			backup.Status.LastScheduleTime = snapshot.StartTime.DeepCopy()
+		}
+		if snapshot.Phase == installv1alpha1.SnapshotPhaseSucceeded && snapshot.CompletionTime != nil {
+			backup.Status.LastSuccessfulTime = snapshot.CompletionTime.DeepCopy()
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	backup.Status.Snapshots = snapshots
+	return nil
+}
+
+// isScheduledBy returns whether the job is created by the cronjob of the backup.
+func isScheduledBy(job *batchv1.Job, backup *installv1alpha1.KarmadaBackup) bool {
+	owner := metav1.GetControllerOf(job)
+	return owner != nil && owner.Kind == "CronJob" && owner.Name == backup.Name
+}
+
+// findSnapshot returns the snapshot with the given name, or nil if it doesn't exist.
+func findSnapshot(snapshots []installv1alpha1.BackupSnapshot, name string) *installv1alpha1.BackupSnapshot {
+	for i := range snapshots {
+		if snapshots[i].Name == name {
+			return &snapshots[i]
+		}
+	}
+	return nil
+}
+
+// backupRetention returns the number of scheduled snapshots which are kept.
+func backupRetention(backup *installv1alpha1.KarmadaBackup) int32 {
+	if backup.Spec.Retention != nil && *backup.Spec.Retention > 0 {
+		return *backup.Spec.Retention
+	}
+	return defaultBackupRetention
+}
+
+// backupDirectory returns the directory of the snapshots of the backup, relative to the root of the storage.
+func backupDirectory(backup *installv1alpha1.KarmadaBackup) string {
+	return backup.Namespace + "/" + backup.Name
+}
+
+// s3Command returns the shell prelude which configures the MinIO client with the credentials of the storage.
+// The client is invoked as $MC afterwards, and the storage is addressed as backup/<bucket>/<prefix>.
+func s3Command(s3 *installv1alpha1.S3Storage) string {
+	mc := "mc --config-dir /tmp/.mc"
+	if s3.Insecure {
+		mc += " --insecure"
+	}
+	return fmt.Sprintf(`set -e
+MC=%q
+$MC alias set backup "$S3_ENDPOINT" "$AWS_ACCESS_KEY_ID" "$AWS_SECRET_ACCESS_KEY" >/dev/null
+`, mc)
+}
+
+// s3Path returns the path of the directory of the snapshots of the backup for the MinIO client.
+func s3Path(backup *installv1alpha1.KarmadaBackup) string {
+	s3 := backup.Spec.Storage.S3
+	return path.Join("backup", s3.Bucket, s3.Prefix, backupDirectory(backup))
+}
+
+// s3Container returns a container of the MinIO client which runs the script against the object storage.
+func s3Container(karmada *installv1alpha1.Karmada, backup *installv1alpha1.KarmadaBackup, name, script string) corev1.Container {
+	s3 := backup.Spec.Storage.S3
+	image := s3.Image
+	if image == "" {
+		image = defaultS3ClientImage
+	}
+	credential := func(key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: key,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: s3.CredentialsSecretRef,
+					Key:                  key,
+				},
+			},
+		}
+	}
+	return corev1.Container{
+		Name:            name,
+		Image:           image,
+		ImagePullPolicy: util.ImagePullPolicy(karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
+		Command:         []string{"/bin/sh", "-c", s3Command(s3) + script},
+		Env: []corev1.EnvVar{
+			{Name: "S3_ENDPOINT", Value: s3.Endpoint},
+			credential("AWS_ACCESS_KEY_ID"),
+			credential("AWS_SECRET_ACCESS_KEY"),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "snapshots", MountPath: snapshotsPath},
+		},
+	}
+}
+
+// backupJobSpec returns the spec of the jobs which take the snapshots of the backup. The snapshot is saved by
+// etcdctl with the client certificates of the karmada from the leader, or from another healthy member if the
+// leader can't be reached, then it's uploaded if it's stored in an object storage. After the snapshot is
+// stored, the scheduled snapshots beyond the retention are deleted.
+func backupJobSpec(karmada *installv1alpha1.Karmada, backup *installv1alpha1.KarmadaBackup) batchv1.JobSpec {
+	image, pullPolicy, pullSecrets := etcdImage(karmada)
+	storage := backup.Spec.Storage
+	retention := backupRetention(backup)
+
+	// The snapshot is written to the volume directly, or to a scratch volume from which it's uploaded.
+	directory := snapshotsPath
+	snapshotsVolume := corev1.Volume{
+		Name:         "snapshots",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	if storage.PersistentVolumeClaim != nil {
+		directory = snapshotsPath + "/" + backupDirectory(backup)
+		snapshotsVolume.VolumeSource = corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: storage.PersistentVolumeClaim.ClaimName},
+		}
+	}
+
+	// The snapshot is named after the job which takes it.
+	snapshotEnv := corev1.EnvVar{
+		Name: "SNAPSHOT",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['job-name']"},
+		},
+	}
+	var members []string
+	for ordinal := int32(0); ordinal < etcdReplicas(karmada); ordinal++ {
+		members = append(members, etcdClientURL(karmada, ordinal))
+	}
+	// The columns of the endpoint status are the endpoint, the member id, the version, the db size, whether
+	// the member is the leader and whether it's a learner.
+	save := fmt.Sprintf(`set -e
+ETCDCTL="etcdctl --cacert=/etc/etcd/pki/etcd-ca.crt --cert=/etc/etcd/pki/etcd-client.crt --key=/etc/etcd/pki/etcd-client.key"
+ENDPOINT=""
+for member in %[2]s; do
+  STATUS=$($ETCDCTL --endpoints="$member" endpoint status 2>/dev/null) || continue
+  if [ -z "$ENDPOINT" ] || echo "$STATUS" | grep -q ', true, false,'; then
+    ENDPOINT="$member"
+  fi
+done
+if [ -z "$ENDPOINT" ]; then
+  echo "No healthy etcd member is found"
+  exit 1
+fi
+mkdir -p %[1]s
+$ETCDCTL --endpoints="$ENDPOINT" snapshot save %[1]s/$SNAPSHOT.db
+`, directory, strings.Join(members, " "))
+	if storage.PersistentVolumeClaim != nil && backup.Spec.Schedule != "" {
+		// The names of the scheduled jobs end with the scheduled time, so the newest snapshots sort last.
+		save += fmt.Sprintf("ls -1 %s/*.db | sort -r | tail -n +%d | xargs -r rm -f\n", directory, retention+1)
+	}
+
+	snapshot := corev1.Container{
+		Name:            "snapshot",
+		Image:           image,
+		ImagePullPolicy: pullPolicy,
+		Command:         []string{"/bin/sh", "-c", save},
+		Env:             []corev1.EnvVar{{Name: "ETCDCTL_API", Value: "3"}, snapshotEnv},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "etcd-certs", MountPath: "/etc/etcd/pki", ReadOnly: true},
+			{Name: "snapshots", MountPath: snapshotsPath},
+		},
+	}
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		Containers:    []corev1.Container{snapshot},
+		Volumes: []corev1.Volume{
+			{
+				Name: "etcd-certs",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: generateCertSecretName(karmada),
+						Items: []corev1.KeyToPath{
+							{Key: "etcd-ca.crt", Path: "etcd-ca.crt"},
+							{Key: "etcd-client.crt", Path: "etcd-client.crt"},
+							{Key: "etcd-client.key", Path: "etcd-client.key"},
+						},
+					},
+				},
+			},
+			snapshotsVolume,
+		},
+	}
+	if storage.S3 != nil {
+		upload := fmt.Sprintf("$MC cp %s/$SNAPSHOT.db %s/$SNAPSHOT.db\n", snapshotsPath, s3Path(backup))
+		if backup.Spec.Schedule != "" {
+			upload += fmt.Sprintf("$MC find %s --name '*.db' | sort -r | tail -n +%d | while read -r f; do $MC rm \"$f\"; done\n", s3Path(backup), retention+1)
+		}
+		podSpec.InitContainers = []corev1.Container{snapshot}
+		uploader := s3Container(karmada, backup, "upload", upload)
+		uploader.Env = append(uploader.Env, snapshotEnv)
+		podSpec.Containers = []corev1.Container{uploader}
+	}
+
+	util.SetPodImagePullSecrets(&podSpec, pullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&podSpec, nil, karmada.Spec.Placement)
+
+	return batchv1.JobSpec{
+		BackoffLimit: utilpointer.Int32(2),
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{constants.BackupLabel: backup.Name},
+			},
+			Spec: podSpec,
+		},
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilpointer "k8s.io/utils/pointer"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+)
+
+func TestS3Path(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		want   string
+	}{
+		{
+			name: "no prefix",
+			want: "backup/snapshots/firefly-system/daily",
+		},
+		{
+			name:   "prefix without a trailing slash",
+			prefix: "karmada",
+			want:   "backup/snapshots/karmada/firefly-system/daily",
+		},
+		{
+			name:   "prefix with slashes",
+			prefix: "/clusters/prod/",
+			want:   "backup/snapshots/clusters/prod/firefly-system/daily",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := &installv1alpha1.KarmadaBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "firefly-system"},
+				Spec: installv1alpha1.KarmadaBackupSpec{
+					Storage: installv1alpha1.BackupStorage{
+						S3: &installv1alpha1.S3Storage{Bucket: "snapshots", Prefix: tt.prefix},
+					},
+				},
+			}
+			if got := s3Path(backup); got != tt.want {
+				t.Errorf("s3Path() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackupJobSpecSnapshotsFromAllMembers(t *testing.T) {
+	karmada := testKarmada()
+	karmada.Spec.Etcd.Local = &installv1alpha1.LocalEtcd{Replicas: utilpointer.Int32(3)}
+	backup := &installv1alpha1.KarmadaBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "firefly-system"},
+		Spec: installv1alpha1.KarmadaBackupSpec{
+			Storage: installv1alpha1.BackupStorage{
+				PersistentVolumeClaim: &installv1alpha1.PersistentVolumeClaimStorage{ClaimName: "snapshots"},
+			},
+		},
+	}
+	script := backupJobSpec(karmada, backup).Template.Spec.Containers[0].Command[2]
+	for ordinal := int32(0); ordinal < 3; ordinal++ {
+		if !strings.Contains(script, etcdClientURL(karmada, ordinal)) {
+			t.Errorf("backupJobSpec() doesn't try the member %s", etcdClientURL(karmada, ordinal))
+		}
+	}
+}
@@ -18,6 +18,8 @@ package karmada
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"time"
@@ -34,10 +36,24 @@ import (
 	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
 	"github.com/carlory/firefly/pkg/constants"
 	"github.com/carlory/firefly/pkg/scheme"
-	"github.com/carlory/firefly/pkg/util"
 	"github.com/carlory/firefly/pkg/util/certs"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
 )
 
+// caCertList is the list of the CA certificates of the karmada, which are persisted in the karmada-cert secret.
+var caCertList = []string{
+	"ca",
+	"etcd-ca",
+	"front-proxy-ca",
+}
+
+// caCommonNames is the common names of the CA certificates of the karmada.
+var caCommonNames = map[string]string{
+	"ca":             "karmada",
+	"etcd-ca":        "etcd-ca",
+	"front-proxy-ca": "front-proxy-ca",
+}
+
 var certList = []string{
 	"ca",
 	"etcd-ca",
@@ -49,24 +65,72 @@ var certList = []string{
 	"front-proxy-client",
 }
 
-func (ctrl *KarmadaController) genCerts(karmada *installv1alpha1.Karmada, karmadaAPIServerIP []net.IP) error {
-	notAfter := time.Now().Add(certs.Duration365d).UTC()
+// EnsureCerts ensures the certificates of the karmada exist. The kube-apiserver service is ensured
+// first, because its addresses must be included in the certificates.
+func (ctrl *KarmadaController) EnsureCerts(karmada *installv1alpha1.Karmada) error {
+	if err := ctrl.EnsureKubeAPIServerService(karmada); err != nil {
+		return err
+	}
+
+	altNames, err := ctrl.kubeAPIServerServiceAltNames(karmada)
+	if err != nil {
+		return err
+	}
+	return ctrl.genCerts(karmada, altNames)
+}
+
+// appendAltNames appends the SANs to the alternative names. A SAN is treated as an IP address
+// if it can be parsed as one, otherwise as a DNS name.
+func appendAltNames(altNames *certutil.AltNames, sans ...string) {
+	for _, san := range sans {
+		if ip := netutils.ParseIPSloppy(san); ip != nil {
+			altNames.IPs = append(altNames.IPs, ip)
+		} else if san != "" {
+			altNames.DNSNames = append(altNames.DNSNames, san)
+		}
+	}
+}
+
+// genCerts issues the certificates of the karmada and writes them into the secrets. The leaf certificates
+// are either signed by the CAs of the karmada or requested from the cert-manager issuer of the karmada.
+// A signed leaf certificate is only issued again if it's missing, about to expire, not signed by the current
+// CA or its subject differs from the desired one, e.g. because the DNSDomain or the SANs are changed.
+func (ctrl *KarmadaController) genCerts(karmada *installv1alpha1.Karmada, apiServerAltNames certutil.AltNames) error {
+	notAfter := time.Now().Add(certificateValidity(karmada)).UTC()
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	kubeAPIServerName := karmadaComponentName(karmada, constants.KarmadaComponentKubeAPIServer)
+	webhookName := karmadaComponentName(karmada, constants.KarmadaComponentWebhook)
+	aggregatedAPIServerName := karmadaComponentName(karmada, constants.KarmadaComponentAggregratedAPIServer)
 
 	var etcdServerCertDNS = []string{
 		"localhost",
-		fmt.Sprintf("%s.%s.svc", constants.KarmadaComponentEtcd, karmada.Namespace),
-		fmt.Sprintf("%s.%s.svc.%s", constants.KarmadaComponentEtcd, karmada.Namespace, karmada.Spec.Networking.DNSDomain),
+		fmt.Sprintf("%s.%s.svc", etcdName, karmada.Namespace),
+		fmt.Sprintf("%s.%s.svc.%s", etcdName, karmada.Namespace, karmada.Spec.Networking.DNSDomain),
 	}
-	for number := int32(0); number < 1; number++ {
-		etcdServerCertDNS = append(etcdServerCertDNS, fmt.Sprintf("%s-%v.%s.%s.svc", constants.KarmadaComponentEtcd, number, constants.KarmadaComponentEtcd, karmada.Namespace))
-		etcdServerCertDNS = append(etcdServerCertDNS, fmt.Sprintf("%s-%v.%s.%s.svc.%s", constants.KarmadaComponentEtcd, number, constants.KarmadaComponentEtcd, karmada.Namespace, karmada.Spec.Networking.DNSDomain))
+	for number := int32(0); number < etcdReplicas(karmada); number++ {
+		etcdServerCertDNS = append(etcdServerCertDNS, fmt.Sprintf("%s-%v.%s.%s.svc", etcdName, number, etcdName, karmada.Namespace))
+		etcdServerCertDNS = append(etcdServerCertDNS, fmt.Sprintf("%s-%v.%s.%s.svc.%s", etcdName, number, etcdName, karmada.Namespace, karmada.Spec.Networking.DNSDomain))
 	}
+	// Members added by a scale up must be able to use the certificates without regenerating them.
+	etcdServerCertDNS = append(etcdServerCertDNS,
+		fmt.Sprintf("*.%s.%s.svc", etcdName, karmada.Namespace),
+		fmt.Sprintf("*.%s.%s.svc.%s", etcdName, karmada.Namespace, karmada.Spec.Networking.DNSDomain),
+	)
 
 	etcdServerAltNames := certutil.AltNames{
 		DNSNames: etcdServerCertDNS,
 		IPs:      []net.IP{netutils.ParseIPSloppy("127.0.0.1")},
 	}
+	etcdPeerAltNames := certutil.AltNames{
+		DNSNames: append([]string{}, etcdServerCertDNS...),
+		IPs:      []net.IP{netutils.ParseIPSloppy("127.0.0.1")},
+	}
+	if etcd := karmada.Spec.Etcd.Local; etcd != nil {
+		appendAltNames(&etcdServerAltNames, etcd.ServerCertSANs...)
+		appendAltNames(&etcdPeerAltNames, etcd.PeerCertSANs...)
+	}
 	etcdServerCertConfig := certs.NewCertConfig("karmada-etcd-server", []string{}, etcdServerAltNames, &notAfter)
+	etcdPeerCertConfig := certs.NewCertConfig("karmada-etcd-peer", []string{}, etcdPeerAltNames, &notAfter)
 	etcdClientCertCfg := certs.NewCertConfig("karmada-etcd-client", []string{}, certutil.AltNames{}, &notAfter)
 
 	var karmadaDNS = []string{
@@ -74,14 +138,14 @@ func (ctrl *KarmadaController) genCerts(karmada *installv1alpha1.Karmada, karmad
 		"kubernetes",
 		"kubernetes.default",
 		"kubernetes.default.svc",
-		constants.KarmadaComponentKubeAPIServer,
-		constants.KarmadaComponentWebhook,
-		constants.KarmadaComponentAggregratedAPIServer,
-		fmt.Sprintf("%s.%s.svc", constants.KarmadaComponentKubeAPIServer, karmada.Namespace),
-		fmt.Sprintf("%s.%s.svc.%s", constants.KarmadaComponentKubeAPIServer, karmada.Namespace, karmada.Spec.Networking.DNSDomain),
-		fmt.Sprintf("%s.%s.svc.%s", constants.KarmadaComponentWebhook, karmada.Namespace, karmada.Spec.Networking.DNSDomain),
-		fmt.Sprintf("%s.%s.svc", constants.KarmadaComponentWebhook, karmada.Namespace),
-		fmt.Sprintf("%s.%s.svc.%s", constants.KarmadaComponentAggregratedAPIServer, karmada.Namespace, karmada.Spec.Networking.DNSDomain),
+		kubeAPIServerName,
+		webhookName,
+		aggregatedAPIServerName,
+		fmt.Sprintf("%s.%s.svc", kubeAPIServerName, karmada.Namespace),
+		fmt.Sprintf("%s.%s.svc.%s", kubeAPIServerName, karmada.Namespace, karmada.Spec.Networking.DNSDomain),
+		fmt.Sprintf("%s.%s.svc.%s", webhookName, karmada.Namespace, karmada.Spec.Networking.DNSDomain),
+		fmt.Sprintf("%s.%s.svc", webhookName, karmada.Namespace),
+		fmt.Sprintf("%s.%s.svc.%s", aggregatedAPIServerName, karmada.Namespace, karmada.Spec.Networking.DNSDomain),
 		fmt.Sprintf("*.%s.svc.%s", karmada.Namespace, karmada.Spec.Networking.DNSDomain),
 		fmt.Sprintf("*.%s.svc", karmada.Namespace),
 	}
@@ -92,85 +156,300 @@ func (ctrl *KarmadaController) genCerts(karmada *installv1alpha1.Karmada, karmad
 		netutils.ParseIPSloppy("127.0.0.1"),
 		netutils.ParseIPSloppy("10.254.0.1"),
 	)
-	if len(karmadaAPIServerIP) > 0 {
-		karmadaIPs = append(karmadaIPs, karmadaAPIServerIP...)
-	}
-
-	internetIP, err := util.InternetIP()
-	if err != nil {
-		klog.Warningln("Failed to obtain internet IP. ", err)
-	} else {
-		karmadaIPs = append(karmadaIPs, internetIP)
+	// The first IP of the service subnet is used by the kubernetes service of the karmada-apiserver.
+	if _, serviceSubnet, err := netutils.ParseCIDRSloppy(karmada.Spec.Networking.ServiceSubnet); err == nil {
+		if ip, err := netutils.GetIndexedIP(serviceSubnet, 1); err == nil {
+			karmadaIPs = append(karmadaIPs, ip)
+		}
 	}
+	karmadaIPs = append(karmadaIPs, apiServerAltNames.IPs...)
+	karmadaDNS = append(karmadaDNS, apiServerAltNames.DNSNames...)
 
 	karmadaAltNames := certutil.AltNames{
 		DNSNames: karmadaDNS,
 		IPs:      karmadaIPs,
 	}
+	appendAltNames(&karmadaAltNames, karmada.Spec.APIServer.KubeAPIServer.CertSANs...)
 	karmadaCertCfg := certs.NewCertConfig("system:admin", []string{"system:masters"}, karmadaAltNames, &notAfter)
 
 	apiserverCertCfg := certs.NewCertConfig("karmada-apiserver", []string{""}, karmadaAltNames, &notAfter)
 
 	frontProxyClientCertCfg := certs.NewCertConfig("front-proxy-client", []string{}, certutil.AltNames{}, &notAfter)
-	data, err := certs.GenCerts(etcdServerCertConfig, etcdClientCertCfg, karmadaCertCfg, apiserverCertCfg, frontProxyClientCertCfg)
+
+	leaves := []leafCert{
+		{name: "etcd-server", ca: "etcd-ca", config: etcdServerCertConfig},
+		{name: "etcd-peer", ca: "etcd-ca", config: etcdPeerCertConfig},
+		{name: "etcd-client", ca: "etcd-ca", config: etcdClientCertCfg},
+		{name: "karmada", ca: "ca", config: karmadaCertCfg},
+		{name: "apiserver", ca: "ca", config: apiserverCertCfg},
+		{name: "front-proxy-client", ca: "front-proxy-ca", config: frontProxyClientCertCfg},
+	}
+	// The etcd certificates of an external etcd are supplied by the user, firefly neither generates an etcd
+	// CA nor signs etcd certificates for it.
+	if karmada.Spec.Etcd.External != nil {
+		var nonEtcdLeaves []leafCert
+		for _, leaf := range leaves {
+			if leaf.ca != "etcd-ca" {
+				nonEtcdLeaves = append(nonEtcdLeaves, leaf)
+			}
+		}
+		leaves = nonEtcdLeaves
+	}
+
+	current, err := ctrl.getSecretData(karmada.Namespace, generateCertSecretName(karmada))
+	if err != nil {
+		return err
+	}
+	// The etcd peer certificate is only stored in the etcd-cert secret.
+	etcdCurrent, err := ctrl.getSecretData(karmada.Namespace, generateComponentCertSecretName(karmada, constants.KarmadaComponentEtcd))
 	if err != nil {
 		return err
 	}
+	if current == nil {
+		current = map[string][]byte{}
+	}
+	for _, key := range []string{"etcd-peer.crt", "etcd-peer.key"} {
+		current[key] = etcdCurrent[key]
+	}
+
+	var data map[string][]byte
+	var statuses []installv1alpha1.CertificateStatus
+	if karmada.Spec.Certificates.IssuerRef != nil {
+		if karmada.Spec.Certificates.CA != nil {
+			return fmt.Errorf("the CA secrets and the issuer of the certificates are mutually exclusive")
+		}
+		data, statuses, err = ctrl.issueCerts(karmada, leaves, current)
+	} else {
+		data, statuses, err = ctrl.signCerts(karmada, leaves, current)
+	}
+	if err != nil {
+		return err
+	}
+	karmada.Status.Certificates = statuses
+	if karmada.Spec.Etcd.External != nil {
+		externalCerts, err := ctrl.externalEtcdCerts(karmada)
+		if err != nil {
+			return err
+		}
+		for key, value := range externalCerts {
+			data[key] = value
+		}
+	}
 
 	// Create kubeconfig Secret
-	karmadaServerURL := fmt.Sprintf("https://%s.%s.svc.%s:%v", constants.KarmadaComponentKubeAPIServer, karmada.Namespace, karmada.Spec.Networking.DNSDomain, 5443)
+	karmadaServerURL := fmt.Sprintf("https://%s.%s.svc.%s:%v", kubeAPIServerName, karmada.Namespace, karmada.Spec.Networking.DNSDomain, kubeAPIServerSecurePort)
 	config := certs.CreateWithCerts(karmadaServerURL, "karmada-admin", "karmada-admin", data["ca.crt"], data["karmada.key"], data["karmada.crt"])
 	configBytes, err := clientcmd.Write(*config)
 	if err != nil {
 		return fmt.Errorf("failure while serializing admin kubeConfig. %v", err)
 	}
-
-	kubeConfigSecret := SecretFromSpec(karmada.Namespace, "karmada-kubeconfig", corev1.SecretTypeOpaque, map[string]string{"kubeconfig": string(configBytes)})
-	controllerutil.SetOwnerReference(karmada, kubeConfigSecret, scheme.Scheme)
-	_, err = ctrl.client.CoreV1().Secrets(karmada.Namespace).Create(context.TODO(), kubeConfigSecret, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
+	if err := ctrl.ensureCertSecret(karmada, GenerateKubeConfigSecretName(karmada), map[string][]byte{"kubeconfig": configBytes}); err != nil {
 		return err
 	}
 
 	// Create certs Secret
-	etcdCert := map[string]string{
-		"etcd-ca.crt":     string(data["etcd-ca.crt"]),
-		"etcd-ca.key":     string(data["etcd-ca.key"]),
-		"etcd-server.crt": string(data["etcd-server.crt"]),
-		"etcd-server.key": string(data["etcd-server.key"]),
-	}
-	etcdSecret := SecretFromSpec(karmada.Namespace, fmt.Sprintf("%s-cert", constants.KarmadaComponentEtcd), corev1.SecretTypeOpaque, etcdCert)
-	controllerutil.SetOwnerReference(karmada, etcdSecret, scheme.Scheme)
-	_, err = ctrl.client.CoreV1().Secrets(karmada.Namespace).Create(context.TODO(), etcdSecret, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return err
+	etcdCert := map[string][]byte{
+		"etcd-ca.crt":     data["etcd-ca.crt"],
+		"etcd-ca.key":     data["etcd-ca.key"],
+		"etcd-server.crt": data["etcd-server.crt"],
+		"etcd-server.key": data["etcd-server.key"],
+		"etcd-peer.crt":   data["etcd-peer.crt"],
+		"etcd-peer.key":   data["etcd-peer.key"],
+	}
+	if karmada.Spec.Etcd.External == nil {
+		if err := ctrl.ensureCertSecret(karmada, generateComponentCertSecretName(karmada, constants.KarmadaComponentEtcd), etcdCert); err != nil {
+			return err
+		}
 	}
 
-	karmadaCert := map[string]string{}
+	// The certificates which don't exist, e.g. the etcd CA key of an external etcd, are left out.
+	karmadaCert := map[string][]byte{}
 	for _, v := range certList {
-		karmadaCert[fmt.Sprintf("%s.crt", v)] = string(data[fmt.Sprintf("%s.crt", v)])
-		karmadaCert[fmt.Sprintf("%s.key", v)] = string(data[fmt.Sprintf("%s.key", v)])
+		for _, key := range []string{v + ".crt", v + ".key"} {
+			if len(data[key]) > 0 {
+				karmadaCert[key] = data[key]
+			}
+		}
 	}
-	karmadaSecret := SecretFromSpec(karmada.Namespace, "karmada-cert", corev1.SecretTypeOpaque, karmadaCert)
-	controllerutil.SetOwnerReference(karmada, karmadaSecret, scheme.Scheme)
-	_, err = ctrl.client.CoreV1().Secrets(karmada.Namespace).Create(context.TODO(), karmadaSecret, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
+	if err := ctrl.ensureCertSecret(karmada, generateCertSecretName(karmada), karmadaCert); err != nil {
 		return err
 	}
-	karmadaWebhookCert := map[string]string{
-		"tls.crt": string(data["karmada.crt"]),
-		"tls.key": string(data["karmada.key"]),
+
+	karmadaWebhookCert := map[string][]byte{
+		"tls.crt": data["karmada.crt"],
+		"tls.key": data["karmada.key"],
 	}
-	karmadaWebhookSecret := SecretFromSpec(karmada.Namespace, fmt.Sprintf("%s-cert", constants.KarmadaComponentWebhook), corev1.SecretTypeOpaque, karmadaWebhookCert)
-	controllerutil.SetOwnerReference(karmada, karmadaWebhookSecret, scheme.Scheme)
-	_, err = ctrl.client.CoreV1().Secrets(karmada.Namespace).Create(context.TODO(), karmadaWebhookSecret, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return err
+	return ctrl.ensureCertSecret(karmada, generateComponentCertSecretName(karmada, constants.KarmadaComponentWebhook), karmadaWebhookCert)
+}
+
+// leafCert is a leaf certificate of the karmada and the CA which signs it.
+type leafCert struct {
+	name   string
+	ca     string
+	config *certs.CertsConfig
+}
+
+// signCerts signs the leaf certificates with the CAs of the karmada and returns the PEM encoded
+// certificates and keys of both the CAs and the leaves.
+func (ctrl *KarmadaController) signCerts(karmada *installv1alpha1.Karmada, leaves []leafCert, current map[string][]byte) (map[string][]byte, []installv1alpha1.CertificateStatus, error) {
+	data := map[string][]byte{}
+	caCerts := map[string]*x509.Certificate{}
+	caKeys := map[string]crypto.Signer{}
+	for _, ca := range caCertList {
+		// The CA of an external etcd is supplied together with its client certificate.
+		if ca == "etcd-ca" && karmada.Spec.Etcd.External != nil {
+			continue
+		}
+		caCert, caKey, certData, keyData, err := ctrl.loadCA(karmada, ca, current)
+		if err != nil {
+			return nil, nil, err
+		}
+		data[ca+".crt"], data[ca+".key"] = certData, keyData
+		caCerts[ca], caKeys[ca] = caCert, caKey
+	}
+
+	renewBefore := certificateRenewBefore(karmada)
+	var statuses []installv1alpha1.CertificateStatus
+	for _, leaf := range leaves {
+		certData, keyData := current[leaf.name+".crt"], current[leaf.name+".key"]
+		cert, _, err := certs.ParseCertAndKey(certData, keyData)
+		if err == nil {
+			reason := certs.CertRenewalReason(cert, caCerts[leaf.ca], leaf.config, renewBefore)
+			if reason == "" {
+				data[leaf.name+".crt"], data[leaf.name+".key"] = certData, keyData
+				statuses = append(statuses, installv1alpha1.CertificateStatus{Name: leaf.name, NotAfter: metav1.NewTime(cert.NotAfter)})
+				continue
+			}
+			klog.InfoS("Renewing certificate", "karmada", klog.KObj(karmada), "certificate", leaf.name, "reason", reason)
+			ctrl.eventRecorder.Eventf(karmada, corev1.EventTypeNormal, "RenewingCertificate", "Renewing the %s certificate: %s", leaf.name, reason)
+		}
+
+		cert, key, err := certs.NewCertAndKey(caCerts[leaf.ca], caKeys[leaf.ca], leaf.config)
+		if err != nil {
+			return nil, nil, err
+		}
+		if data[leaf.name+".crt"], data[leaf.name+".key"], err = certs.EncodeCertAndKeyPEM(cert, key); err != nil {
+			return nil, nil, err
+		}
+		statuses = append(statuses, installv1alpha1.CertificateStatus{Name: leaf.name, NotAfter: metav1.NewTime(cert.NotAfter)})
+	}
+	return data, statuses, nil
+}
+
+// loadCA returns the CA certificate and key of the authority, and their PEM encoded data. A CA referenced
+// by the karmada takes precedence over the persisted one, and a self-signed CA is generated if neither
+// exists. The certificate data of a referenced CA is kept as is, so that its whole chain is trusted.
+func (ctrl *KarmadaController) loadCA(karmada *installv1alpha1.Karmada, ca string, current map[string][]byte) (*x509.Certificate, crypto.Signer, []byte, []byte, error) {
+	if ref := caSecretRef(karmada, ca); ref != nil {
+		secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to get the CA secret %q: %v", ref.Name, err)
+		}
+		certData, keyData := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+		caCert, caKey, err := certs.ParseCertAndKey(certData, keyData)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("invalid CA secret %q: %v", ref.Name, err)
+		}
+		if !caCert.IsCA {
+			return nil, nil, nil, nil, fmt.Errorf("invalid CA secret %q: the certificate is not a CA", ref.Name)
+		}
+		return caCert, caKey, certData, keyData, nil
+	}
+
+	caCert, caKey, err := certs.ParseCertAndKey(current[ca+".crt"], current[ca+".key"])
+	if err != nil {
+		klog.InfoS("Generating CA certificate", "karmada", klog.KObj(karmada), "certificate", ca)
+		caCert, caKey, err = certs.NewCACertAndKey(caCommonNames[ca])
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+	certData, keyData, err := certs.EncodeCertAndKeyPEM(caCert, caKey)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return caCert, caKey, certData, keyData, nil
+}
+
+// caSecretRef returns the reference to the secret which holds the given CA, or nil if the CA isn't supplied by the user.
+func caSecretRef(karmada *installv1alpha1.Karmada, ca string) *corev1.LocalObjectReference {
+	authorities := karmada.Spec.Certificates.CA
+	if authorities == nil {
+		return nil
+	}
+	switch ca {
+	case "ca":
+		return authorities.Karmada
+	case "etcd-ca":
+		return authorities.Etcd
+	case "front-proxy-ca":
+		return authorities.FrontProxy
 	}
 	return nil
 }
 
+// getSecretData returns the data of the secret, or nil if the secret doesn't exist.
+func (ctrl *KarmadaController) getSecretData(namespace, name string) (map[string][]byte, error) {
+	secret, err := ctrl.client.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+// ensureCertSecret applies the secret with the given data.
+func (ctrl *KarmadaController) ensureCertSecret(karmada *installv1alpha1.Karmada, name string, data map[string][]byte) error {
+	secret := SecretFromSpec(karmada.Namespace, name, corev1.SecretTypeOpaque, nil)
+	secret.Data = data
+	controllerutil.SetOwnerReference(karmada, secret, scheme.Scheme)
+	return clientutil.ApplySecret(ctrl.client, secret)
+}
+
+// certificateValidity returns the duration for which the leaf certificates of the karmada are valid.
+func certificateValidity(karmada *installv1alpha1.Karmada) time.Duration {
+	if validity := karmada.Spec.Certificates.Validity; validity != nil && validity.Duration > 0 {
+		return validity.Duration
+	}
+	return certs.Duration365d
+}
+
+// certificateRenewBefore returns the duration before the expiry at which the leaf certificates of the karmada are renewed.
+func certificateRenewBefore(karmada *installv1alpha1.Karmada) time.Duration {
+	if renewBefore := karmada.Spec.Certificates.RenewBefore; renewBefore != nil {
+		return renewBefore.Duration
+	}
+	return 30 * 24 * time.Hour
+}
+
+// nextCertificateRenewal returns the duration after which the first leaf certificate of the karmada must be renewed.
+func nextCertificateRenewal(karmada *installv1alpha1.Karmada) (time.Duration, bool) {
+	var next time.Time
+	for _, status := range karmada.Status.Certificates {
+		if next.IsZero() || status.NotAfter.Time.Before(next) {
+			next = status.NotAfter.Time
+		}
+	}
+	if next.IsZero() {
+		return 0, false
+	}
+	// A certificate issued by cert-manager may be renewed a bit later than expected.
+	renewal := time.Until(next.Add(-certificateRenewBefore(karmada)))
+	if renewal < notReadyRequeueInterval {
+		renewal = notReadyRequeueInterval
+	}
+	return renewal, true
+}
+
+// SecretFromSpec returns a secret with the given data. The data is set as bytes rather than string data, which
+// is never returned by the server, so that the applied secret isn't considered as drifted on every sync.
 func SecretFromSpec(namespace, name string, secretType corev1.SecretType, data map[string]string) *corev1.Secret {
+	secretData := make(map[string][]byte, len(data))
+	for key, value := range data {
+		secretData[key] = []byte(value)
+	}
 	return &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
@@ -181,8 +460,7 @@ func SecretFromSpec(namespace, name string, secretType corev1.SecretType, data m
 			Namespace: namespace,
 			Labels:    map[string]string{"karmada.io/bootstrapping": "secret-defaults"},
 		},
-		//Immutable:  immutable,
-		Type:       secretType,
-		StringData: data,
+		Type: secretType,
+		Data: secretData,
 	}
 }
@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/scheme"
+	"github.com/carlory/firefly/pkg/util/certs"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+)
+
+// certificateGVR is the resource of the cert-manager certificates.
+var certificateGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+// issuedCertificateName returns the name of the cert-manager certificate, and of the secret it's stored in,
+// of the given leaf certificate.
+func issuedCertificateName(karmada *installv1alpha1.Karmada, leaf string) string {
+	return karmadaComponentName(karmada, leaf+"-tls")
+}
+
+// issueCerts requests the leaf certificates from the cert-manager issuer of the karmada and returns the
+// PEM encoded certificates and keys of the leaves, together with the CA certificates taken from the
+// issued secrets. It fails until all the certificates are issued by cert-manager.
+func (ctrl *KarmadaController) issueCerts(karmada *installv1alpha1.Karmada, leaves []leafCert, current map[string][]byte) (map[string][]byte, []installv1alpha1.CertificateStatus, error) {
+	data := map[string][]byte{}
+	var statuses []installv1alpha1.CertificateStatus
+	var pending []string
+	for _, leaf := range leaves {
+		name := issuedCertificateName(karmada, leaf.name)
+		ready, err := ctrl.ensureIssuedCertificate(karmada, name, leaf.config)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ready {
+			pending = append(pending, name)
+			continue
+		}
+
+		secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get the secret of the certificate %q: %v", name, err)
+		}
+		certData, keyData := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+		cert, _, err := certs.ParseCertAndKey(certData, keyData)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid secret of the certificate %q: %v", name, err)
+		}
+		caData := secret.Data["ca.crt"]
+		if len(caData) == 0 {
+			return nil, nil, fmt.Errorf("the issuer %q doesn't provide the CA of the certificate %q", karmada.Spec.Certificates.IssuerRef.Name, name)
+		}
+
+		data[leaf.name+".crt"], data[leaf.name+".key"] = certData, keyData
+		data[leaf.ca+".crt"] = caData
+		statuses = append(statuses, installv1alpha1.CertificateStatus{Name: leaf.name, NotAfter: metav1.NewTime(cert.NotAfter)})
+	}
+	if len(pending) > 0 {
+		return nil, nil, fmt.Errorf("waiting for the certificates %s to be issued", strings.Join(pending, ", "))
+	}
+	return data, statuses, nil
+}
+
+// ensureIssuedCertificate applies the cert-manager certificate with the given config and returns whether
+// its secret is up to date with the desired spec.
+func (ctrl *KarmadaController) ensureIssuedCertificate(karmada *installv1alpha1.Karmada, name string, config *certs.CertsConfig) (bool, error) {
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{"spec": issuedCertificateSpec(karmada, name, config)}}
+	certificate.SetAPIVersion(certificateGVR.GroupVersion().String())
+	certificate.SetKind("Certificate")
+	certificate.SetNamespace(karmada.Namespace)
+	certificate.SetName(name)
+	controllerutil.SetOwnerReference(karmada, certificate, scheme.Scheme)
+
+	client := ctrl.dynamicClient.Resource(certificateGVR).Namespace(karmada.Namespace)
+	if err := clientutil.ApplyUnstructured(client, certificate); err != nil {
+		return false, err
+	}
+	got, err := client.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to get the certificate %q: %v", name, err)
+	}
+	return issuedCertificateReady(got), nil
+}
+
+// issuedCertificateSpec returns the spec of the cert-manager certificate which is issued with the given config.
+func issuedCertificateSpec(karmada *installv1alpha1.Karmada, name string, config *certs.CertsConfig) map[string]interface{} {
+	issuer := karmada.Spec.Certificates.IssuerRef
+	kind, group := issuer.Kind, issuer.Group
+	if kind == "" {
+		kind = "Issuer"
+	}
+	if group == "" {
+		group = certificateGVR.Group
+	}
+
+	// The key of the karmada certificate also signs the service account tokens, so it must not be rotated.
+	rotationPolicy := "Always"
+	if name == issuedCertificateName(karmada, "karmada") {
+		rotationPolicy = "Never"
+	}
+
+	spec := map[string]interface{}{
+		"secretName":  name,
+		"commonName":  config.CommonName,
+		"duration":    certificateValidity(karmada).String(),
+		"renewBefore": certificateRenewBefore(karmada).String(),
+		"usages":      []interface{}{"digital signature", "key encipherment", "server auth", "client auth"},
+		"privateKey": map[string]interface{}{
+			"algorithm":      "RSA",
+			"size":           int64(2048),
+			"encoding":       "PKCS1",
+			"rotationPolicy": rotationPolicy,
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  issuer.Name,
+			"kind":  kind,
+			"group": group,
+		},
+	}
+
+	var organizations []interface{}
+	for _, org := range config.Organization {
+		if org != "" {
+			organizations = append(organizations, org)
+		}
+	}
+	if len(organizations) > 0 {
+		spec["subject"] = map[string]interface{}{"organizations": organizations}
+	}
+
+	altNames := config.AltNames
+	certs.RemoveDuplicateAltNames(&altNames)
+	var dnsNames, ipAddresses []interface{}
+	for _, dnsName := range altNames.DNSNames {
+		dnsNames = append(dnsNames, dnsName)
+	}
+	for _, ip := range altNames.IPs {
+		ipAddresses = append(ipAddresses, ip.String())
+	}
+	if len(dnsNames) > 0 {
+		spec["dnsNames"] = dnsNames
+	}
+	if len(ipAddresses) > 0 {
+		spec["ipAddresses"] = ipAddresses
+	}
+	return spec
+}
+
+// issuedCertificateReady returns whether the cert-manager certificate is ready for its current spec.
+func issuedCertificateReady(certificate *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		if observed, ok := condition["observedGeneration"].(int64); ok && observed < certificate.GetGeneration() {
+			return false
+		}
+		return condition["status"] == string(metav1.ConditionTrue)
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+)
+
+// certificateSecrets returns the secrets which hold the certificates used by the components of the karmada.
+func certificateSecrets(karmada *installv1alpha1.Karmada) []string {
+	return []string{
+		generateCertSecretName(karmada),
+		generateComponentCertSecretName(karmada, constants.KarmadaComponentEtcd),
+		generateComponentCertSecretName(karmada, constants.KarmadaComponentWebhook),
+		GenerateKubeConfigSecretName(karmada),
+	}
+}
+
+// certificatesHash returns the hash of the certificates used by the components of the karmada.
+func (ctrl *KarmadaController) certificatesHash(karmada *installv1alpha1.Karmada) (string, error) {
+	hasher := sha256.New()
+	for _, name := range certificateSecrets(karmada) {
+		data, err := ctrl.getSecretData(karmada.Namespace, name)
+		if err != nil {
+			return "", err
+		}
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(hasher, "%s/%s:", name, key)
+			hasher.Write(data[key])
+		}
+	}
+	return hex.EncodeToString(hasher.Sum(nil))[:16], nil
+}
+
+// certificateDeployments returns the deployments using the certificates in the order in which they are restarted.
+func certificateDeployments(karmada *installv1alpha1.Karmada) []string {
+	deployments := []string{
+		constants.KarmadaComponentKubeAPIServer,
+		constants.KarmadaComponentAggregratedAPIServer,
+		constants.KarmadaComponentWebhook,
+		constants.KarmadaComponentKubeControllerManager,
+		constants.KarmadaComponentControllerManager,
+		constants.KarmadaComponentScheduler,
+	}
+	if isKarmadaDeschedulerEnabled(karmada) {
+		deployments = append(deployments, constants.KarmadaComponentDescheduler)
+	}
+	deployments = append(deployments, constants.FireflyComponentKarmadaManager)
+	return karmadaComponentNames(karmada, deployments...)
+}
+
+// rolloutCertificates restarts the components of the karmada one by one in the order of their dependencies
+// after the certificates are changed. A component is only restarted when all the previous ones are ready.
+// It returns whether all the components are running with the current certificates.
+func (ctrl *KarmadaController) rolloutCertificates(karmada *installv1alpha1.Karmada) (bool, error) {
+	hash, err := ctrl.certificatesHash(karmada)
+	if err != nil {
+		return false, err
+	}
+
+	if karmada.Spec.Etcd.External == nil {
+		sts, err := ctrl.client.AppsV1().StatefulSets(karmada.Namespace).Get(context.TODO(), karmadaComponentName(karmada, constants.KarmadaComponentEtcd), metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		changed, done := ctrl.restartForCertificates(karmada, &sts.ObjectMeta, &sts.Spec.Template, hash, statefulSetReady(sts))
+		if changed {
+			if _, err := ctrl.client.AppsV1().StatefulSets(karmada.Namespace).Update(context.TODO(), sts, metav1.UpdateOptions{}); err != nil {
+				return false, err
+			}
+		}
+		if !done {
+			return false, nil
+		}
+	}
+
+	for _, name := range certificateDeployments(karmada) {
+		deployment, err := ctrl.client.AppsV1().Deployments(karmada.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		changed, done := ctrl.restartForCertificates(karmada, &deployment.ObjectMeta, &deployment.Spec.Template, hash, deploymentReady(deployment))
+		if changed {
+			if _, err := ctrl.client.AppsV1().Deployments(karmada.Namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{}); err != nil {
+				return false, err
+			}
+		}
+		if !done {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// restartForCertificates restarts the pods of the workload if they are not started with the current
+// certificates. It returns whether the workload is changed, and whether the workload is ready with
+// the current certificates.
+func (ctrl *KarmadaController) restartForCertificates(karmada *installv1alpha1.Karmada, meta *metav1.ObjectMeta, template *corev1.PodTemplateSpec, hash string, ready bool) (bool, bool) {
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	current, ok := meta.Annotations[constants.CertificatesHashAnnotation]
+	// The workload hasn't been tracked yet, its pods are considered to be started with the current certificates.
+	if !ok {
+		meta.Annotations[constants.CertificatesHashAnnotation] = hash
+		return true, ready
+	}
+	if current == hash {
+		return false, ready
+	}
+	// Wait for the workload to be stable before restarting it.
+	if !ready {
+		return false, false
+	}
+
+	klog.InfoS("Restarting component to use the renewed certificates", "karmada", klog.KObj(karmada), "component", meta.Name)
+	ctrl.eventRecorder.Eventf(karmada, corev1.EventTypeNormal, "RestartingComponent", "Restarting %s to use the renewed certificates", meta.Name)
+	meta.Annotations[constants.CertificatesHashAnnotation] = hash
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[constants.RestartedAtAnnotation] = time.Now().Format(time.RFC3339)
+	return true, false
+}
@@ -17,32 +17,38 @@ limitations under the License.
 package karmada
 
 import (
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/cli-runtime/pkg/resource"
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"k8s.io/klog/v2"
 
 	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
 	utilresource "github.com/carlory/firefly/pkg/util/resource"
 )
 
+// crds holds a bundle of the karmada crds for each supported karmada minor version. A bundle is copied
+// from the charts/karmada/_crds/bases directory of the karmada release, e.g. v1.3 from karmada v1.3.0.
+//
+//go:embed crds
+var crds embed.FS
+
+// EnsureKarmadaCRDs applies the crd bundle of the karmada version into the karmada-apiserver.
 func (ctrl *KarmadaController) EnsureKarmadaCRDs(karmada *installv1alpha1.Karmada) error {
+	bundles, err := fs.Sub(crds, "crds")
+	if err != nil {
+		return err
+	}
+	bundle, err := utilresource.SelectBundle(bundles, karmada.Spec.KarmadaVersion)
+	if err != nil {
+		return fmt.Errorf("failed to select the crds of karmada %s: %v", karmada.Spec.KarmadaVersion, err)
+	}
+
 	clientConfig, err := ctrl.GenerateClientConfig(karmada)
 	if err != nil {
 		return err
 	}
-	result := utilresource.NewBuilder(clientConfig).
-		Unstructured().
-		FilenameParam(false, &resource.FilenameOptions{Recursive: false, Filenames: []string{"./pkg/controller/karmada/crds"}}).
-		Flatten().Do()
-	return result.Visit(func(info *resource.Info, err error) error {
-		if err != nil {
-			return err
-		}
-		_, err1 := resource.NewHelper(info.Client, info.Mapping).Create(info.Namespace, true, info.Object)
-		if err1 != nil {
-			if !errors.IsAlreadyExists(err1) {
-				return err1
-			}
-		}
-		return nil
-	})
+	klog.V(4).InfoS("Applying karmada crds", "karmada", klog.KObj(karmada), "bundle", bundle)
+	return utilresource.ApplyBundle(utilresource.NewBuilder(clientConfig), bundles, bundle, constants.FieldManager)
 }
@@ -17,12 +17,25 @@ limitations under the License.
 package karmada
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	utilpointer "k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
@@ -30,20 +43,147 @@ import (
 	"github.com/carlory/firefly/pkg/scheme"
 	"github.com/carlory/firefly/pkg/util"
 	clientutil "github.com/carlory/firefly/pkg/util/client"
+	etcdutil "github.com/carlory/firefly/pkg/util/etcd"
+)
+
+const (
+	// The initial cluster configuration of the etcd members is read by etcd from these environment variables.
+	etcdInitialClusterEnv           = "ETCD_INITIAL_CLUSTER"
+	etcdInitialClusterStateEnv      = "ETCD_INITIAL_CLUSTER_STATE"
+	etcdInitialClusterStateNew      = "new"
+	etcdInitialClusterStateExisting = "existing"
+
+	// etcdDataVolumeName is the name of the volume where etcd places its data.
+	etcdDataVolumeName = "etcd-data"
+
+	// etcdRequestTimeout is the timeout of the requests to the etcd cluster.
+	etcdRequestTimeout = 30 * time.Second
+
+	// externalEtcdCACertKey is the key of the etcd CA certificate in the secret referenced by the external etcd.
+	externalEtcdCACertKey = "ca.crt"
+	// externalEtcdClientCertKey is the key of the etcd client certificate in the secret referenced by the external etcd.
+	externalEtcdClientCertKey = corev1.TLSCertKey
+	// externalEtcdClientKeyKey is the key of the etcd client key in the secret referenced by the external etcd.
+	externalEtcdClientKeyKey = corev1.TLSPrivateKeyKey
 )
 
 func (ctrl *KarmadaController) EnsureEtcd(karmada *installv1alpha1.Karmada) error {
+	// The certificates of an external etcd are written into the karmada-cert secret together with the
+	// other certificates.
+	if karmada.Spec.Etcd.External != nil {
+		return nil
+	}
+
 	if err := ctrl.EnsureEtcdService(karmada); err != nil {
 		return err
 	}
 	if err := ctrl.EnsureEtcdStatefulSet(karmada); err != nil {
 		return err
 	}
+	if err := ctrl.EnsureEtcdPodDisruptionBudget(karmada); err != nil {
+		return err
+	}
 	return nil
 }
 
+// EnsureEtcdPodDisruptionBudget ensures the PodDisruptionBudget of the etcd cluster, which keeps a node drain
+// from evicting more members at once than the cluster can lose without losing its quorum.
+func (ctrl *KarmadaController) EnsureEtcdPodDisruptionBudget(karmada *installv1alpha1.Karmada) error {
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	replicas := etcdReplicas(karmada)
+	var policy *installv1alpha1.PodDisruptionBudgetPolicy
+	if local := karmada.Spec.Etcd.Local; local != nil {
+		policy = local.PodDisruptionBudget
+	}
+	return ctrl.EnsurePodDisruptionBudget(karmada, etcdName, map[string]string{"app": etcdName}, &replicas, etcdPodDisruptionBudgetPolicy(policy, replicas))
+}
+
+// etcdPodDisruptionBudgetPolicy returns the policy of the PodDisruptionBudget of the etcd cluster. Unless it's
+// set by the user, a quorum of the members must be available, e.g. no member of a cluster with two members
+// can be evicted.
+func etcdPodDisruptionBudgetPolicy(policy *installv1alpha1.PodDisruptionBudgetPolicy, replicas int32) *installv1alpha1.PodDisruptionBudgetPolicy {
+	if policy != nil && (policy.MinAvailable != nil || policy.MaxUnavailable != nil) {
+		return policy
+	}
+	quorum := intstr.FromInt(int(replicas/2 + 1))
+	if policy == nil {
+		return &installv1alpha1.PodDisruptionBudgetPolicy{MinAvailable: &quorum}
+	}
+	policy = policy.DeepCopy()
+	policy.MinAvailable = &quorum
+	return policy
+}
+
+// externalEtcdCerts returns the CA and the client certificate of the external etcd which are supplied by the
+// user, keyed by their names in the karmada-cert secret. Nil is returned if the external etcd doesn't use a
+// TLS connection.
+func (ctrl *KarmadaController) externalEtcdCerts(karmada *installv1alpha1.Karmada) (map[string][]byte, error) {
+	external := karmada.Spec.Etcd.External
+	caData, certData, keyData := external.CAData, external.CertData, external.KeyData
+	if external.SecretRef != nil {
+		secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), external.SecretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the external etcd secret %q: %v", external.SecretRef.Name, err)
+		}
+		if data, ok := secret.Data[externalEtcdCACertKey]; ok {
+			caData = data
+		}
+		if data, ok := secret.Data[externalEtcdClientCertKey]; ok {
+			certData = data
+		}
+		if data, ok := secret.Data[externalEtcdClientKeyKey]; ok {
+			keyData = data
+		}
+	}
+
+	certs := map[string][]byte{}
+	for key, data := range map[string][]byte{"etcd-ca.crt": caData, "etcd-client.crt": certData, "etcd-client.key": keyData} {
+		if len(data) > 0 {
+			certs[key] = data
+		}
+	}
+	if len(certs) == 0 {
+		return nil, nil
+	}
+	return certs, nil
+}
+
+// etcdClientTLSArgs returns the flags of the karmada apiservers for the TLS connection to the etcd. The flags
+// of the certificates which aren't supplied for an external etcd, inline or in its secret, are left out, as
+// the apiservers fail to start with a missing file.
+func (ctrl *KarmadaController) etcdClientTLSArgs(karmada *installv1alpha1.Karmada) (map[string]string, error) {
+	files := map[string]string{
+		"etcd-cafile":   "etcd-ca.crt",
+		"etcd-certfile": "etcd-client.crt",
+		"etcd-keyfile":  "etcd-client.key",
+	}
+	var externalCerts map[string][]byte
+	if karmada.Spec.Etcd.External != nil {
+		var err error
+		if externalCerts, err = ctrl.externalEtcdCerts(karmada); err != nil {
+			return nil, err
+		}
+	}
+
+	args := map[string]string{}
+	for flag, file := range files {
+		if karmada.Spec.Etcd.External == nil || len(externalCerts[file]) > 0 {
+			args[flag] = filepath.Join("/etc/kubernetes/pki", file)
+		}
+	}
+	return args, nil
+}
+
+// etcdServers returns the etcd servers which the karmada apiservers connect to.
+func etcdServers(karmada *installv1alpha1.Karmada) string {
+	if external := karmada.Spec.Etcd.External; external != nil {
+		return strings.Join(external.Endpoints, ",")
+	}
+	return fmt.Sprintf("https://%s.%s.svc:2379", karmadaComponentName(karmada, constants.KarmadaComponentEtcd), karmada.Namespace)
+}
+
 func (ctrl *KarmadaController) EnsureEtcdService(karmada *installv1alpha1.Karmada) error {
-	etcdName := constants.KarmadaComponentEtcd
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
 	svc := &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
@@ -52,11 +192,14 @@ func (ctrl *KarmadaController) EnsureEtcdService(karmada *installv1alpha1.Karmad
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      etcdName,
 			Namespace: karmada.Namespace,
+			Labels:    karmadaComponentLabels(karmada, constants.KarmadaComponentEtcd),
 		},
 		Spec: corev1.ServiceSpec{
 			Selector:  map[string]string{"app": etcdName},
 			ClusterIP: "None",
 			Type:      corev1.ServiceTypeClusterIP,
+			// The members must be able to resolve each other before they are ready.
+			PublishNotReadyAddresses: true,
 			Ports: []corev1.ServicePort{
 				{
 					Name:     "client",
@@ -80,11 +223,186 @@ func (ctrl *KarmadaController) EnsureEtcdService(karmada *installv1alpha1.Karmad
 		},
 	}
 	controllerutil.SetOwnerReference(karmada, svc, scheme.Scheme)
-	return clientutil.CreateOrUpdateService(ctrl.client, svc)
+	return clientutil.ApplyService(ctrl.client, svc)
 }
 
+// EnsureEtcdStatefulSet ensures the etcd statefulset exists and its members converge to the desired replicas.
+// Members are added or removed one at a time, and only when all the current members are healthy.
 func (ctrl *KarmadaController) EnsureEtcdStatefulSet(karmada *installv1alpha1.Karmada) error {
-	etcdName := constants.KarmadaComponentEtcd
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	desired := etcdReplicas(karmada)
+
+	got, err := ctrl.client.AppsV1().StatefulSets(karmada.Namespace).Get(context.TODO(), etcdName, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	// A new cluster is bootstrapped with all the desired members at once.
+	if errors.IsNotFound(err) {
+		if err := ensureEtcdInitialCluster(ctrl.client, karmada, etcdInitialCluster(karmada, desired), etcdInitialClusterStateNew); err != nil {
+			return err
+		}
+		sts := etcdStatefulSet(karmada, desired)
+		setEtcdDataVolume(sts)
+		return clientutil.ApplyStatefulSet(ctrl.client, sts)
+	}
+
+	replicas := int32(1)
+	if got.Spec.Replicas != nil {
+		replicas = *got.Spec.Replicas
+	}
+
+	// The etcd members of older versions are started with the initial cluster configuration in their flags.
+	_, err = ctrl.client.CoreV1().ConfigMaps(karmada.Namespace).Get(context.TODO(), etcdInitialClusterConfigMapName(karmada), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		err = ensureEtcdInitialCluster(ctrl.client, karmada, etcdInitialCluster(karmada, replicas), etcdInitialClusterStateExisting)
+	}
+	if err != nil {
+		return err
+	}
+
+	if replicas != desired && ctrl.plan != nil {
+		// The members are changed in the etcd cluster itself, which can't be planned, so only the
+		// change of the statefulset is planned.
+		replicas = desired
+	} else if replicas != desired {
+		replicas, err = ctrl.scaleEtcdMembers(karmada, replicas, desired, statefulSetReady(got))
+		if err != nil {
+			return err
+		}
+	}
+
+	sts := etcdStatefulSet(karmada, replicas)
+	if len(sts.Spec.VolumeClaimTemplates) != len(got.Spec.VolumeClaimTemplates) {
+		ctrl.eventRecorder.Event(karmada, corev1.EventTypeWarning, "DataVolumeImmutable", "The data volume of the etcd can't be changed after the etcd cluster is created")
+	}
+	// The pod management policy and the volume claim templates are immutable.
+	sts.Spec.PodManagementPolicy = got.Spec.PodManagementPolicy
+	sts.Spec.VolumeClaimTemplates = got.Spec.VolumeClaimTemplates
+	setEtcdDataVolume(sts)
+	return clientutil.ApplyStatefulSet(ctrl.client, sts)
+}
+
+// scaleEtcdMembers adds or removes one member of the etcd cluster towards the desired replicas, and
+// returns the replicas which the etcd statefulset should be scaled to. The members are only changed when
+// the statefulset is ready. The initial cluster configuration which an added member joins with is taken
+// from the members of the cluster after it's added.
+func (ctrl *KarmadaController) scaleEtcdMembers(karmada *installv1alpha1.Karmada, replicas, desired int32, ready bool) (int32, error) {
+	var endpoints []string
+	for ordinal := int32(0); ordinal < replicas; ordinal++ {
+		endpoints = append(endpoints, etcdClientURL(karmada, ordinal))
+	}
+	client, err := ctrl.newEtcdClient(karmada, endpoints)
+	if err != nil {
+		return replicas, err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), etcdRequestTimeout)
+	defer cancel()
+
+	resp, err := client.MemberList(ctx)
+	if err != nil {
+		return replicas, fmt.Errorf("failed to list etcd members: %v", err)
+	}
+
+	// The pod of a removed member can't become ready, so the scale-down is finished once the member is gone
+	// even if the statefulset isn't ready, e.g. when it failed to be scaled down after the member was removed.
+	if desired < replicas && etcdMemberByPeerURL(resp.Members, etcdPeerURL(karmada, replicas-1)) == nil {
+		return replicas - 1, ctrl.deleteEtcdMemberData(karmada, replicas-1)
+	}
+	if !ready {
+		klog.InfoS("Waiting for etcd members to be ready before scaling", "karmada", klog.KObj(karmada), "replicas", replicas, "desired", desired)
+		return replicas, nil
+	}
+
+	// Members created by older versions use plain http for peer communication.
+	migrated := false
+	for _, member := range resp.Members {
+		ordinal, ok := etcdMemberOrdinal(karmada, member.PeerURLs)
+		if !ok {
+			continue
+		}
+		if peerURL := etcdPeerURL(karmada, ordinal); member.PeerURLs[0] != peerURL {
+			klog.InfoS("Updating etcd member peer url", "karmada", klog.KObj(karmada), "member", member.Name, "peerURL", peerURL)
+			if _, err := client.MemberUpdate(ctx, member.ID, []string{peerURL}); err != nil {
+				return replicas, fmt.Errorf("failed to update etcd member %q: %v", member.Name, err)
+			}
+			migrated = true
+		}
+	}
+	if migrated {
+		return replicas, nil
+	}
+
+	if desired > replicas {
+		peerURL := etcdPeerURL(karmada, replicas)
+		for _, member := range resp.Members {
+			// The member was added, but the statefulset was not scaled.
+			if len(member.PeerURLs) > 0 && member.PeerURLs[0] == peerURL {
+				return replicas + 1, ensureEtcdInitialCluster(ctrl.client, karmada, etcdMembersInitialCluster(karmada, resp.Members), etcdInitialClusterStateExisting)
+			}
+			// A member which has been added but not started yet makes the cluster unable to tolerate another one.
+			if member.Name == "" {
+				klog.InfoS("Waiting for etcd member to be started before scaling", "karmada", klog.KObj(karmada), "peerURLs", member.PeerURLs)
+				return replicas, nil
+			}
+		}
+
+		klog.InfoS("Adding etcd member", "karmada", klog.KObj(karmada), "peerURL", peerURL)
+		added, err := client.MemberAdd(ctx, []string{peerURL})
+		if err != nil {
+			return replicas, fmt.Errorf("failed to add etcd member: %v", err)
+		}
+		if err := ensureEtcdInitialCluster(ctrl.client, karmada, etcdMembersInitialCluster(karmada, added.Members), etcdInitialClusterStateExisting); err != nil {
+			return replicas, err
+		}
+		return replicas + 1, nil
+	}
+
+	member := etcdMemberByPeerURL(resp.Members, etcdPeerURL(karmada, replicas-1))
+	klog.InfoS("Removing etcd member", "karmada", klog.KObj(karmada), "member", member.Name)
+	if _, err := client.MemberRemove(ctx, member.ID); err != nil {
+		return replicas, fmt.Errorf("failed to remove etcd member %q: %v", member.Name, err)
+	}
+	if err := ctrl.deleteEtcdMemberData(karmada, replicas-1); err != nil {
+		return replicas, err
+	}
+	return replicas - 1, nil
+}
+
+// etcdMemberByPeerURL returns the member of the etcd cluster with the given peer url, or nil if it isn't a member.
+func etcdMemberByPeerURL(members []*etcdserverpb.Member, peerURL string) *etcdserverpb.Member {
+	for _, member := range members {
+		if len(member.PeerURLs) > 0 && member.PeerURLs[0] == peerURL {
+			return member
+		}
+	}
+	return nil
+}
+
+// deleteEtcdMemberData deletes the data volume of the removed member with the given ordinal, as its data
+// can't be reused by a member added later.
+func (ctrl *KarmadaController) deleteEtcdMemberData(karmada *installv1alpha1.Karmada, ordinal int32) error {
+	claimName := fmt.Sprintf("%s-%s-%d", etcdDataVolumeName, karmadaComponentName(karmada, constants.KarmadaComponentEtcd), ordinal)
+	err := ctrl.client.CoreV1().PersistentVolumeClaims(karmada.Namespace).Delete(context.TODO(), claimName, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// newEtcdClient creates an etcd client which connects to the built-in etcd cluster of the karmada.
+func (ctrl *KarmadaController) newEtcdClient(karmada *installv1alpha1.Karmada, endpoints []string) (*clientv3.Client, error) {
+	secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), generateCertSecretName(karmada), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return etcdutil.NewClient(endpoints, secret.Data["etcd-ca.crt"], secret.Data["etcd-client.crt"], secret.Data["etcd-client.key"])
+}
+
+// etcdImage returns the image of the built-in etcd, its pull policy and its pull secrets.
+func etcdImage(karmada *installv1alpha1.Karmada) (string, corev1.PullPolicy, []corev1.LocalObjectReference) {
 	etcd := karmada.Spec.Etcd.Local
 	repository := karmada.Spec.ImageRepository
 	if karmada.Spec.KubeImageRepository != "" {
@@ -93,7 +411,11 @@ func (ctrl *KarmadaController) EnsureEtcdStatefulSet(karmada *installv1alpha1.Ka
 
 	tag := "3.4.13-0"
 	imageName := "etcd"
+	var pullPolicy corev1.PullPolicy
+	var pullSecrets []corev1.LocalObjectReference
 	if etcd != nil {
+		pullPolicy = etcd.ImagePullPolicy
+		pullSecrets = etcd.ImagePullSecrets
 		if etcd.ImageRepository != "" {
 			repository = etcd.ImageRepository
 		}
@@ -104,6 +426,16 @@ func (ctrl *KarmadaController) EnsureEtcdStatefulSet(karmada *installv1alpha1.Ka
 			tag = etcd.ImageTag
 		}
 	}
+	image := util.ComponentImage(karmada.Spec.ImageOverrides, constants.KarmadaComponentEtcd, repository, imageName, tag)
+	return image, util.ImagePullPolicy(pullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent), pullSecrets
+}
+
+// etcdStatefulSet returns the etcd statefulset with the given replicas. The pod template doesn't depend on the
+// replicas, so the members aren't restarted when the etcd cluster is scaled.
+func etcdStatefulSet(karmada *installv1alpha1.Karmada, replicas int32) *appsv1.StatefulSet {
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	etcd := karmada.Spec.Etcd.Local
+	image, pullPolicy, pullSecrets := etcdImage(karmada)
 
 	sts := &appsv1.StatefulSet{
 		TypeMeta: metav1.TypeMeta{
@@ -116,10 +448,13 @@ func (ctrl *KarmadaController) EnsureEtcdStatefulSet(karmada *installv1alpha1.Ka
 			Labels:    map[string]string{"app": etcdName},
 		},
 		Spec: appsv1.StatefulSetSpec{
+			Replicas: utilpointer.Int32(replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{"app": etcdName},
 			},
 			ServiceName: etcdName,
+			// All the members must be started together to bootstrap a new cluster.
+			PodManagementPolicy: appsv1.ParallelPodManagement,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"app": etcdName},
@@ -128,33 +463,53 @@ func (ctrl *KarmadaController) EnsureEtcdStatefulSet(karmada *installv1alpha1.Ka
 					Containers: []corev1.Container{
 						{
 							Name:            "etcd",
-							Image:           util.ComponentImageName(repository, imageName, tag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           image,
+							ImagePullPolicy: pullPolicy,
 							Command: []string{
 								"/usr/local/bin/etcd",
-								"--name",
-								"etcd0",
-								"--listen-peer-urls",
-								"http://0.0.0.0:2380",
-								"--listen-client-urls",
-								"https://0.0.0.0:2379",
-								"--advertise-client-urls",
-								fmt.Sprintf("https://%s.%s.svc:2379", etcdName, karmada.Namespace),
-								"--initial-cluster",
-								fmt.Sprintf("etcd0=http://%s-0.%s.%s.svc:2380", etcdName, etcdName, karmada.Namespace),
-								"--initial-cluster-state",
-								"new",
+								"--name=$(POD_NAME)",
+								"--listen-peer-urls=https://0.0.0.0:2380",
+								"--listen-client-urls=https://0.0.0.0:2379",
+								fmt.Sprintf("--advertise-client-urls=https://$(POD_NAME).%s.%s.svc:2379", etcdName, karmada.Namespace),
+								fmt.Sprintf("--initial-advertise-peer-urls=https://$(POD_NAME).%s.%s.svc:2380", etcdName, karmada.Namespace),
 								"--cert-file=/etc/etcd/pki/etcd-server.crt",
 								"--client-cert-auth=true",
 								"--key-file=/etc/etcd/pki/etcd-server.key",
 								"--trusted-ca-file=/etc/etcd/pki/etcd-ca.crt",
+								"--peer-cert-file=/etc/etcd/pki/etcd-peer.crt",
+								"--peer-client-cert-auth=true",
+								"--peer-key-file=/etc/etcd/pki/etcd-peer.key",
+								"--peer-trusted-ca-file=/etc/etcd/pki/etcd-ca.crt",
 								"--data-dir=/var/lib/etcd",
 							},
+							Env: []corev1.EnvVar{
+								{
+									Name: "POD_NAME",
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{
+											FieldPath: "metadata.name",
+										},
+									},
+								},
+							},
+							// The initial cluster configuration is only used by the members which are started
+							// without data, the others ignore it.
+							EnvFrom: []corev1.EnvFromSource{
+								{
+									ConfigMapRef: &corev1.ConfigMapEnvSource{
+										LocalObjectReference: corev1.LocalObjectReference{Name: etcdInitialClusterConfigMapName(karmada)},
+									},
+								},
+							},
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									Name:      "etcd-certs",
 									MountPath: "/etc/etcd/pki",
 								},
+								{
+									Name:      etcdDataVolumeName,
+									MountPath: "/var/lib/etcd",
+								},
 							},
 						},
 					},
@@ -163,7 +518,7 @@ func (ctrl *KarmadaController) EnsureEtcdStatefulSet(karmada *installv1alpha1.Ka
 							Name: "etcd-certs",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: fmt.Sprintf("%s-cert", constants.KarmadaComponentEtcd),
+									SecretName: generateComponentCertSecretName(karmada, constants.KarmadaComponentEtcd),
 								},
 							},
 						},
@@ -172,6 +527,167 @@ func (ctrl *KarmadaController) EnsureEtcdStatefulSet(karmada *installv1alpha1.Ka
 			},
 		},
 	}
+	if etcd != nil && etcd.DataVolume != nil {
+		labels := map[string]string{"app": etcdName}
+		for k, v := range etcd.DataVolume.Labels {
+			labels[k] = v
+		}
+		sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        etcdDataVolumeName,
+					Labels:      labels,
+					Annotations: etcd.DataVolume.Annotations,
+				},
+				Spec: etcd.DataVolume.Spec,
+			},
+		}
+	}
+	var placement *installv1alpha1.Placement
+	if etcd != nil {
+		placement = etcd.Placement
+	}
+	util.SetPodImagePullSecrets(&sts.Spec.Template.Spec, pullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&sts.Spec.Template.Spec, placement, karmada.Spec.Placement)
+	// The desired replicas are used, so that the pod template isn't changed while the members are added.
+	util.SetDefaultPodAntiAffinity(&sts.Spec.Template.Spec, sts.Spec.Selector.MatchLabels, utilpointer.Int32(etcdReplicas(karmada)))
 	controllerutil.SetOwnerReference(karmada, sts, scheme.Scheme)
-	return clientutil.CreateOrUpdateStatefulSet(ctrl.client, sts)
+	return sts
+}
+
+// etcdInitialClusterConfigMapName returns the name of the configmap which holds the initial cluster
+// configuration of the etcd members.
+func etcdInitialClusterConfigMapName(karmada *installv1alpha1.Karmada) string {
+	return karmadaComponentName(karmada, constants.KarmadaComponentEtcd+"-initial-cluster")
+}
+
+// ensureEtcdInitialCluster writes the initial cluster configuration which the next started etcd members are
+// started with. It's passed to etcd in its environment, so the running members aren't restarted when it's
+// changed.
+func ensureEtcdInitialCluster(client clientset.Interface, karmada *installv1alpha1.Karmada, initialCluster, state string) error {
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdInitialClusterConfigMapName(karmada),
+			Namespace: karmada.Namespace,
+		},
+		Data: map[string]string{
+			etcdInitialClusterEnv:      initialCluster,
+			etcdInitialClusterStateEnv: state,
+		},
+	}
+	controllerutil.SetOwnerReference(karmada, cm, scheme.Scheme)
+	return clientutil.ApplyConfigMap(client, cm)
+}
+
+// setEtcdDataVolume makes etcd use an emptyDir for its data if the statefulset has no volume claim templates.
+func setEtcdDataVolume(sts *appsv1.StatefulSet) {
+	if len(sts.Spec.VolumeClaimTemplates) > 0 {
+		return
+	}
+	sts.Spec.Template.Spec.Volumes = append(sts.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: etcdDataVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+}
+
+// RemoveEtcdDataVolumes deletes the persistent volume claims of the etcd members if the
+// deletion policy of the data volume is Delete.
+func (ctrl *KarmadaController) RemoveEtcdDataVolumes(karmada *installv1alpha1.Karmada) error {
+	etcd := karmada.Spec.Etcd.Local
+	if etcd == nil || etcd.DataVolume == nil || etcd.DataVolumeDeletionPolicy != installv1alpha1.DataVolumeDeletionPolicyDelete {
+		return nil
+	}
+	selector := labels.SelectorFromSet(labels.Set{"app": karmadaComponentName(karmada, constants.KarmadaComponentEtcd)}).String()
+	return ctrl.client.CoreV1().PersistentVolumeClaims(karmada.Namespace).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: selector})
+}
+
+// checkEtcdMembers reports the etcd cluster as not ready until it's scaled to the desired replicas.
+func (ctrl *KarmadaController) checkEtcdMembers(karmada *installv1alpha1.Karmada) ([]string, error) {
+	if karmada.Spec.Etcd.External != nil {
+		return nil, nil
+	}
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	sts, err := ctrl.client.AppsV1().StatefulSets(karmada.Namespace).Get(context.TODO(), etcdName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return []string{etcdName}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sts.Spec.Replicas == nil || *sts.Spec.Replicas != etcdReplicas(karmada) {
+		return []string{etcdName}, nil
+	}
+	return nil, nil
+}
+
+// etcdReplicas returns the desired number of members of the built-in etcd cluster.
+func etcdReplicas(karmada *installv1alpha1.Karmada) int32 {
+	if local := karmada.Spec.Etcd.Local; local != nil && local.Replicas != nil {
+		return *local.Replicas
+	}
+	return 1
+}
+
+// etcdInitialCluster returns the initial cluster configuration of the etcd with the given replicas.
+func etcdInitialCluster(karmada *installv1alpha1.Karmada, replicas int32) string {
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	var initialCluster []string
+	for ordinal := int32(0); ordinal < replicas; ordinal++ {
+		initialCluster = append(initialCluster, fmt.Sprintf("%s-%d=%s", etcdName, ordinal, etcdPeerURL(karmada, ordinal)))
+	}
+	return strings.Join(initialCluster, ",")
+}
+
+// etcdMembersInitialCluster returns the initial cluster configuration of the etcd with the given members,
+// which are the members listed after a member is added. A member which hasn't started yet has no name, so
+// the members are named after their peer urls.
+func etcdMembersInitialCluster(karmada *installv1alpha1.Karmada, members []*etcdserverpb.Member) string {
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	var initialCluster []string
+	for _, member := range members {
+		ordinal, ok := etcdMemberOrdinal(karmada, member.PeerURLs)
+		if !ok {
+			continue
+		}
+		for _, peerURL := range member.PeerURLs {
+			initialCluster = append(initialCluster, fmt.Sprintf("%s-%d=%s", etcdName, ordinal, peerURL))
+		}
+	}
+	sort.Strings(initialCluster)
+	return strings.Join(initialCluster, ",")
+}
+
+// etcdPeerURL returns the peer url of the etcd member with the given ordinal.
+func etcdPeerURL(karmada *installv1alpha1.Karmada, ordinal int32) string {
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	return fmt.Sprintf("https://%s-%d.%s.%s.svc:2380", etcdName, ordinal, etcdName, karmada.Namespace)
+}
+
+// etcdClientURL returns the client url of the etcd member with the given ordinal.
+func etcdClientURL(karmada *installv1alpha1.Karmada, ordinal int32) string {
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	return fmt.Sprintf("https://%s-%d.%s.%s.svc:2379", etcdName, ordinal, etcdName, karmada.Namespace)
+}
+
+// etcdMemberOrdinal returns the ordinal of the etcd member from its peer urls.
+func etcdMemberOrdinal(karmada *installv1alpha1.Karmada, peerURLs []string) (int32, bool) {
+	if len(peerURLs) == 0 {
+		return 0, false
+	}
+	u, err := url.Parse(peerURLs[0])
+	if err != nil {
+		return 0, false
+	}
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	var ordinal int32
+	if _, err := fmt.Sscanf(u.Hostname(), etcdName+"-%d."+etcdName+"."+karmada.Namespace+".svc", &ordinal); err != nil {
+		return 0, false
+	}
+	return ordinal, true
 }
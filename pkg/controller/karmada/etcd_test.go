@@ -0,0 +1,266 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"reflect"
+	"testing"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilpointer "k8s.io/utils/pointer"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+)
+
+func testKarmada() *installv1alpha1.Karmada {
+	return &installv1alpha1.Karmada{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "firefly-system"},
+	}
+}
+
+func TestEtcdInitialCluster(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas int32
+		want     string
+	}{
+		{
+			name:     "single member",
+			replicas: 1,
+			want:     "demo-etcd-0=https://demo-etcd-0.demo-etcd.firefly-system.svc:2380",
+		},
+		{
+			name:     "three members",
+			replicas: 3,
+			want: "demo-etcd-0=https://demo-etcd-0.demo-etcd.firefly-system.svc:2380," +
+				"demo-etcd-1=https://demo-etcd-1.demo-etcd.firefly-system.svc:2380," +
+				"demo-etcd-2=https://demo-etcd-2.demo-etcd.firefly-system.svc:2380",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := etcdInitialCluster(testKarmada(), tt.replicas); got != tt.want {
+				t.Errorf("etcdInitialCluster() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEtcdMembersInitialCluster(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []*etcdserverpb.Member
+		want    string
+	}{
+		{
+			name: "added member isn't started yet",
+			members: []*etcdserverpb.Member{
+				{Name: "demo-etcd-1", PeerURLs: []string{"https://demo-etcd-1.demo-etcd.firefly-system.svc:2380"}},
+				{Name: "demo-etcd-0", PeerURLs: []string{"https://demo-etcd-0.demo-etcd.firefly-system.svc:2380"}},
+				{PeerURLs: []string{"https://demo-etcd-2.demo-etcd.firefly-system.svc:2380"}},
+			},
+			want: "demo-etcd-0=https://demo-etcd-0.demo-etcd.firefly-system.svc:2380," +
+				"demo-etcd-1=https://demo-etcd-1.demo-etcd.firefly-system.svc:2380," +
+				"demo-etcd-2=https://demo-etcd-2.demo-etcd.firefly-system.svc:2380",
+		},
+		{
+			name: "unknown members are skipped",
+			members: []*etcdserverpb.Member{
+				{Name: "demo-etcd-0", PeerURLs: []string{"https://demo-etcd-0.demo-etcd.firefly-system.svc:2380"}},
+				{Name: "other", PeerURLs: []string{"https://other:2380"}},
+			},
+			want: "demo-etcd-0=https://demo-etcd-0.demo-etcd.firefly-system.svc:2380",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := etcdMembersInitialCluster(testKarmada(), tt.members); got != tt.want {
+				t.Errorf("etcdMembersInitialCluster() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEtcdMemberByPeerURL(t *testing.T) {
+	members := []*etcdserverpb.Member{
+		{ID: 1, Name: "demo-etcd-0", PeerURLs: []string{"https://demo-etcd-0.demo-etcd.firefly-system.svc:2380"}},
+		{ID: 2, Name: "demo-etcd-1", PeerURLs: []string{"https://demo-etcd-1.demo-etcd.firefly-system.svc:2380"}},
+		{ID: 3},
+	}
+	tests := []struct {
+		name    string
+		ordinal int32
+		wantID  uint64
+	}{
+		{
+			name:    "member",
+			ordinal: 1,
+			wantID:  2,
+		},
+		{
+			name:    "removed member",
+			ordinal: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := etcdMemberByPeerURL(members, etcdPeerURL(testKarmada(), tt.ordinal))
+			if tt.wantID == 0 {
+				if got != nil {
+					t.Errorf("etcdMemberByPeerURL() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.ID != tt.wantID {
+				t.Errorf("etcdMemberByPeerURL() = %v, want the member %d", got, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestEtcdClientTLSArgs(t *testing.T) {
+	secrets := map[string]*corev1.Secret{
+		"etcd-mtls": {Data: map[string][]byte{"ca.crt": []byte("ca"), "tls.crt": []byte("cert"), "tls.key": []byte("key")}},
+		"etcd-tls":  {Data: map[string][]byte{"ca.crt": []byte("ca")}},
+	}
+	all := map[string]string{
+		"etcd-cafile":   "/etc/kubernetes/pki/etcd-ca.crt",
+		"etcd-certfile": "/etc/kubernetes/pki/etcd-client.crt",
+		"etcd-keyfile":  "/etc/kubernetes/pki/etcd-client.key",
+	}
+	caOnly := map[string]string{"etcd-cafile": "/etc/kubernetes/pki/etcd-ca.crt"}
+	tests := []struct {
+		name     string
+		external *installv1alpha1.ExternalEtcd
+		want     map[string]string
+		wantErr  bool
+	}{
+		{
+			name: "local etcd",
+			want: all,
+		},
+		{
+			name:     "external etcd without TLS",
+			external: &installv1alpha1.ExternalEtcd{},
+			want:     map[string]string{},
+		},
+		{
+			name:     "inline CA only",
+			external: &installv1alpha1.ExternalEtcd{CAData: []byte("ca")},
+			want:     caOnly,
+		},
+		{
+			name:     "secret with client certificate",
+			external: &installv1alpha1.ExternalEtcd{SecretRef: &corev1.LocalObjectReference{Name: "etcd-mtls"}},
+			want:     all,
+		},
+		{
+			name:     "secret with CA only",
+			external: &installv1alpha1.ExternalEtcd{SecretRef: &corev1.LocalObjectReference{Name: "etcd-tls"}},
+			want:     caOnly,
+		},
+		{
+			name:     "secret merged with inline data",
+			external: &installv1alpha1.ExternalEtcd{CAData: []byte("ca"), CertData: []byte("cert"), KeyData: []byte("key"), SecretRef: &corev1.LocalObjectReference{Name: "etcd-tls"}},
+			want:     all,
+		},
+		{
+			name:     "missing secret",
+			external: &installv1alpha1.ExternalEtcd{SecretRef: &corev1.LocalObjectReference{Name: "missing"}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &KarmadaController{client: &fakeSecretsClientset{secrets: secrets}}
+			karmada := testKarmada()
+			karmada.Spec.Etcd.External = tt.external
+
+			got, err := ctrl.etcdClientTLSArgs(karmada)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("etcdClientTLSArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("etcdClientTLSArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEtcdStatefulSetTemplateIgnoresReplicas(t *testing.T) {
+	karmada := testKarmada()
+	karmada.Spec.Etcd.Local = &installv1alpha1.LocalEtcd{Replicas: utilpointer.Int32(3)}
+
+	one := etcdStatefulSet(karmada, 1)
+	three := etcdStatefulSet(karmada, 3)
+	if !reflect.DeepEqual(one.Spec.Template, three.Spec.Template) {
+		t.Errorf("etcdStatefulSet() pod template depends on the replicas")
+	}
+}
+
+func TestEtcdPodDisruptionBudgetPolicy(t *testing.T) {
+	one := intstr.FromInt(1)
+	tests := []struct {
+		name     string
+		policy   *installv1alpha1.PodDisruptionBudgetPolicy
+		replicas int32
+		want     *installv1alpha1.PodDisruptionBudgetPolicy
+	}{
+		{
+			name:     "two members",
+			replicas: 2,
+			want:     &installv1alpha1.PodDisruptionBudgetPolicy{MinAvailable: intstrPtr(2)},
+		},
+		{
+			name:     "three members",
+			replicas: 3,
+			want:     &installv1alpha1.PodDisruptionBudgetPolicy{MinAvailable: intstrPtr(2)},
+		},
+		{
+			name:     "five members",
+			replicas: 5,
+			want:     &installv1alpha1.PodDisruptionBudgetPolicy{MinAvailable: intstrPtr(3)},
+		},
+		{
+			name:     "enable is kept",
+			policy:   &installv1alpha1.PodDisruptionBudgetPolicy{Enable: utilpointer.Bool(true)},
+			replicas: 3,
+			want:     &installv1alpha1.PodDisruptionBudgetPolicy{Enable: utilpointer.Bool(true), MinAvailable: intstrPtr(2)},
+		},
+		{
+			name:     "set by the user",
+			policy:   &installv1alpha1.PodDisruptionBudgetPolicy{MaxUnavailable: &one},
+			replicas: 3,
+			want:     &installv1alpha1.PodDisruptionBudgetPolicy{MaxUnavailable: &one},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := etcdPodDisruptionBudgetPolicy(tt.policy, tt.replicas); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("etcdPodDisruptionBudgetPolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func intstrPtr(val int) *intstr.IntOrString {
+	v := intstr.FromInt(val)
+	return &v
+}
@@ -17,22 +17,21 @@ limitations under the License.
 package karmada
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/cli-runtime/pkg/resource"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
 	"github.com/carlory/firefly/pkg/constants"
+	karmadamanagercrds "github.com/carlory/firefly/pkg/karmada/crds"
 	"github.com/carlory/firefly/pkg/scheme"
 	"github.com/carlory/firefly/pkg/util"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
 	maputil "github.com/carlory/firefly/pkg/util/map"
 	utilresource "github.com/carlory/firefly/pkg/util/resource"
 )
@@ -56,27 +55,23 @@ func (ctrl *KarmadaController) EnsureFireflyKarmadaManager(karmada *installv1alp
 func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerServiceAccount(karmada *installv1alpha1.Karmada) error {
 	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      constants.FireflyComponentKarmadaManager,
+			Name:      karmadaComponentName(karmada, constants.FireflyComponentKarmadaManager),
 			Namespace: karmada.Namespace,
 		},
 	}
 	controllerutil.SetOwnerReference(karmada, sa, scheme.Scheme)
-	_, err := ctrl.client.CoreV1().ServiceAccounts(karmada.Namespace).Create(context.TODO(), sa, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return err
-	}
-	return nil
+	return clientutil.ApplyServiceAccount(ctrl.client, sa)
 }
 
 func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerClusterRoleBinding(karmada *installv1alpha1.Karmada) error {
 	crb := &rbacv1.ClusterRoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: fmt.Sprintf("%s-%s", constants.FireflyComponentKarmadaManager, karmada.Namespace),
+			Name: generateKarmadaManagerClusterRoleBindingName(karmada),
 		},
 		Subjects: []rbacv1.Subject{
 			{
 				Kind:      "ServiceAccount",
-				Name:      constants.FireflyComponentKarmadaManager,
+				Name:      karmadaComponentName(karmada, constants.FireflyComponentKarmadaManager),
 				Namespace: karmada.Namespace,
 			},
 		},
@@ -87,23 +82,25 @@ func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerClusterRoleBinding(kar
 		},
 	}
 	controllerutil.SetOwnerReference(karmada, crb, scheme.Scheme)
-	_, err := ctrl.client.RbacV1().ClusterRoleBindings().Create(context.TODO(), crb, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return err
-	}
-	return nil
+	return clientutil.ApplyClusterRoleBinding(ctrl.client, crb)
+}
+
+// generateKarmadaManagerClusterRoleBindingName returns the name of the cluster role binding of the
+// firefly-karmada-manager, which is cluster scoped and so also contains the namespace of the karmada.
+func generateKarmadaManagerClusterRoleBindingName(karmada *installv1alpha1.Karmada) string {
+	return fmt.Sprintf("%s-%s", karmadaComponentName(karmada, constants.FireflyComponentKarmadaManager), karmada.Namespace)
 }
 
 func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerRoleBinding(karmada *installv1alpha1.Karmada) error {
 	rb := &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      constants.FireflyComponentKarmadaManager,
+			Name:      karmadaComponentName(karmada, constants.FireflyComponentKarmadaManager),
 			Namespace: karmada.Namespace,
 		},
 		Subjects: []rbacv1.Subject{
 			{
 				Kind:      "ServiceAccount",
-				Name:      constants.FireflyComponentKarmadaManager,
+				Name:      karmadaComponentName(karmada, constants.FireflyComponentKarmadaManager),
 				Namespace: karmada.Namespace,
 			},
 		},
@@ -114,15 +111,11 @@ func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerRoleBinding(karmada *i
 		},
 	}
 	controllerutil.SetOwnerReference(karmada, rb, scheme.Scheme)
-	_, err := ctrl.client.RbacV1().RoleBindings(karmada.Namespace).Create(context.TODO(), rb, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return err
-	}
-	return nil
+	return clientutil.ApplyRoleBinding(ctrl.client, rb)
 }
 
 func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerDeployment(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.FireflyComponentKarmadaManager
+	componentName := karmadaComponentName(karmada, constants.FireflyComponentKarmadaManager)
 	repository := karmada.Spec.ImageRepository
 
 	fkm := karmada.Spec.ControllerManager.FireflyKarmadaManager
@@ -178,7 +171,7 @@ func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerDeployment(karmada *in
 							Name: "karmada-kubeconfig",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "karmada-kubeconfig",
+									SecretName: GenerateKubeConfigSecretName(karmada),
 								},
 							},
 						},
@@ -186,8 +179,8 @@ func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerDeployment(karmada *in
 					Containers: []corev1.Container{
 						{
 							Name:            componentName,
-							Image:           util.ComponentImageName(repository, imageName, tag),
-							ImagePullPolicy: corev1.PullAlways,
+							Image:           util.ComponentImage(karmada.Spec.ImageOverrides, constants.FireflyComponentKarmadaManager, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(fkm.ImagePullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullAlways),
 							Command:         []string{"firefly-karmada-manager"},
 							Args:            args,
 							VolumeMounts: []corev1.VolumeMount{
@@ -203,12 +196,14 @@ func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerDeployment(karmada *in
 		},
 	}
 
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, fkm.ImagePullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, fkm.Placement, karmada.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(karmada, deployment, scheme.Scheme)
-	_, err := ctrl.client.AppsV1().Deployments(karmada.Namespace).Create(context.TODO(), deployment, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
+	if err := clientutil.ApplyDeployment(ctrl.client, deployment); err != nil {
 		return err
 	}
-	return nil
+	return ctrl.EnsurePodDisruptionBudget(karmada, deployment.Name, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas, fkm.PodDisruptionBudget)
 }
 
 func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerCRDs(karmada *installv1alpha1.Karmada) error {
@@ -216,20 +211,5 @@ func (ctrl *KarmadaController) EnsureFireflyKarmadaManagerCRDs(karmada *installv
 	if err != nil {
 		return err
 	}
-	result := utilresource.NewBuilder(clientConfig).
-		Unstructured().
-		FilenameParam(false, &resource.FilenameOptions{Recursive: false, Filenames: []string{"./pkg/karmada/crds"}}).
-		Flatten().Do()
-	return result.Visit(func(info *resource.Info, err error) error {
-		if err != nil {
-			return err
-		}
-		_, err1 := resource.NewHelper(info.Client, info.Mapping).Create(info.Namespace, true, info.Object)
-		if err1 != nil {
-			if !errors.IsAlreadyExists(err1) {
-				return err1
-			}
-		}
-		return nil
-	})
+	return utilresource.ApplyBundle(utilresource.NewBuilder(clientConfig), karmadamanagercrds.CRDs, ".", constants.FieldManager)
 }
@@ -17,9 +17,17 @@ limitations under the License.
 package karmada
 
 import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	restclient "k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/scheme"
+	"github.com/carlory/firefly/pkg/util"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
 	utilresource "github.com/carlory/firefly/pkg/util/resource"
 )
 
@@ -29,6 +37,25 @@ const (
 )
 
 func (ctrl *KarmadaController) GenerateClientConfig(karmada *installv1alpha1.Karmada) (*restclient.Config, error) {
-	secretName := "karmada-kubeconfig"
-	return utilresource.GetClientConfigFromKubeConfigSecret(ctrl.client, karmada.Namespace, secretName, userAgentName)
+	secretName := GenerateKubeConfigSecretName(karmada)
+	config, err := utilresource.GetClientConfigFromKubeConfigSecret(ctrl.client, karmada.Namespace, secretName, userAgentName)
+	if err != nil {
+		return nil, err
+	}
+	if ctrl.plan != nil {
+		config = ctrl.plan.WrapConfig(config, config.Host)
+	}
+	return config, nil
+}
+
+// EnsurePodDisruptionBudget ensures the PodDisruptionBudget of a component exists when the component has more
+// than one replica, and removes it otherwise.
+func (ctrl *KarmadaController) EnsurePodDisruptionBudget(karmada *installv1alpha1.Karmada, componentName string, selector map[string]string, replicas *int32, policy *installv1alpha1.PodDisruptionBudgetPolicy) error {
+	pdb := util.PodDisruptionBudget(componentName, karmada.Namespace, selector, replicas, policy)
+	if pdb == nil {
+		err := ctrl.client.PolicyV1().PodDisruptionBudgets(karmada.Namespace).Delete(context.TODO(), componentName, metav1.DeleteOptions{})
+		return client.IgnoreNotFound(err)
+	}
+	controllerutil.SetOwnerReference(karmada, pdb, scheme.Scheme)
+	return clientutil.ApplyPodDisruptionBudget(ctrl.client, pdb)
 }
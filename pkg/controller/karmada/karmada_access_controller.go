@@ -0,0 +1,681 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authentication/user"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/record"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/component-base/metrics/prometheus/ratelimiter"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	fireflyclient "github.com/carlory/firefly/pkg/generated/clientset/versioned"
+	installinformers "github.com/carlory/firefly/pkg/generated/informers/externalversions/install/v1alpha1"
+	installlisters "github.com/carlory/firefly/pkg/generated/listers/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/metrics"
+	"github.com/carlory/firefly/pkg/scheme"
+	"github.com/carlory/firefly/pkg/util/certs"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+	utilresource "github.com/carlory/firefly/pkg/util/resource"
+)
+
+const (
+	// KarmadaAccessControllerFinalizerName is the finalizer which removes the role bindings of an access from its karmada.
+	KarmadaAccessControllerFinalizerName = "karmadaaccess.install.firefly.io/finalizer"
+
+	// karmadaAccessRetryInterval is the interval in which an access which can't be issued yet is retried.
+	karmadaAccessRetryInterval = 10 * time.Second
+	// defaultKarmadaAccessTTL is the TTL of an access which doesn't set it.
+	defaultKarmadaAccessTTL = 24 * time.Hour
+
+	reasonAccessInvalid    = "Invalid"
+	reasonCAKeyUnavailable = "CAKeyUnavailable"
+	reasonAccessIssued     = "Issued"
+	reasonAccessExpired    = "Expired"
+
+	// systemSubjectPrefix is the prefix of the users and groups which are reserved for the system components.
+	systemSubjectPrefix = "system:"
+)
+
+// NewKarmadaAccessController returns a new *KarmadaAccessController.
+func NewKarmadaAccessController(
+	client clientset.Interface,
+	fireflyClient fireflyclient.Interface,
+	karmadaAccessInformer installinformers.KarmadaAccessInformer,
+	karmadaInformer installinformers.KarmadaInformer,
+	allowedSystemSubjects []string) (*KarmadaAccessController, error) {
+	broadcaster := record.NewBroadcaster()
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "karmada-access-controller"})
+
+	if client != nil && client.CoreV1().RESTClient().GetRateLimiter() != nil {
+		ratelimiter.RegisterMetricAndTrackRateLimiterUsage("karmada_access_controller", client.CoreV1().RESTClient().GetRateLimiter())
+	}
+	metrics.Register()
+
+	ctrl := &KarmadaAccessController{
+		client:                client,
+		fireflyClient:         fireflyClient,
+		karmadaAccessesLister: karmadaAccessInformer.Lister(),
+		karmadaAccessesSynced: karmadaAccessInformer.Informer().HasSynced,
+		karmadasLister:        karmadaInformer.Lister(),
+		karmadasSynced:        karmadaInformer.Informer().HasSynced,
+		queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "karmadaaccess"),
+		workerLoopPeriod:      time.Second,
+		eventBroadcaster:      broadcaster,
+		eventRecorder:         recorder,
+		allowedSystemSubjects: sets.NewString(allowedSystemSubjects...),
+	}
+
+	karmadaAccessInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ctrl.enqueue,
+		UpdateFunc: func(old, cur interface{}) { ctrl.enqueue(cur) },
+	})
+	karmadaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ctrl.enqueueKarmadaAccesses,
+		UpdateFunc: func(old, cur interface{}) { ctrl.enqueueKarmadaAccesses(cur) },
+	})
+
+	return ctrl, nil
+}
+
+// KarmadaAccessController issues the kubeconfigs of the karmada accesses.
+//
+// The client certificate of an access is signed by the CA of its karmada and written into a kubeconfig secret
+// together with the address of the karmada-apiserver, and the roles of the access are bound to its user and
+// groups in the karmada-apiserver. When the TTL of the access expires, its kubeconfig and role bindings are
+// removed, or the certificate is rotated in advance if the access asks for it.
+type KarmadaAccessController struct {
+	client           clientset.Interface
+	fireflyClient    fireflyclient.Interface
+	eventBroadcaster record.EventBroadcaster
+	eventRecorder    record.EventRecorder
+
+	karmadaAccessesLister installlisters.KarmadaAccessLister
+	karmadaAccessesSynced cache.InformerSynced
+	karmadasLister        installlisters.KarmadaLister
+	karmadasSynced        cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+
+	// workerLoopPeriod is the time between worker runs.
+	workerLoopPeriod time.Duration
+
+	// allowedSystemSubjects are the users and groups with the system: prefix which an access can be issued for.
+	allowedSystemSubjects sets.String
+}
+
+// Run will not return until stopCh is closed. workers determines how many
+// karmada accesses will be handled in parallel.
+func (ctrl *KarmadaAccessController) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+
+	ctrl.eventBroadcaster.StartStructuredLogging(0)
+	ctrl.eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: ctrl.client.CoreV1().Events("")})
+	defer ctrl.eventBroadcaster.Shutdown()
+
+	defer ctrl.queue.ShutDown()
+
+	klog.Infof("Starting karmada access controller")
+	defer klog.Infof("Shutting down karmada access controller")
+
+	if !cache.WaitForNamedCacheSync("karmadaaccess", ctx.Done(), ctrl.karmadaAccessesSynced, ctrl.karmadasSynced) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, ctrl.worker, ctrl.workerLoopPeriod)
+	}
+	<-ctx.Done()
+}
+
+func (ctrl *KarmadaAccessController) worker(ctx context.Context) {
+	for ctrl.processNextWorkItem(ctx) {
+	}
+}
+
+func (ctrl *KarmadaAccessController) processNextWorkItem(ctx context.Context) bool {
+	key, quit := ctrl.queue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.queue.Done(key)
+
+	startTime := time.Now()
+	err := ctrl.syncKarmadaAccess(ctx, key.(string))
+	metrics.ObserveReconcile("karmadaaccess", metrics.PhaseSync, startTime, err)
+	if err == nil {
+		ctrl.queue.Forget(key)
+		return true
+	}
+	if ctrl.queue.NumRequeues(key) < maxRetries {
+		klog.V(2).InfoS("Error syncing karmada access, retrying", "karmadaAccess", key, "err", err)
+		ctrl.queue.AddRateLimited(key)
+		return true
+	}
+	utilruntime.HandleError(err)
+	klog.V(2).InfoS("Dropping karmada access out of the queue", "karmadaAccess", key, "err", err)
+	ctrl.queue.Forget(key)
+	return true
+}
+
+func (ctrl *KarmadaAccessController) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	ctrl.queue.Add(key)
+}
+
+// enqueueKarmadaAccesses enqueues the accesses of the karmada.
+func (ctrl *KarmadaAccessController) enqueueKarmadaAccesses(obj interface{}) {
+	karmada := obj.(*installv1alpha1.Karmada)
+	accesses, err := ctrl.karmadaAccessesLister.KarmadaAccesses(karmada.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, access := range accesses {
+		if access.Spec.KarmadaName == karmada.Name {
+			ctrl.enqueue(access)
+		}
+	}
+}
+
+func (ctrl *KarmadaAccessController) syncKarmadaAccess(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	access, err := ctrl.karmadaAccessesLister.KarmadaAccesses(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		klog.V(2).InfoS("Karmada access has been deleted", "karmadaAccess", klog.KRef(namespace, name))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	access = access.DeepCopy()
+
+	if !access.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(access, KarmadaAccessControllerFinalizerName) {
+			return nil
+		}
+		if err := ctrl.removeRoleBindings(ctx, access); err != nil {
+			return err
+		}
+		controllerutil.RemoveFinalizer(access, KarmadaAccessControllerFinalizerName)
+		_, err = ctrl.fireflyClient.InstallV1alpha1().KarmadaAccesses(namespace).Update(ctx, access, metav1.UpdateOptions{})
+		return err
+	}
+	if !controllerutil.ContainsFinalizer(access, KarmadaAccessControllerFinalizerName) {
+		controllerutil.AddFinalizer(access, KarmadaAccessControllerFinalizerName)
+		access, err = ctrl.fireflyClient.InstallV1alpha1().KarmadaAccesses(namespace).Update(ctx, access, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	old := access.DeepCopy()
+	requeueAfter, syncErr := ctrl.issue(ctx, access)
+	if !equality.Semantic.DeepEqual(old.Status, access.Status) {
+		if _, err := ctrl.fireflyClient.InstallV1alpha1().KarmadaAccesses(namespace).UpdateStatus(ctx, access, metav1.UpdateOptions{}); err != nil {
+			klog.ErrorS(err, "Failed to update karmada access status", "karmadaAccess", klog.KObj(access))
+			if syncErr == nil {
+				syncErr = err
+			}
+		}
+	}
+	if syncErr != nil {
+		return syncErr
+	}
+	if requeueAfter > 0 {
+		ctrl.queue.AddAfter(key, requeueAfter)
+	}
+	return nil
+}
+
+// issue issues the kubeconfig of the access and binds its roles, or revokes them once its TTL expires. It returns
+// the duration after which the access must be synced again, or zero if it's not needed.
+//
+// The observed generation is only recorded once the spec is acted on, a spec which is changed while the karmada
+// isn't ready is still issued as a new TTL later.
+func (ctrl *KarmadaAccessController) issue(ctx context.Context, access *installv1alpha1.KarmadaAccess) (time.Duration, error) {
+	// Changing the spec starts a new TTL, also for an access which is revoked.
+	specChanged := access.Status.ObservedGeneration != access.Generation
+	if ready := meta.FindStatusCondition(access.Status.Conditions, installv1alpha1.KarmadaAccessConditionReady); ready != nil && ready.Reason == reasonAccessExpired && !specChanged {
+		return 0, nil
+	}
+
+	karmada, err := ctrl.karmadasLister.Karmadas(access.Namespace).Get(access.Spec.KarmadaName)
+	if errors.IsNotFound(err) {
+		ctrl.setCondition(access, metav1.ConditionFalse, reasonKarmadaNotReady, fmt.Sprintf("The karmada %s is not found", access.Spec.KarmadaName))
+		return karmadaAccessRetryInterval, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !meta.IsStatusConditionTrue(karmada.Status.Conditions, installv1alpha1.KarmadaConditionReady) {
+		ctrl.setCondition(access, metav1.ConditionFalse, reasonKarmadaNotReady, fmt.Sprintf("Waiting for the karmada %s to be ready", karmada.Name))
+		return karmadaAccessRetryInterval, nil
+	}
+	if err := validateKarmadaAccess(access, ctrl.allowedSystemSubjects); err != nil {
+		access.Status.ObservedGeneration = access.Generation
+		ctrl.setCondition(access, metav1.ConditionFalse, reasonAccessInvalid, err.Error())
+		return 0, nil
+	}
+	if karmada.Spec.Certificates.IssuerRef != nil {
+		ctrl.setCondition(access, metav1.ConditionFalse, reasonCAKeyUnavailable, fmt.Sprintf("The certificates of the karmada %s are issued by cert-manager, its CA key is not available to sign the certificate of the access", karmada.Name))
+		return 0, nil
+	}
+
+	ttl := karmadaAccessTTL(access)
+	policy := access.Spec.ExpirationPolicy
+	if policy == "" {
+		policy = installv1alpha1.AccessExpirationPolicyRevoke
+	}
+	if policy == installv1alpha1.AccessExpirationPolicyRevoke && !specChanged && access.Status.ExpirationTime != nil && !time.Now().Before(access.Status.ExpirationTime.Time) {
+		if err := ctrl.revoke(ctx, access); err != nil {
+			return 0, err
+		}
+		ctrl.setCondition(access, metav1.ConditionFalse, reasonAccessExpired, fmt.Sprintf("The access expired at %s, its kubeconfig and role bindings are removed", access.Status.ExpirationTime.Format(time.RFC3339)))
+		return 0, nil
+	}
+
+	certSecret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(ctx, generateCertSecretName(karmada), metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+	caData := certSecret.Data["ca.crt"]
+	caCert, caKey, err := certs.ParseCertAndKey(caData, certSecret.Data["ca.key"])
+	if err != nil {
+		return 0, fmt.Errorf("failed to load the CA of the karmada %s: %v", karmada.Name, err)
+	}
+
+	// The TTL of a revoked access is fixed when it's issued, a certificate which is issued again, e.g. for a
+	// new CA, expires at the same time. A rotated access gets another TTL with each certificate.
+	notAfter := time.Now().Add(ttl).UTC()
+	renewBefore := ttl / 3
+	if policy == installv1alpha1.AccessExpirationPolicyRevoke {
+		if access.Status.ExpirationTime != nil && !specChanged {
+			notAfter = access.Status.ExpirationTime.UTC()
+		}
+		renewBefore = 0
+	}
+	user := karmadaAccessUser(access)
+	config := certs.NewCertConfig(user, access.Spec.Groups, certutil.AltNames{}, &notAfter)
+	config.Usages = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	secretName := karmadaAccessSecretName(access)
+	cert, key, err := ctrl.currentCertAndKey(ctx, access.Namespace, secretName)
+	if err != nil {
+		return 0, err
+	}
+	if specChanged {
+		cert, key = nil, nil
+	} else if cert != nil {
+		if reason := certs.CertRenewalReason(cert, caCert, config, renewBefore); reason != "" {
+			klog.InfoS("Issuing the certificate of karmada access again", "karmadaAccess", klog.KObj(access), "reason", reason)
+			cert, key = nil, nil
+		}
+	}
+	if cert == nil {
+		cert, key, err = certs.NewCertAndKey(caCert, caKey, config)
+		if err != nil {
+			return 0, fmt.Errorf("failed to sign the certificate of the access: %v", err)
+		}
+		ctrl.eventRecorder.Eventf(access, corev1.EventTypeNormal, "CertificateIssued", "Issued a certificate for %s which expires at %s", user, cert.NotAfter.Format(time.RFC3339))
+	}
+
+	if err := ctrl.ensureKubeconfig(ctx, karmada, access, secretName, caData, cert, key); err != nil {
+		return 0, err
+	}
+	if old := access.Status.SecretName; old != "" && old != secretName {
+		if err := ctrl.client.CoreV1().Secrets(access.Namespace).Delete(ctx, old, metav1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+			return 0, err
+		}
+	}
+	if err := ctrl.ensureRoleBindings(ctx, karmada, access, user); err != nil {
+		return 0, err
+	}
+
+	access.Status.ObservedGeneration = access.Generation
+	access.Status.User = user
+	access.Status.SecretName = secretName
+	access.Status.ExpirationTime = &metav1.Time{Time: cert.NotAfter}
+	ctrl.setCondition(access, metav1.ConditionTrue, reasonAccessIssued, fmt.Sprintf("The kubeconfig of %s is written into the secret %s, it expires at %s", user, secretName, cert.NotAfter.Format(time.RFC3339)))
+	return time.Until(cert.NotAfter.Add(-renewBefore)) + time.Second, nil
+}
+
+// currentCertAndKey returns the client certificate and key of the kubeconfig in the secret, or nil if it doesn't
+// exist or is invalid.
+func (ctrl *KarmadaAccessController) currentCertAndKey(ctx context.Context, namespace, secretName string) (*x509.Certificate, crypto.Signer, error) {
+	secret, err := ctrl.client.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, key := kubeconfigCertAndKey(secret.Data["kubeconfig"])
+	return cert, key, nil
+}
+
+// kubeconfigCertAndKey returns the client certificate and key of the current context of the kubeconfig, or nil if
+// the kubeconfig is invalid or has no client certificate.
+func kubeconfigCertAndKey(data []byte) (*x509.Certificate, crypto.Signer) {
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, nil
+	}
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, nil
+	}
+	authInfo, ok := config.AuthInfos[kubeContext.AuthInfo]
+	if !ok {
+		return nil, nil
+	}
+	cert, key, err := certs.ParseCertAndKey(authInfo.ClientCertificateData, authInfo.ClientKeyData)
+	if err != nil {
+		return nil, nil
+	}
+	return cert, key
+}
+
+// ensureKubeconfig writes the kubeconfig with the client certificate into the secret of the access. The address of
+// the karmada-apiserver is taken from the external kubeconfig of the karmada if it's exposed, otherwise the access
+// only works inside of the host cluster.
+func (ctrl *KarmadaAccessController) ensureKubeconfig(ctx context.Context, karmada *installv1alpha1.Karmada, access *installv1alpha1.KarmadaAccess,
+	secretName string, caData []byte, cert *x509.Certificate, key crypto.Signer) error {
+	kubeconfigSecret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(ctx, generateExternalKubeConfigSecretName(karmada), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		kubeconfigSecret, err = ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(ctx, GenerateKubeConfigSecretName(karmada), metav1.GetOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	kubeconfig, err := clientcmd.Load(kubeconfigSecret.Data["kubeconfig"])
+	if err != nil {
+		return err
+	}
+	var server *clientcmdapi.Cluster
+	for _, cluster := range kubeconfig.Clusters {
+		server = cluster
+		break
+	}
+	if server == nil || server.Server == "" {
+		return fmt.Errorf("the kubeconfig secret %s has no cluster", kubeconfigSecret.Name)
+	}
+
+	certData, keyData, err := certs.EncodeCertAndKeyPEM(cert, key)
+	if err != nil {
+		return err
+	}
+	config := certs.CreateWithCerts(server.Server, karmadaAccessUser(access), karmada.Name, caData, keyData, certData)
+	config.Clusters[karmada.Name].TLSServerName = server.TLSServerName
+	configBytes, err := clientcmd.Write(*config)
+	if err != nil {
+		return fmt.Errorf("failure while serializing the kubeconfig of the access: %v", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: access.Namespace},
+		Data:       map[string][]byte{"kubeconfig": configBytes},
+	}
+	controllerutil.SetOwnerReference(access, secret, scheme.Scheme)
+	return clientutil.ApplySecret(ctrl.client, secret)
+}
+
+// ensureRoleBindings binds the roles of the access to its user and groups in the karmada, and removes the role
+// bindings of the roles which are no longer listed.
+func (ctrl *KarmadaAccessController) ensureRoleBindings(ctx context.Context, karmada *installv1alpha1.Karmada, access *installv1alpha1.KarmadaAccess, user string) error {
+	karmadaClient, err := ctrl.karmadaClient(karmada)
+	if err != nil {
+		return err
+	}
+
+	subjects := []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: user}}
+	for _, group := range access.Spec.Groups {
+		subjects = append(subjects, rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: group})
+	}
+	accessLabels := map[string]string{constants.KarmadaAccessLabel: access.Namespace}
+
+	clusterRoleBindings, roleBindings := sets.NewString(), sets.NewString()
+	for _, role := range access.Spec.Roles {
+		kind := karmadaAccessRoleKind(role)
+		name := karmadaAccessRoleBindingName(access, kind, role.Name)
+		roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: kind, Name: role.Name}
+		if role.Namespace == "" {
+			clusterRoleBindings.Insert(name)
+			if err := clientutil.ApplyClusterRoleBinding(karmadaClient, &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: accessLabels},
+				RoleRef:    roleRef,
+				Subjects:   subjects,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		roleBindings.Insert(role.Namespace + "/" + name)
+		if err := clientutil.ApplyRoleBinding(karmadaClient, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: role.Namespace, Labels: accessLabels},
+			RoleRef:    roleRef,
+			Subjects:   subjects,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return ctrl.cleanupRoleBindings(ctx, karmadaClient, access, clusterRoleBindings, roleBindings)
+}
+
+// removeRoleBindings removes all the role bindings of the access from its karmada, it's skipped if the karmada is
+// no longer accessible.
+func (ctrl *KarmadaAccessController) removeRoleBindings(ctx context.Context, access *installv1alpha1.KarmadaAccess) error {
+	karmada, err := ctrl.karmadasLister.Karmadas(access.Namespace).Get(access.Spec.KarmadaName)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !karmada.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	karmadaClient, err := ctrl.karmadaClient(karmada)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return ctrl.cleanupRoleBindings(ctx, karmadaClient, access, sets.NewString(), sets.NewString())
+}
+
+// cleanupRoleBindings removes the role bindings of the access from the karmada except the given ones. The names of
+// the role bindings are prefixed with the access, as their labels can't hold the name of an access.
+func (ctrl *KarmadaAccessController) cleanupRoleBindings(ctx context.Context, karmadaClient clientset.Interface, access *installv1alpha1.KarmadaAccess, clusterRoleBindings, roleBindings sets.String) error {
+	prefix := karmadaAccessRoleBindingName(access, "", "")
+	selector := labels.Set{constants.KarmadaAccessLabel: access.Namespace}.String()
+
+	crbs, err := karmadaClient.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, crb := range crbs.Items {
+		if !strings.HasPrefix(crb.Name, prefix) || clusterRoleBindings.Has(crb.Name) {
+			continue
+		}
+		if err := karmadaClient.RbacV1().ClusterRoleBindings().Delete(ctx, crb.Name, metav1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	rbs, err := karmadaClient.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, rb := range rbs.Items {
+		if !strings.HasPrefix(rb.Name, prefix) || roleBindings.Has(rb.Namespace+"/"+rb.Name) {
+			continue
+		}
+		if err := karmadaClient.RbacV1().RoleBindings(rb.Namespace).Delete(ctx, rb.Name, metav1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revoke removes the kubeconfig and the role bindings of an expired access.
+func (ctrl *KarmadaAccessController) revoke(ctx context.Context, access *installv1alpha1.KarmadaAccess) error {
+	klog.InfoS("Revoking expired karmada access", "karmadaAccess", klog.KObj(access))
+	if err := ctrl.removeRoleBindings(ctx, access); err != nil {
+		return err
+	}
+	if access.Status.SecretName != "" {
+		if err := ctrl.client.CoreV1().Secrets(access.Namespace).Delete(ctx, access.Status.SecretName, metav1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// karmadaClient returns the client of the karmada-apiserver with the admin kubeconfig of the karmada.
+func (ctrl *KarmadaAccessController) karmadaClient(karmada *installv1alpha1.Karmada) (clientset.Interface, error) {
+	karmadaConfig, err := utilresource.GetClientConfigFromKubeConfigSecret(ctrl.client, karmada.Namespace, GenerateKubeConfigSecretName(karmada), userAgentName)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.NewForConfig(karmadaConfig)
+}
+
+// setCondition sets the Ready condition of the access and records an event if it changes.
+func (ctrl *KarmadaAccessController) setCondition(access *installv1alpha1.KarmadaAccess, status metav1.ConditionStatus, reason, message string) {
+	old := meta.FindStatusCondition(access.Status.Conditions, installv1alpha1.KarmadaAccessConditionReady)
+	if old == nil || old.Status != status || old.Reason != reason {
+		eventType := corev1.EventTypeNormal
+		if status != metav1.ConditionTrue {
+			eventType = corev1.EventTypeWarning
+		}
+		ctrl.eventRecorder.Event(access, eventType, reason, message)
+	}
+	meta.SetStatusCondition(&access.Status.Conditions, metav1.Condition{
+		Type:               installv1alpha1.KarmadaAccessConditionReady,
+		Status:             status,
+		ObservedGeneration: access.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// validateKarmadaAccess returns an error if the access can't be issued as specified. The users and groups
+// with the system: prefix are reserved for the components of kubernetes and karmada, so they're rejected
+// unless they're allowed explicitly. The system:masters group bypasses the authorization and is never allowed.
+func validateKarmadaAccess(access *installv1alpha1.KarmadaAccess, allowedSystemSubjects sets.String) error {
+	if user := karmadaAccessUser(access); !systemSubjectAllowed(user, allowedSystemSubjects) {
+		return fmt.Errorf("the user %s is not allowed", user)
+	}
+	for _, group := range access.Spec.Groups {
+		if group == user.SystemPrivilegedGroup || !systemSubjectAllowed(group, allowedSystemSubjects) {
+			return fmt.Errorf("the group %s is not allowed", group)
+		}
+	}
+	for _, role := range access.Spec.Roles {
+		if karmadaAccessRoleKind(role) == "Role" && role.Namespace == "" {
+			return fmt.Errorf("the namespace of the role %s is not set", role.Name)
+		}
+	}
+	if ttl := access.Spec.TTL; ttl != nil && ttl.Duration <= 0 {
+		return fmt.Errorf("the ttl %s is not positive", ttl.Duration)
+	}
+	return nil
+}
+
+// systemSubjectAllowed returns true if the user or group doesn't have the system: prefix or is allowed explicitly.
+func systemSubjectAllowed(subject string, allowedSystemSubjects sets.String) bool {
+	return !strings.HasPrefix(subject, systemSubjectPrefix) || allowedSystemSubjects.Has(subject)
+}
+
+// karmadaAccessUser returns the name of the user of the access.
+func karmadaAccessUser(access *installv1alpha1.KarmadaAccess) string {
+	if access.Spec.User != "" {
+		return access.Spec.User
+	}
+	return fmt.Sprintf("karmada-access:%s:%s", access.Namespace, access.Name)
+}
+
+// karmadaAccessTTL returns the duration for which the certificate of the access is valid.
+func karmadaAccessTTL(access *installv1alpha1.KarmadaAccess) time.Duration {
+	if access.Spec.TTL != nil {
+		return access.Spec.TTL.Duration
+	}
+	return defaultKarmadaAccessTTL
+}
+
+// karmadaAccessSecretName returns the name of the secret which holds the kubeconfig of the access.
+func karmadaAccessSecretName(access *installv1alpha1.KarmadaAccess) string {
+	if access.Spec.SecretName != "" {
+		return access.Spec.SecretName
+	}
+	return fmt.Sprintf("%s-kubeconfig", access.Name)
+}
+
+func karmadaAccessRoleKind(role installv1alpha1.AccessRole) string {
+	if role.Kind != "" {
+		return role.Kind
+	}
+	return "ClusterRole"
+}
+
+// karmadaAccessRoleBindingName returns the name of the role binding of a role of the access in the karmada,
+// or the prefix of the names if the kind and the name of the role are empty.
+func karmadaAccessRoleBindingName(access *installv1alpha1.KarmadaAccess, kind, name string) string {
+	prefix := fmt.Sprintf("karmada-access:%s:%s:", access.Namespace, access.Name)
+	if kind == "" {
+		return prefix
+	}
+	return fmt.Sprintf("%s%s:%s", prefix, strings.ToLower(kind), name)
+}
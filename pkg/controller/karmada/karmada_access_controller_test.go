@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+)
+
+func TestValidateKarmadaAccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    installv1alpha1.KarmadaAccessSpec
+		allowed []string
+		wantErr bool
+	}{
+		{
+			name: "default user",
+			spec: installv1alpha1.KarmadaAccessSpec{Groups: []string{"team-a"}},
+		},
+		{
+			name:    "system user",
+			spec:    installv1alpha1.KarmadaAccessSpec{User: "system:kube-controller-manager"},
+			wantErr: true,
+		},
+		{
+			name:    "allowed system user",
+			spec:    installv1alpha1.KarmadaAccessSpec{User: "system:monitoring"},
+			allowed: []string{"system:monitoring"},
+		},
+		{
+			name:    "system group",
+			spec:    installv1alpha1.KarmadaAccessSpec{User: "alice", Groups: []string{"team-a", "system:nodes"}},
+			wantErr: true,
+		},
+		{
+			name:    "allowed system group",
+			spec:    installv1alpha1.KarmadaAccessSpec{User: "alice", Groups: []string{"system:monitoring"}},
+			allowed: []string{"system:monitoring"},
+		},
+		{
+			name:    "system:masters",
+			spec:    installv1alpha1.KarmadaAccessSpec{User: "alice", Groups: []string{"system:masters"}},
+			wantErr: true,
+		},
+		{
+			name:    "allowed system:masters",
+			spec:    installv1alpha1.KarmadaAccessSpec{User: "alice", Groups: []string{"system:masters"}},
+			allowed: []string{"system:masters"},
+			wantErr: true,
+		},
+		{
+			name:    "role without namespace",
+			spec:    installv1alpha1.KarmadaAccessSpec{Roles: []installv1alpha1.AccessRole{{Kind: "Role", Name: "view"}}},
+			wantErr: true,
+		},
+		{
+			name:    "non-positive ttl",
+			spec:    installv1alpha1.KarmadaAccessSpec{TTL: &metav1.Duration{Duration: -time.Hour}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			access := &installv1alpha1.KarmadaAccess{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "firefly-system"},
+				Spec:       tt.spec,
+			}
+			err := validateKarmadaAccess(access, sets.NewString(tt.allowed...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateKarmadaAccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
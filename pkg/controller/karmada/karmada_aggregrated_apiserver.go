@@ -44,7 +44,7 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServer(karmada *install
 	if err := ctrl.EnsureKarmadaAggregatedAPIServerDeployment(karmada); err != nil {
 		return err
 	}
-	podLabel := fmt.Sprintf("app=%s", constants.KarmadaComponentAggregratedAPIServer)
+	podLabel := fmt.Sprintf("app=%s", karmadaComponentName(karmada, constants.KarmadaComponentAggregratedAPIServer))
 	err := util.NewKubeWaiter(ctrl.client, 10*time.Second).WaitForPodsWithLabel(karmada.Namespace, podLabel)
 	if err != nil {
 		return err
@@ -53,7 +53,7 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServer(karmada *install
 }
 
 func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerService(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentAggregratedAPIServer
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentAggregratedAPIServer)
 	svc := &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
@@ -79,11 +79,11 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerService(karmada *
 		},
 	}
 	controllerutil.SetOwnerReference(karmada, svc, scheme.Scheme)
-	return clientutil.CreateOrUpdateService(ctrl.client, svc)
+	return clientutil.ApplyService(ctrl.client, svc)
 }
 
 func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerDeployment(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentAggregratedAPIServer
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentAggregratedAPIServer)
 	server := karmada.Spec.APIServer.KarmadaAggregratedAPIServer
 	repository := karmada.Spec.ImageRepository
 	if server.ImageRepository != "" {
@@ -102,10 +102,7 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerDeployment(karmad
 		"kubeconfig":                "/etc/kubeconfig",
 		"authentication-kubeconfig": "/etc/kubeconfig",
 		"authorization-kubeconfig":  "/etc/kubeconfig",
-		"etcd-cafile":               "/etc/kubernetes/pki/etcd-ca.crt",
-		"etcd-certfile":             "/etc/kubernetes/pki/etcd-client.crt",
-		"etcd-keyfile":              "/etc/kubernetes/pki/etcd-client.key",
-		"etcd-servers":              fmt.Sprintf("https://%s.%s.svc:2379", constants.KarmadaComponentEtcd, karmada.Namespace),
+		"etcd-servers":              etcdServers(karmada),
 		"audit-log-path":            "-",
 		"feature-gates":             "APIPriorityAndFairness=false",
 		"audit-log-maxage":          "0",
@@ -114,6 +111,11 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerDeployment(karmad
 		"tls-private-key-file":      "/etc/kubernetes/pki/apiserver.key",
 	}
 	featureGates := karmada.Spec.FeatureGates
+	etcdArgs, err := ctrl.etcdClientTLSArgs(karmada)
+	if err != nil {
+		return err
+	}
+	defaultArgs = maputil.MergeStringMaps(defaultArgs, etcdArgs)
 	for feature, enabled := range featureGates {
 		if defaultArgs["feature-gates"] == "" {
 			defaultArgs["feature-gates"] = fmt.Sprintf("%s=%t", feature, enabled)
@@ -146,8 +148,8 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerDeployment(karmad
 					Containers: []corev1.Container{
 						{
 							Name:            "karmada-aggregated-apiserver",
-							Image:           util.ComponentImageName(repository, imageName, tag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           util.ComponentImage(karmada.Spec.ImageOverrides, constants.KarmadaComponentAggregratedAPIServer, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(server.ImagePullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
 							Command:         []string{"/bin/karmada-aggregated-apiserver"},
 							Args:            args,
 							Resources:       server.Resources,
@@ -203,7 +205,7 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerDeployment(karmad
 							Name: "k8s-certs",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "karmada-cert",
+									SecretName: generateCertSecretName(karmada),
 								},
 							},
 						},
@@ -211,7 +213,7 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerDeployment(karmad
 							Name: "kubeconfig",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "karmada-kubeconfig",
+									SecretName: GenerateKubeConfigSecretName(karmada),
 								},
 							},
 						},
@@ -221,8 +223,14 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerDeployment(karmad
 		},
 	}
 
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, server.ImagePullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, server.Placement, karmada.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(karmada, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.client, deployment)
+	if err := clientutil.ApplyDeployment(ctrl.client, deployment); err != nil {
+		return err
+	}
+	return ctrl.EnsurePodDisruptionBudget(karmada, deployment.Name, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas, server.PodDisruptionBudget)
 }
 
 func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerAPIService(karmada *installv1alpha1.Karmada) error {
@@ -250,10 +258,10 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerAPIService(karmad
 		},
 		Spec: corev1.ServiceSpec{
 			Type:         corev1.ServiceTypeExternalName,
-			ExternalName: fmt.Sprintf("%s.%s.svc", constants.KarmadaComponentAggregratedAPIServer, karmada.Namespace),
+			ExternalName: fmt.Sprintf("%s.%s.svc", karmadaComponentName(karmada, constants.KarmadaComponentAggregratedAPIServer), karmada.Namespace),
 		},
 	}
-	if err = clientutil.CreateOrUpdateService(kubeClient, svc); err != nil {
+	if err = clientutil.ApplyService(kubeClient, svc); err != nil {
 		return err
 	}
 
@@ -279,5 +287,5 @@ func (ctrl *KarmadaController) EnsureKarmadaAggregatedAPIServerAPIService(karmad
 			VersionPriority: 10,
 		},
 	}
-	return clientutil.CreateOrUpdateAPIService(aaClient, apisvc)
+	return clientutil.ApplyAPIService(aaClient, apisvc)
 }
@@ -26,8 +26,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -41,7 +43,9 @@ import (
 	fireflyclient "github.com/carlory/firefly/pkg/generated/clientset/versioned"
 	installinformers "github.com/carlory/firefly/pkg/generated/informers/externalversions/install/v1alpha1"
 	installlisters "github.com/carlory/firefly/pkg/generated/listers/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/metrics"
 	"github.com/carlory/firefly/pkg/scheme"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
 )
 
 const (
@@ -60,6 +64,8 @@ const (
 func NewKarmadaController(
 	client clientset.Interface,
 	fireflyClient fireflyclient.Interface,
+	dynamicClient dynamic.Interface,
+	config *restclient.Config,
 	karmadaInformer installinformers.KarmadaInformer) (*KarmadaController, error) {
 	broadcaster := record.NewBroadcaster()
 	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "karmada-controller"})
@@ -67,10 +73,14 @@ func NewKarmadaController(
 	if client != nil && client.CoreV1().RESTClient().GetRateLimiter() != nil {
 		ratelimiter.RegisterMetricAndTrackRateLimiterUsage("karmada_controller", client.CoreV1().RESTClient().GetRateLimiter())
 	}
+	metrics.Register()
+	metrics.RegisterKarmadaCollector(karmadaInformer.Lister())
 
 	ctrl := &KarmadaController{
 		client:           client,
 		fireflyClient:    fireflyClient,
+		dynamicClient:    dynamicClient,
+		config:           config,
 		karmadasLister:   karmadaInformer.Lister(),
 		karmadasSynced:   karmadaInformer.Informer().HasSynced,
 		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "karmada"),
@@ -91,9 +101,16 @@ func NewKarmadaController(
 type KarmadaController struct {
 	client           clientset.Interface
 	fireflyClient    fireflyclient.Interface
+	dynamicClient    dynamic.Interface
 	eventBroadcaster record.EventBroadcaster
 	eventRecorder    record.EventRecorder
 
+	// config is the config of the clients of the host cluster, from which the dry-run clients of a plan are built.
+	config *restclient.Config
+	// plan records the changes instead of applying them. It is only set on the copy of the controller which
+	// computes the plan of a karmada.
+	plan *clientutil.Plan
+
 	karmadasLister installlisters.KarmadaLister
 	karmadasSynced cache.InformerSynced
 
@@ -149,7 +166,9 @@ func (ctrl *KarmadaController) processNextWorkItem(ctx context.Context) bool {
 	}
 	defer ctrl.queue.Done(key)
 
+	startTime := time.Now()
 	err := ctrl.syncKarmada(ctx, key.(string))
+	metrics.ObserveReconcile("karmada", metrics.PhaseSync, startTime, err)
 	ctrl.handleErr(err, key)
 
 	return true
@@ -243,6 +262,13 @@ func (ctrl *KarmadaController) syncKarmada(ctx context.Context, key string) erro
 	// TODO: Deep-copy only when needed.
 	karmada = karmada.DeepCopy()
 
+	if karmada.Annotations[constants.NamingAnnotation] == "" {
+		karmada, err = ctrl.setNaming(ctx, karmada)
+		if err != nil {
+			return err
+		}
+	}
+
 	// examine DeletionTimestamp to determine if object is under deletion
 	if karmada.DeletionTimestamp.IsZero() {
 		// The object is not being deleted, so if it does not have our finalizer,
@@ -276,30 +302,68 @@ func (ctrl *KarmadaController) syncKarmada(ctx context.Context, key string) erro
 		}
 	}
 
+	if karmada.Spec.Paused || karmada.Spec.Plan || karmada.Annotations[constants.RestoreAnnotation] != "" {
+		klog.InfoS("Karmada is paused", "karmada", klog.KObj(karmada), "plan", karmada.Spec.Plan, "restore", karmada.Annotations[constants.RestoreAnnotation])
+		old := karmada.DeepCopy()
+		planErr := ctrl.pause(karmada)
+		if err := ctrl.updateStatus(ctx, old, karmada); err != nil {
+			return err
+		}
+		return planErr
+	}
+
 	klog.InfoS("Syncing karmada", "karmada", klog.KObj(karmada))
 
-	if err := ctrl.genCerts(karmada, nil); err != nil {
-		klog.ErrorS(err, "Failed to generate certs", "namespace", namespace)
-		return err
+	old := karmada.DeepCopy()
+	resume(karmada)
+	ready, syncErr := ctrl.reconcilePhases(karmada)
+	if err := ctrl.updateStatus(ctx, old, karmada); err != nil {
+		klog.ErrorS(err, "Failed to update karmada status", "karmada", klog.KObj(karmada))
+		if syncErr == nil {
+			syncErr = err
+		}
 	}
-
-	if err := ctrl.EnsureEtcd(karmada); err != nil {
-		return err
+	if syncErr != nil {
+		return syncErr
 	}
 
-	if err := ctrl.EnsureAPIServer(karmada); err != nil {
-		return err
+	// Some components are still starting, check them again later.
+	if !ready {
+		ctrl.queue.AddAfter(key, notReadyRequeueInterval)
+		return nil
+	}
+	// Check the certificates again when the first of them must be renewed.
+	if renewal, ok := nextCertificateRenewal(karmada); ok {
+		ctrl.queue.AddAfter(key, renewal)
 	}
+	return nil
+}
 
-	if err := ctrl.EnsureControllerManager(karmada); err != nil {
-		return err
+// setNaming records on the karmada how its objects are named. A karmada which was installed before the
+// names were prefixed with the name of the karmada owns the certificate secret with the legacy name, it keeps
+// the legacy names so that its etcd, its volumes and its certificates are adopted instead of created again.
+func (ctrl *KarmadaController) setNaming(ctx context.Context, karmada *installv1alpha1.Karmada) (*installv1alpha1.Karmada, error) {
+	naming := constants.NamingInstance
+	secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(ctx, "karmada-cert", metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
 	}
-	if err := ctrl.EnsureScheduler(karmada); err != nil {
-		return err
+	if err == nil {
+		for _, ref := range secret.OwnerReferences {
+			if ref.UID == karmada.UID {
+				naming = constants.NamingLegacy
+				break
+			}
+		}
 	}
-	return nil
+
+	klog.InfoS("Setting the naming of the karmada objects", "karmada", klog.KObj(karmada), "naming", naming)
+	metav1.SetMetaDataAnnotation(&karmada.ObjectMeta, constants.NamingAnnotation, naming)
+	return ctrl.fireflyClient.InstallV1alpha1().Karmadas(karmada.Namespace).Update(ctx, karmada, metav1.UpdateOptions{})
 }
 
+// EnsureAPIServer ensures the karmada-apiserver is ready and the karmada system namespace exists in it.
+// If the karmada-apiserver is exposed, the external kubeconfig is also ensured.
 func (ctrl *KarmadaController) EnsureAPIServer(karmada *installv1alpha1.Karmada) error {
 	kubeClient, err := ctrl.EnsureKubeAPIServer(karmada)
 	if err != nil {
@@ -308,22 +372,11 @@ func (ctrl *KarmadaController) EnsureAPIServer(karmada *installv1alpha1.Karmada)
 
 	klog.InfoS("karmada-apiserver is ready", "karmada", klog.KObj(karmada))
 
-	_, err = kubeClient.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "karmada-system"}}, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return err
-	}
-
-	if err := ctrl.EnsureKarmadaAggregatedAPIServer(karmada); err != nil {
-		return err
-	}
-
-	if err := ctrl.EnsureKarmadaCRDs(karmada); err != nil {
-		return err
-	}
-	if err := ctrl.EnsureKaramdaWebhook(karmada); err != nil {
+	err = clientutil.ApplyNamespace(kubeClient, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.KarmadaSystemNamespace}})
+	if err != nil {
 		return err
 	}
-	return nil
+	return ctrl.EnsureKubeAPIServerExternalKubeconfig(karmada)
 }
 
 func (ctrl *KarmadaController) EnsureControllerManager(karmada *installv1alpha1.Karmada) error {
@@ -351,7 +404,14 @@ func (ctrl *KarmadaController) EnsureScheduler(karmada *installv1alpha1.Karmada)
 }
 
 func (ctrl *KarmadaController) deleteUnableGCResources(karmada *installv1alpha1.Karmada) error {
-	bindingName := constants.FireflyComponentKarmadaManager
-	err := ctrl.client.RbacV1beta1().ClusterRoleBindings().Delete(context.Background(), bindingName, metav1.DeleteOptions{})
-	return client.IgnoreNotFound(err)
+	bindingName := generateKarmadaManagerClusterRoleBindingName(karmada)
+	err := ctrl.client.RbacV1().ClusterRoleBindings().Delete(context.Background(), bindingName, metav1.DeleteOptions{})
+	if err := client.IgnoreNotFound(err); err != nil {
+		return err
+	}
+	// Persistent volume claims created from the volume claim templates aren't owned by the statefulset.
+	if err := ctrl.RemoveEtcdDataVolumes(karmada); err != nil {
+		return err
+	}
+	return ctrl.RemoveAuditLogVolume(karmada)
 }
@@ -38,7 +38,7 @@ func (ctrl *KarmadaController) EnsureKarmadaControllerManager(karmada *installv1
 }
 
 func (ctrl *KarmadaController) EnsureKarmadaControllerManagerDeployment(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentControllerManager
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentControllerManager)
 	kcm := karmada.Spec.ControllerManager.KarmadaControllerManager
 
 	repository := karmada.Spec.ImageRepository
@@ -99,8 +99,8 @@ func (ctrl *KarmadaController) EnsureKarmadaControllerManagerDeployment(karmada
 					Containers: []corev1.Container{
 						{
 							Name:            "karmada-controller-manager",
-							Image:           util.ComponentImageName(repository, imageName, tag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           util.ComponentImage(karmada.Spec.ImageOverrides, constants.KarmadaComponentControllerManager, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(kcm.ImagePullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
 							Command:         []string{"/bin/karmada-controller-manager"},
 							Args:            args,
 							Resources:       kcm.Resources,
@@ -118,7 +118,7 @@ func (ctrl *KarmadaController) EnsureKarmadaControllerManagerDeployment(karmada
 							Name: "kubeconfig",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "karmada-kubeconfig",
+									SecretName: GenerateKubeConfigSecretName(karmada),
 								},
 							},
 						},
@@ -127,6 +127,12 @@ func (ctrl *KarmadaController) EnsureKarmadaControllerManagerDeployment(karmada
 			},
 		},
 	}
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, kcm.ImagePullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, kcm.Placement, karmada.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(karmada, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.client, deployment)
+	if err := clientutil.ApplyDeployment(ctrl.client, deployment); err != nil {
+		return err
+	}
+	return ctrl.EnsurePodDisruptionBudget(karmada, deployment.Name, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas, kcm.PodDisruptionBudget)
 }
@@ -35,6 +35,14 @@ import (
 )
 
 func (ctrl *KarmadaController) EnsureKarmadaDescheduler(karmada *installv1alpha1.Karmada) error {
+	if isKarmadaDeschedulerEnabled(karmada) {
+		return ctrl.EnsureKarmadaDeschedulerDeployment(karmada)
+	}
+	return ctrl.RemoveKarmadaDescheduler(karmada)
+}
+
+// isKarmadaDeschedulerEnabled returns whether the karmada-descheduler should be deployed for the karmada.
+func isKarmadaDeschedulerEnabled(karmada *installv1alpha1.Karmada) bool {
 	var enabled bool
 	if karmada.Spec.Scheduler.KarmadaDescheduler.Enable != nil {
 		enabled = *karmada.Spec.Scheduler.KarmadaDescheduler.Enable
@@ -42,21 +50,21 @@ func (ctrl *KarmadaController) EnsureKarmadaDescheduler(karmada *installv1alpha1
 	if version.CompareKubeAwareVersionStrings("v1.1.0", karmada.Spec.KarmadaVersion) < 0 {
 		enabled = false
 	}
-
-	if enabled {
-		return ctrl.EnsureKarmadaDeschedulerDeployment(karmada)
-	}
-	return ctrl.RemoveKarmadaDescheduler(karmada)
+	return enabled
 }
 
 func (ctrl *KarmadaController) RemoveKarmadaDescheduler(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentDescheduler
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentDescheduler)
 	err := ctrl.client.AppsV1().Deployments(karmada.Namespace).Delete(context.TODO(), componentName, metav1.DeleteOptions{})
+	if err := client.IgnoreNotFound(err); err != nil {
+		return err
+	}
+	err = ctrl.client.PolicyV1().PodDisruptionBudgets(karmada.Namespace).Delete(context.TODO(), componentName, metav1.DeleteOptions{})
 	return client.IgnoreNotFound(err)
 }
 
 func (ctrl *KarmadaController) EnsureKarmadaDeschedulerDeployment(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentDescheduler
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentDescheduler)
 	scheduler := karmada.Spec.Scheduler.KarmadaDescheduler
 
 	repository := karmada.Spec.ImageRepository
@@ -104,8 +112,8 @@ func (ctrl *KarmadaController) EnsureKarmadaDeschedulerDeployment(karmada *insta
 					Containers: []corev1.Container{
 						{
 							Name:            "karmada-descheduler",
-							Image:           util.ComponentImageName(repository, imageName, tag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           util.ComponentImage(karmada.Spec.ImageOverrides, constants.KarmadaComponentDescheduler, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(scheduler.ImagePullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
 							Command:         []string{"/bin/karmada-descheduler"},
 							Args:            args,
 							Resources:       scheduler.Resources,
@@ -123,7 +131,7 @@ func (ctrl *KarmadaController) EnsureKarmadaDeschedulerDeployment(karmada *insta
 							Name: "kubeconfig",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "karmada-kubeconfig",
+									SecretName: GenerateKubeConfigSecretName(karmada),
 								},
 							},
 						},
@@ -133,6 +141,12 @@ func (ctrl *KarmadaController) EnsureKarmadaDeschedulerDeployment(karmada *insta
 		},
 	}
 
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, scheduler.ImagePullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, scheduler.Placement, karmada.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(karmada, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.client, deployment)
+	if err := clientutil.ApplyDeployment(ctrl.client, deployment); err != nil {
+		return err
+	}
+	return ctrl.EnsurePodDisruptionBudget(karmada, deployment.Name, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas, scheduler.PodDisruptionBudget)
 }
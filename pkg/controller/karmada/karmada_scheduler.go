@@ -37,7 +37,7 @@ func (ctrl *KarmadaController) EnsureKarmadaScheduler(karmada *installv1alpha1.K
 }
 
 func (ctrl *KarmadaController) EnsureKarmadaSchedulerDeployment(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentScheduler
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentScheduler)
 	scheduler := karmada.Spec.Scheduler.KarmadaScheduler
 
 	repository := karmada.Spec.ImageRepository
@@ -62,6 +62,11 @@ func (ctrl *KarmadaController) EnsureKarmadaSchedulerDeployment(karmada *install
 		"enable-scheduler-estimator": "true",
 		"v":                          "4",
 	}
+	// The estimators of the member clusters are named like a component of the karmada. Legacy names keep the
+	// default prefix of the karmada-scheduler.
+	if prefix := karmadaComponentName(karmada, constants.KarmadaComponentSchedulerEstimator); prefix != constants.KarmadaComponentSchedulerEstimator {
+		defaultArgs["scheduler-estimator-service-prefix"] = prefix
+	}
 	featureGates := karmada.Spec.FeatureGates
 	for feature, enabled := range featureGates {
 		if defaultArgs["feature-gates"] == "" {
@@ -95,8 +100,8 @@ func (ctrl *KarmadaController) EnsureKarmadaSchedulerDeployment(karmada *install
 					Containers: []corev1.Container{
 						{
 							Name:            "karmada-scheduler",
-							Image:           util.ComponentImageName(repository, imageName, tag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           util.ComponentImage(karmada.Spec.ImageOverrides, constants.KarmadaComponentScheduler, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(scheduler.ImagePullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
 							Command:         []string{"/bin/karmada-scheduler"},
 							Args:            args,
 							Resources:       scheduler.Resources,
@@ -114,7 +119,7 @@ func (ctrl *KarmadaController) EnsureKarmadaSchedulerDeployment(karmada *install
 							Name: "kubeconfig",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "karmada-kubeconfig",
+									SecretName: GenerateKubeConfigSecretName(karmada),
 								},
 							},
 						},
@@ -124,6 +129,12 @@ func (ctrl *KarmadaController) EnsureKarmadaSchedulerDeployment(karmada *install
 		},
 	}
 
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, scheduler.ImagePullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, scheduler.Placement, karmada.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(karmada, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.client, deployment)
+	if err := clientutil.ApplyDeployment(ctrl.client, deployment); err != nil {
+		return err
+	}
+	return ctrl.EnsurePodDisruptionBudget(karmada, deployment.Name, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas, scheduler.PodDisruptionBudget)
 }
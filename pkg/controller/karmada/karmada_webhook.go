@@ -26,7 +26,6 @@ import (
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
@@ -56,7 +55,7 @@ func (ctrl *KarmadaController) EnsureKaramdaWebhook(karmada *installv1alpha1.Kar
 }
 
 func (ctrl *KarmadaController) EnsureKaramdaWebhookService(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentWebhook
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentWebhook)
 	svc := &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
@@ -82,11 +81,11 @@ func (ctrl *KarmadaController) EnsureKaramdaWebhookService(karmada *installv1alp
 		},
 	}
 	controllerutil.SetOwnerReference(karmada, svc, scheme.Scheme)
-	return clientutil.CreateOrUpdateService(ctrl.client, svc)
+	return clientutil.ApplyService(ctrl.client, svc)
 }
 
 func (ctrl *KarmadaController) EnsureKaramdaWebhookDeployment(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentWebhook
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentWebhook)
 	webhook := karmada.Spec.Webhook.KarmadaWebhook
 
 	repository := karmada.Spec.ImageRepository
@@ -136,8 +135,8 @@ func (ctrl *KarmadaController) EnsureKaramdaWebhookDeployment(karmada *installv1
 					Containers: []corev1.Container{
 						{
 							Name:            "karmada-webhook",
-							Image:           util.ComponentImageName(repository, imageName, tag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           util.ComponentImage(karmada.Spec.ImageOverrides, constants.KarmadaComponentWebhook, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(webhook.ImagePullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
 							Command:         []string{"/bin/karmada-webhook"},
 							Args:            args,
 							Ports: []corev1.ContainerPort{
@@ -165,7 +164,7 @@ func (ctrl *KarmadaController) EnsureKaramdaWebhookDeployment(karmada *installv1
 							Name: "kubeconfig",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "karmada-kubeconfig",
+									SecretName: GenerateKubeConfigSecretName(karmada),
 								},
 							},
 						},
@@ -173,7 +172,7 @@ func (ctrl *KarmadaController) EnsureKaramdaWebhookDeployment(karmada *installv1
 							Name: "cert",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: fmt.Sprintf("%s-cert", componentName),
+									SecretName: generateComponentCertSecretName(karmada, constants.KarmadaComponentWebhook),
 								},
 							},
 						},
@@ -182,8 +181,14 @@ func (ctrl *KarmadaController) EnsureKaramdaWebhookDeployment(karmada *installv1
 			},
 		},
 	}
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, webhook.ImagePullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, webhook.Placement, karmada.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(karmada, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.client, deployment)
+	if err := clientutil.ApplyDeployment(ctrl.client, deployment); err != nil {
+		return err
+	}
+	return ctrl.EnsurePodDisruptionBudget(karmada, deployment.Name, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas, webhook.PodDisruptionBudget)
 }
 
 func (ctrl *KarmadaController) EnsureKarmadaWebhookConfiguration(karmada *installv1alpha1.Karmada) error {
@@ -196,7 +201,7 @@ func (ctrl *KarmadaController) EnsureKarmadaWebhookConfiguration(karmada *instal
 		return err
 	}
 
-	karmadaCert, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), "karmada-cert", metav1.GetOptions{})
+	karmadaCert, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), generateCertSecretName(karmada), metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
@@ -275,7 +280,7 @@ webhooks:
     failurePolicy: Fail
     sideEffects: None
     admissionReviewVersions: ["v1"]
-    timeoutSeconds: 3`, karmada.Namespace, caBundle, constants.KarmadaComponentWebhook)
+    timeoutSeconds: 3`, karmada.Namespace, caBundle, karmadaComponentName(karmada, constants.KarmadaComponentWebhook))
 }
 
 func validatingConfig(caBundle string, karmada *installv1alpha1.Karmada) string {
@@ -355,7 +360,7 @@ webhooks:
     failurePolicy: Fail
     sideEffects: None
     admissionReviewVersions: ["v1"]
-    timeoutSeconds: 3`, karmada.Namespace, caBundle, constants.KarmadaComponentWebhook)
+    timeoutSeconds: 3`, karmada.Namespace, caBundle, karmadaComponentName(karmada, constants.KarmadaComponentWebhook))
 }
 
 func createValidatingWebhookConfiguration(c kubernetes.Interface, staticYaml string) error {
@@ -365,26 +370,7 @@ func createValidatingWebhookConfiguration(c kubernetes.Interface, staticYaml str
 		klog.Errorln("Error convert json byte to admissionregistration v1 ValidatingWebhookConfiguration struct.")
 		return err
 	}
-
-	_, err := c.AdmissionregistrationV1().ValidatingWebhookConfigurations().Create(context.TODO(), &obj, metav1.CreateOptions{})
-	if err != nil {
-		if errors.IsAlreadyExists(err) {
-			latest, err := c.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.TODO(), obj.Name, metav1.GetOptions{})
-			if err != nil {
-				klog.Errorln("Error get validating webhook configuration.")
-				return err
-			}
-			obj.ResourceVersion = latest.ResourceVersion
-			_, err = c.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(context.TODO(), &obj, metav1.UpdateOptions{})
-			if err != nil {
-				klog.Errorln("Error update validating webhook configuration.")
-				return err
-			}
-			return nil
-		}
-		return err
-	}
-	return nil
+	return clientutil.ApplyValidatingWebhookConfiguration(c, &obj)
 }
 
 func createMutatingWebhookConfiguration(c kubernetes.Interface, staticYaml string) error {
@@ -394,24 +380,7 @@ func createMutatingWebhookConfiguration(c kubernetes.Interface, staticYaml strin
 		klog.Errorln("Error convert json byte to admissionregistration v1 MutatingWebhookConfiguration struct.")
 		return err
 	}
-
-	_, err := c.AdmissionregistrationV1().MutatingWebhookConfigurations().Create(context.TODO(), &obj, metav1.CreateOptions{})
-	if err != nil {
-		if errors.IsAlreadyExists(err) {
-			latest, err := c.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), obj.Name, metav1.GetOptions{})
-			if err != nil {
-				return err
-			}
-			obj.ResourceVersion = latest.ResourceVersion
-			_, err = c.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(context.TODO(), &obj, metav1.UpdateOptions{})
-			if err != nil {
-				return err
-			}
-			return nil
-		}
-		return err
-	}
-	return nil
+	return clientutil.ApplyMutatingWebhookConfiguration(c, &obj)
 }
 
 // StaticYamlToJSONByte  Static yaml file conversion JSON Byte
@@ -17,7 +17,11 @@ limitations under the License.
 package karmada
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -25,6 +29,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
@@ -35,6 +42,11 @@ import (
 	maputil "github.com/carlory/firefly/pkg/util/map"
 )
 
+const (
+	// kubeAPIServerSecurePort is the port which the kube-apiserver component serves on.
+	kubeAPIServerSecurePort = 5443
+)
+
 // EnsureKubeAPIServer ensures the kube-apiserver components exists and returns a kubeclient if it's ready.
 func (ctrl *KarmadaController) EnsureKubeAPIServer(karmada *installv1alpha1.Karmada) (kubernetes.Interface, error) {
 	if err := ctrl.EnsureKubeAPIServerService(karmada); err != nil {
@@ -61,18 +73,20 @@ func (ctrl *KarmadaController) EnsureKubeAPIServer(karmada *installv1alpha1.Karm
 
 // EnsureKubeAPIServerService ensures the kube-apiserver service exists.
 func (ctrl *KarmadaController) EnsureKubeAPIServerService(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentKubeAPIServer
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentKubeAPIServer)
 	svc := &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
 			Kind:       "Service",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      componentName,
-			Namespace: karmada.Namespace,
+			Name:        componentName,
+			Namespace:   karmada.Namespace,
+			Labels:      karmadaComponentLabels(karmada, constants.KarmadaComponentKubeAPIServer),
+			Annotations: karmada.Spec.APIServer.KubeAPIServer.ServiceAnnotations,
 		},
 		Spec: corev1.ServiceSpec{
-			Type:     corev1.ServiceTypeClusterIP,
+			Type:     kubeAPIServerServiceType(karmada),
 			Selector: map[string]string{"app": componentName},
 			Ports: []corev1.ServicePort{
 				{
@@ -88,12 +102,177 @@ func (ctrl *KarmadaController) EnsureKubeAPIServerService(karmada *installv1alph
 		},
 	}
 	controllerutil.SetOwnerReference(karmada, svc, scheme.Scheme)
-	return clientutil.CreateOrUpdateService(ctrl.client, svc)
+	return clientutil.ApplyService(ctrl.client, svc)
+}
+
+// kubeAPIServerServiceType returns the type of the kube-apiserver service.
+func kubeAPIServerServiceType(karmada *installv1alpha1.Karmada) corev1.ServiceType {
+	if serviceType := karmada.Spec.APIServer.KubeAPIServer.ServiceType; serviceType != "" {
+		return serviceType
+	}
+	return corev1.ServiceTypeClusterIP
+}
+
+// kubeAPIServerExternalEndpoint returns the host and port which the kube-apiserver can be reached with from
+// outside of the host cluster. An empty host is returned if the kube-apiserver is not exposed.
+func (ctrl *KarmadaController) kubeAPIServerExternalEndpoint(karmada *installv1alpha1.Karmada) (string, int32, error) {
+	if endpoint := karmada.Spec.ControlPlaneEndpoint; endpoint != "" {
+		host, portStr, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			// The endpoint is specified without a port.
+			return endpoint, kubeAPIServerSecurePort, nil
+		}
+		port, err := strconv.ParseInt(portStr, 10, 32)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid port of the control plane endpoint %q: %v", endpoint, err)
+		}
+		return host, int32(port), nil
+	}
+
+	serviceType := kubeAPIServerServiceType(karmada)
+	if serviceType == corev1.ServiceTypeClusterIP {
+		return "", 0, nil
+	}
+
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentKubeAPIServer)
+	svc, err := ctrl.client.CoreV1().Services(karmada.Namespace).Get(context.TODO(), componentName, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+
+	switch serviceType {
+	case corev1.ServiceTypeLoadBalancer:
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				return ingress.IP, kubeAPIServerSecurePort, nil
+			}
+			if ingress.Hostname != "" {
+				return ingress.Hostname, kubeAPIServerSecurePort, nil
+			}
+		}
+		return "", 0, fmt.Errorf("waiting for the load balancer of the %s service to be provisioned", componentName)
+	case corev1.ServiceTypeNodePort:
+		var nodePort int32
+		for _, port := range svc.Spec.Ports {
+			if port.Port == kubeAPIServerSecurePort {
+				nodePort = port.NodePort
+			}
+		}
+		if nodePort == 0 {
+			return "", 0, fmt.Errorf("waiting for the node port of the %s service to be allocated", componentName)
+		}
+		if addresses := karmada.Spec.APIServer.KubeAPIServer.NodeAddresses; len(addresses) > 0 {
+			return addresses[0], nodePort, nil
+		}
+		nodes, err := ctrl.client.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return "", 0, err
+		}
+		if address := firstNodeAddress(nodes.Items); address != "" {
+			return address, nodePort, nil
+		}
+		return "", 0, fmt.Errorf("no node address is found for the %s service", componentName)
+	}
+	return "", 0, fmt.Errorf("unsupported service type %q of the %s service", serviceType, componentName)
+}
+
+// firstNodeAddress returns the external, or else the internal, address of the node which sorts first by name,
+// so that the same address is used regardless of the order in which the nodes are listed.
+func firstNodeAddress(nodes []corev1.Node) string {
+	sorted := make([]corev1.Node, len(nodes))
+	copy(sorted, nodes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	for _, addressType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP} {
+		for _, node := range sorted {
+			for _, address := range node.Status.Addresses {
+				if address.Type == addressType && address.Address != "" {
+					return address.Address
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// kubeAPIServerNodeAddressDiscovered returns whether the external endpoint of the kube-apiserver is a node
+// address which is discovered from the nodes of the host cluster, as no address is given in the spec.
+func kubeAPIServerNodeAddressDiscovered(karmada *installv1alpha1.Karmada) bool {
+	return karmada.Spec.ControlPlaneEndpoint == "" && kubeAPIServerServiceType(karmada) == corev1.ServiceTypeNodePort &&
+		len(karmada.Spec.APIServer.KubeAPIServer.NodeAddresses) == 0
+}
+
+// kubeAPIServerServiceAltNames returns the alternative names of the kube-apiserver, which are the external
+// endpoint, the cluster IP of its service and the node addresses in the spec. A node address which is discovered
+// from the nodes of the host cluster isn't added, because it changes with the nodes and the certificates would
+// be issued again each time.
+func (ctrl *KarmadaController) kubeAPIServerServiceAltNames(karmada *installv1alpha1.Karmada) (certutil.AltNames, error) {
+	var altNames certutil.AltNames
+
+	if !kubeAPIServerNodeAddressDiscovered(karmada) {
+		// It fails if the service isn't exposed yet, so that the certificates aren't issued without its address.
+		host, _, err := ctrl.kubeAPIServerExternalEndpoint(karmada)
+		if err != nil {
+			return altNames, err
+		}
+		appendAltNames(&altNames, host)
+	}
+
+	svc, err := ctrl.client.CoreV1().Services(karmada.Namespace).Get(context.TODO(), karmadaComponentName(karmada, constants.KarmadaComponentKubeAPIServer), metav1.GetOptions{})
+	if err != nil {
+		return altNames, err
+	}
+	if svc.Spec.ClusterIP != corev1.ClusterIPNone {
+		appendAltNames(&altNames, svc.Spec.ClusterIP)
+	}
+	if svc.Spec.Type == corev1.ServiceTypeNodePort {
+		appendAltNames(&altNames, karmada.Spec.APIServer.KubeAPIServer.NodeAddresses...)
+	}
+	return altNames, nil
+}
+
+// EnsureKubeAPIServerExternalKubeconfig ensures the kubeconfig which can be used from outside of the host
+// cluster exists if the kube-apiserver is exposed, and removes it otherwise.
+func (ctrl *KarmadaController) EnsureKubeAPIServerExternalKubeconfig(karmada *installv1alpha1.Karmada) error {
+	host, port, err := ctrl.kubeAPIServerExternalEndpoint(karmada)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Delete(context.TODO(), generateExternalKubeConfigSecretName(karmada), metav1.DeleteOptions{})
+		return client.IgnoreNotFound(err)
+	}
+
+	secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), GenerateKubeConfigSecretName(karmada), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	config, err := clientcmd.Load(secret.Data["kubeconfig"])
+	if err != nil {
+		return err
+	}
+	server := fmt.Sprintf("https://%s", net.JoinHostPort(host, strconv.Itoa(int(port))))
+	// A discovered node address isn't in the certificate, the name of the service is verified instead.
+	tlsServerName := ""
+	if kubeAPIServerNodeAddressDiscovered(karmada) {
+		tlsServerName = fmt.Sprintf("%s.%s.svc", karmadaComponentName(karmada, constants.KarmadaComponentKubeAPIServer), karmada.Namespace)
+	}
+	for _, cluster := range config.Clusters {
+		cluster.Server = server
+		cluster.TLSServerName = tlsServerName
+	}
+	configBytes, err := clientcmd.Write(*config)
+	if err != nil {
+		return fmt.Errorf("failure while serializing external kubeConfig. %v", err)
+	}
+
+	externalSecret := SecretFromSpec(karmada.Namespace, generateExternalKubeConfigSecretName(karmada), corev1.SecretTypeOpaque, map[string]string{"kubeconfig": string(configBytes)})
+	controllerutil.SetOwnerReference(karmada, externalSecret, scheme.Scheme)
+	return clientutil.ApplySecret(ctrl.client, externalSecret)
 }
 
 // EnsureKubeAPIServerDeployment ensures the kube-apiserver deployment exists.
 func (ctrl *KarmadaController) EnsureKubeAPIServerDeployment(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentKubeAPIServer
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentKubeAPIServer)
 	server := karmada.Spec.APIServer.KubeAPIServer
 
 	repository := karmada.Spec.ImageRepository
@@ -120,10 +299,7 @@ func (ctrl *KarmadaController) EnsureKubeAPIServerDeployment(karmada *installv1a
 		"client-ca-file":                     "/etc/kubernetes/pki/ca.crt",
 		"enable-admission-plugins":           "NodeRestriction",
 		"enable-bootstrap-token-auth":        "true",
-		"etcd-cafile":                        "/etc/kubernetes/pki/etcd-ca.crt",
-		"etcd-certfile":                      "/etc/kubernetes/pki/etcd-client.crt",
-		"etcd-keyfile":                       "/etc/kubernetes/pki/etcd-client.key",
-		"etcd-servers":                       fmt.Sprintf("https://%s.%s.svc:2379", constants.KarmadaComponentEtcd, karmada.Namespace),
+		"etcd-servers":                       etcdServers(karmada),
 		"bind-address":                       "0.0.0.0",
 		"insecure-port":                      "0",
 		"kubelet-client-certificate":         "/etc/kubernetes/pki/karmada.crt",
@@ -146,6 +322,11 @@ func (ctrl *KarmadaController) EnsureKubeAPIServerDeployment(karmada *installv1a
 		"tls-cert-file":                      "/etc/kubernetes/pki/apiserver.crt",
 		"tls-private-key-file":               "/etc/kubernetes/pki/apiserver.key",
 	}
+	etcdArgs, err := ctrl.etcdClientTLSArgs(karmada)
+	if err != nil {
+		return err
+	}
+	defaultArgs = maputil.MergeStringMaps(defaultArgs, etcdArgs)
 	for feature, enabled := range server.FeatureGates {
 		if defaultArgs["feature-gates"] == "" {
 			defaultArgs["feature-gates"] = fmt.Sprintf("%s=%t", feature, enabled)
@@ -153,6 +334,20 @@ func (ctrl *KarmadaController) EnsureKubeAPIServerDeployment(karmada *installv1a
 			defaultArgs["feature-gates"] = fmt.Sprintf("%s,%s=%t", defaultArgs["feature-gates"], feature, enabled)
 		}
 	}
+	audit, err := ctrl.ensureKubeAPIServerAudit(karmada)
+	if err != nil {
+		return err
+	}
+	if audit != nil {
+		defaultArgs = maputil.MergeStringMaps(defaultArgs, audit.args)
+	}
+	authn, err := ctrl.kubeAPIServerAuthenticationSettings(karmada)
+	if err != nil {
+		return err
+	}
+	if authn != nil {
+		defaultArgs = maputil.MergeStringMaps(defaultArgs, authn.args)
+	}
 	computedArgs := maputil.MergeStringMaps(defaultArgs, server.ExtraArgs)
 	args := maputil.ConvertToCommandOrArgs(computedArgs)
 
@@ -178,8 +373,8 @@ func (ctrl *KarmadaController) EnsureKubeAPIServerDeployment(karmada *installv1a
 					Containers: []corev1.Container{
 						{
 							Name:            "karmada-apiserver",
-							Image:           util.ComponentImageName(repository, imageName, tag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           util.ComponentImage(karmada.Spec.ImageOverrides, constants.KarmadaComponentKubeAPIServer, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(server.ImagePullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
 							Command:         []string{"kube-apiserver"},
 							Args:            args,
 							Resources:       server.Resources,
@@ -230,7 +425,7 @@ func (ctrl *KarmadaController) EnsureKubeAPIServerDeployment(karmada *installv1a
 							Name: "k8s-certs",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "karmada-cert",
+									SecretName: generateCertSecretName(karmada),
 								},
 							},
 						},
@@ -239,6 +434,14 @@ func (ctrl *KarmadaController) EnsureKubeAPIServerDeployment(karmada *installv1a
 			},
 		},
 	}
+	setKubeAPIServerAudit(&deployment.Spec.Template, audit)
+	setKubeAPIServerAuthentication(&deployment.Spec.Template, authn)
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, server.ImagePullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, server.Placement, karmada.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(karmada, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.client, deployment)
+	if err := clientutil.ApplyDeployment(ctrl.client, deployment); err != nil {
+		return err
+	}
+	return ctrl.EnsurePodDisruptionBudget(karmada, deployment.Name, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas, server.PodDisruptionBudget)
 }
@@ -0,0 +1,328 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"path/filepath"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	"github.com/carlory/firefly/pkg/scheme"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+)
+
+const (
+	auditPolicyVolumeName  = "audit-policy"
+	auditPolicyDir         = "/etc/kubernetes/audit"
+	auditPolicyKey         = "policy.yaml"
+	auditLogVolumeName     = "audit-logs"
+	auditLogDir            = "/var/log/kubernetes/audit"
+	auditWebhookVolumeName = "audit-webhook"
+	auditWebhookDir        = "/etc/kubernetes/audit-webhook"
+	auditWebhookKey        = "kubeconfig"
+)
+
+// defaultAuditPolicy is the audit policy of the kube-apiserver component if the audit doesn't set one.
+// The changes of the objects which decide where and how the resources are propagated to the member
+// clusters, and who is allowed to change them, are recorded with their bodies. The other changes are
+// only recorded with their metadata, so that the secrets of the member clusters don't leak into the
+// audit logs, and the read-only requests and the frequent updates of the controllers are left out.
+const defaultAuditPolicy = `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+  - RequestReceived
+rules:
+  - level: None
+    nonResourceURLs:
+      - /healthz*
+      - /livez*
+      - /readyz*
+      - /metrics
+      - /version
+  - level: None
+    resources:
+      - group: coordination.k8s.io
+        resources: ["leases"]
+      - group: ""
+        resources: ["events"]
+      - group: events.k8s.io
+        resources: ["events"]
+  - level: None
+    verbs: ["get", "list", "watch"]
+  - level: Metadata
+    resources:
+      - group: ""
+        resources: ["secrets", "configmaps", "serviceaccounts/token"]
+      - group: authentication.k8s.io
+        resources: ["tokenreviews"]
+  - level: RequestResponse
+    resources:
+      - group: policy.karmada.io
+      - group: config.karmada.io
+      - group: cluster.karmada.io
+        resources: ["clusters"]
+      - group: rbac.authorization.k8s.io
+  - level: Metadata
+`
+
+// kubeAPIServerAudit holds the settings of the kube-apiserver component which enable its audit logging.
+type kubeAPIServerAudit struct {
+	args         map[string]string
+	env          []corev1.EnvVar
+	volumes      []corev1.Volume
+	volumeMounts []corev1.VolumeMount
+	// hash is the hash of the audit policy and the webhook kubeconfig, which are only read when
+	// the kube-apiserver starts, so that the pods are restarted when they are changed.
+	hash string
+}
+
+// ensureKubeAPIServerAudit ensures the audit policy and the log volume of the kube-apiserver component
+// exist, and returns the settings which enable its audit logging. Nil is returned if no audit backend is set.
+func (ctrl *KarmadaController) ensureKubeAPIServerAudit(karmada *installv1alpha1.Karmada) (*kubeAPIServerAudit, error) {
+	audit := karmada.Spec.APIServer.KubeAPIServer.Audit
+	if audit == nil || (audit.Log == nil && audit.Webhook == nil) {
+		return nil, nil
+	}
+
+	hasher := sha256.New()
+	policyVolume, err := ctrl.ensureAuditPolicy(karmada, audit.Policy, hasher)
+	if err != nil {
+		return nil, err
+	}
+	settings := &kubeAPIServerAudit{
+		args: map[string]string{
+			"audit-policy-file": filepath.Join(auditPolicyDir, auditPolicyKey),
+		},
+		volumes: []corev1.Volume{policyVolume},
+		volumeMounts: []corev1.VolumeMount{
+			{Name: auditPolicyVolumeName, MountPath: auditPolicyDir, ReadOnly: true},
+		},
+	}
+
+	if log := audit.Log; log != nil {
+		logVolume, err := ctrl.ensureAuditLogVolume(karmada, log)
+		if err != nil {
+			return nil, err
+		}
+		// The pods may share the volume, so each of them writes to a file named after itself.
+		settings.args["audit-log-path"] = filepath.Join(auditLogDir, "$(POD_NAME).log")
+		if log.MaxAge != nil {
+			settings.args["audit-log-maxage"] = strconv.Itoa(int(*log.MaxAge))
+		}
+		if log.MaxBackups != nil {
+			settings.args["audit-log-maxbackup"] = strconv.Itoa(int(*log.MaxBackups))
+		}
+		if log.MaxSize != nil {
+			settings.args["audit-log-maxsize"] = strconv.Itoa(int(*log.MaxSize))
+		}
+		if log.Format != "" {
+			settings.args["audit-log-format"] = log.Format
+		}
+		settings.env = append(settings.env, corev1.EnvVar{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+			},
+		})
+		settings.volumes = append(settings.volumes, logVolume)
+		settings.volumeMounts = append(settings.volumeMounts, corev1.VolumeMount{Name: auditLogVolumeName, MountPath: auditLogDir})
+	}
+
+	if webhook := audit.Webhook; webhook != nil {
+		secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), webhook.ConfigSecretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the kubeconfig of the audit webhook: %v", err)
+		}
+		kubeconfig, ok := secret.Data[auditWebhookKey]
+		if !ok {
+			return nil, fmt.Errorf("the secret %q of the audit webhook has no %s key", secret.Name, auditWebhookKey)
+		}
+		hasher.Write(kubeconfig)
+
+		settings.args["audit-webhook-config-file"] = filepath.Join(auditWebhookDir, auditWebhookKey)
+		// The bodies of the karmada policies are recorded, truncate the events and the batches which exceed
+		// the limits of the webhook instead of dropping them.
+		settings.args["audit-webhook-truncate-enabled"] = "true"
+		if webhook.Mode != "" {
+			settings.args["audit-webhook-mode"] = webhook.Mode
+		}
+		if webhook.InitialBackoff != nil {
+			settings.args["audit-webhook-initial-backoff"] = webhook.InitialBackoff.Duration.String()
+		}
+		settings.volumes = append(settings.volumes, corev1.Volume{
+			Name: auditWebhookVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+					Items:      []corev1.KeyToPath{{Key: auditWebhookKey, Path: auditWebhookKey}},
+				},
+			},
+		})
+		settings.volumeMounts = append(settings.volumeMounts, corev1.VolumeMount{Name: auditWebhookVolumeName, MountPath: auditWebhookDir, ReadOnly: true})
+	}
+
+	settings.hash = hex.EncodeToString(hasher.Sum(nil))[:16]
+	return settings, nil
+}
+
+// ensureAuditPolicy ensures the configmap of the audit policy exists and returns the volume it's mounted with.
+// The policy is written to a configmap owned by the karmada unless it's referenced from a configmap of the user.
+func (ctrl *KarmadaController) ensureAuditPolicy(karmada *installv1alpha1.Karmada, policy *installv1alpha1.AuditPolicy, hasher hash.Hash) (corev1.Volume, error) {
+	volume := corev1.Volume{Name: auditPolicyVolumeName}
+
+	if policy != nil && policy.ConfigMapRef != nil {
+		ref := policy.ConfigMapRef
+		cm, err := ctrl.client.CoreV1().ConfigMaps(karmada.Namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return volume, fmt.Errorf("failed to get the audit policy: %v", err)
+		}
+		data, ok := cm.Data[ref.Key]
+		if !ok {
+			return volume, fmt.Errorf("the configmap %q of the audit policy has no %s key", ref.Name, ref.Key)
+		}
+		if err := validateAuditPolicy(data); err != nil {
+			return volume, err
+		}
+		hasher.Write([]byte(data))
+		volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name},
+			Items:                []corev1.KeyToPath{{Key: ref.Key, Path: auditPolicyKey}},
+		}
+		return volume, nil
+	}
+
+	data := defaultAuditPolicy
+	if policy != nil && policy.Inline != "" {
+		data = policy.Inline
+	}
+	if err := validateAuditPolicy(data); err != nil {
+		return volume, err
+	}
+	hasher.Write([]byte(data))
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generateAuditPolicyConfigMapName(karmada),
+			Namespace: karmada.Namespace,
+		},
+		Data: map[string]string{auditPolicyKey: data},
+	}
+	controllerutil.SetOwnerReference(karmada, cm, scheme.Scheme)
+	if err := clientutil.ApplyConfigMap(ctrl.client, cm); err != nil {
+		return volume, err
+	}
+	volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{Name: cm.Name},
+	}
+	return volume, nil
+}
+
+// validateAuditPolicy checks the audit policy is a valid audit.k8s.io/v1 Policy, so that a mistake in it is
+// reported on the karmada instead of crashing the kube-apiserver.
+func validateAuditPolicy(data string) error {
+	policy := &auditv1.Policy{}
+	if err := yaml.UnmarshalStrict([]byte(data), policy); err != nil {
+		return fmt.Errorf("invalid audit policy: %v", err)
+	}
+	if policy.APIVersion != auditv1.SchemeGroupVersion.String() || policy.Kind != "Policy" {
+		return fmt.Errorf("invalid audit policy: expected %s Policy, got %s %s", auditv1.SchemeGroupVersion, policy.APIVersion, policy.Kind)
+	}
+	if len(policy.Rules) == 0 {
+		return fmt.Errorf("invalid audit policy: no rules are defined")
+	}
+	return nil
+}
+
+// ensureAuditLogVolume ensures the persistent volume claim of the audit logs exists if the log backend has a
+// volume, and returns the volume the audit logs are written to. The claim isn't owned by the karmada, it's
+// removed by RemoveAuditLogVolume according to its deletion policy.
+func (ctrl *KarmadaController) ensureAuditLogVolume(karmada *installv1alpha1.Karmada, log *installv1alpha1.AuditLogBackend) (corev1.Volume, error) {
+	if log.Volume == nil {
+		return corev1.Volume{
+			Name: auditLogVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}, nil
+	}
+
+	name := generateAuditLogVolumeName(karmada)
+	_, err := ctrl.client.CoreV1().PersistentVolumeClaims(karmada.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		// The spec of a claim is immutable, so it's only created from the template.
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: *log.Volume.ObjectMeta.DeepCopy(),
+			Spec:       *log.Volume.Spec.DeepCopy(),
+		}
+		pvc.Name = name
+		pvc.Namespace = karmada.Namespace
+		_, err = ctrl.client.CoreV1().PersistentVolumeClaims(karmada.Namespace).Create(context.TODO(), pvc, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return corev1.Volume{}, err
+	}
+	return corev1.Volume{
+		Name: auditLogVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+		},
+	}, nil
+}
+
+// RemoveAuditLogVolume deletes the persistent volume claim of the audit logs if the deletion policy of
+// the volume is Delete.
+func (ctrl *KarmadaController) RemoveAuditLogVolume(karmada *installv1alpha1.Karmada) error {
+	audit := karmada.Spec.APIServer.KubeAPIServer.Audit
+	if audit == nil || audit.Log == nil || audit.Log.Volume == nil || audit.Log.VolumeDeletionPolicy != installv1alpha1.DataVolumeDeletionPolicyDelete {
+		return nil
+	}
+	err := ctrl.client.CoreV1().PersistentVolumeClaims(karmada.Namespace).Delete(context.TODO(), generateAuditLogVolumeName(karmada), metav1.DeleteOptions{})
+	return client.IgnoreNotFound(err)
+}
+
+// setKubeAPIServerAudit applies the audit settings to the pod template of the kube-apiserver component.
+func setKubeAPIServerAudit(template *corev1.PodTemplateSpec, audit *kubeAPIServerAudit) {
+	if audit == nil {
+		return
+	}
+	container := &template.Spec.Containers[0]
+	container.Env = append(container.Env, audit.env...)
+	container.VolumeMounts = append(container.VolumeMounts, audit.volumeMounts...)
+	template.Spec.Volumes = append(template.Spec.Volumes, audit.volumes...)
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[constants.AuditConfigHashAnnotation] = audit.hash
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import "testing"
+
+func TestValidateAuditPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			policy: `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+- RequestReceived
+rules:
+- level: None
+  users: ["system:kube-proxy"]
+- level: Metadata
+`,
+		},
+		{
+			name:    "invalid yaml",
+			policy:  "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules: [",
+			wantErr: true,
+		},
+		{
+			name: "unknown field",
+			policy: `apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: Metadata
+  user: alice
+`,
+			wantErr: true,
+		},
+		{
+			name: "v1beta1 policy",
+			policy: `apiVersion: audit.k8s.io/v1beta1
+kind: Policy
+rules:
+- level: Metadata
+`,
+			wantErr: true,
+		},
+		{
+			name: "wrong kind",
+			policy: `apiVersion: audit.k8s.io/v1
+kind: Event
+rules:
+- level: Metadata
+`,
+			wantErr: true,
+		},
+		{
+			name: "no rules",
+			policy: `apiVersion: audit.k8s.io/v1
+kind: Policy
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateAuditPolicy(tt.policy); (err != nil) != tt.wantErr {
+				t.Errorf("validateAuditPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
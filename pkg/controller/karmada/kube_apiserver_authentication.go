@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+)
+
+const (
+	oidcCAVolumeName                = "oidc-ca"
+	oidcCADir                       = "/etc/kubernetes/oidc"
+	oidcCAKey                       = "ca.crt"
+	authenticationWebhookVolumeName = "authentication-webhook"
+	authenticationWebhookDir        = "/etc/kubernetes/authentication-webhook"
+	authenticationWebhookKey        = "kubeconfig"
+)
+
+// kubeAPIServerAuthentication holds the settings of the kube-apiserver component which enable its token
+// authenticators.
+type kubeAPIServerAuthentication struct {
+	args map[string]string
+	// repeatedArgs are the flags which are repeated instead of taking a list of values.
+	repeatedArgs []string
+	volumes      []corev1.Volume
+	volumeMounts []corev1.VolumeMount
+	// hash is the hash of the CA of the OIDC provider and the webhook kubeconfig, which are only read
+	// when the kube-apiserver starts, so that the pods are restarted when they are changed.
+	hash string
+}
+
+// kubeAPIServerAuthenticationSettings returns the settings which enable the token authenticators of the
+// kube-apiserver component. Nil is returned if no authenticator is set.
+func (ctrl *KarmadaController) kubeAPIServerAuthenticationSettings(karmada *installv1alpha1.Karmada) (*kubeAPIServerAuthentication, error) {
+	authn := karmada.Spec.APIServer.KubeAPIServer.Authentication
+	if authn == nil || (authn.OIDC == nil && authn.Webhook == nil) {
+		return nil, nil
+	}
+
+	hasher := sha256.New()
+	settings := &kubeAPIServerAuthentication{args: map[string]string{}}
+
+	if oidc := authn.OIDC; oidc != nil {
+		if !strings.HasPrefix(oidc.IssuerURL, "https://") {
+			return nil, fmt.Errorf("the issuer URL %q of the OIDC provider must use the https scheme", oidc.IssuerURL)
+		}
+		settings.args["oidc-issuer-url"] = oidc.IssuerURL
+		settings.args["oidc-client-id"] = oidc.ClientID
+		if oidc.UsernameClaim != "" {
+			settings.args["oidc-username-claim"] = oidc.UsernameClaim
+		}
+		if oidc.UsernamePrefix != "" {
+			settings.args["oidc-username-prefix"] = oidc.UsernamePrefix
+		}
+		if oidc.GroupsClaim != "" {
+			settings.args["oidc-groups-claim"] = oidc.GroupsClaim
+		}
+		if oidc.GroupsPrefix != "" {
+			settings.args["oidc-groups-prefix"] = oidc.GroupsPrefix
+		}
+		if len(oidc.SigningAlgs) > 0 {
+			settings.args["oidc-signing-algs"] = strings.Join(oidc.SigningAlgs, ",")
+		}
+		// The value of a required claim may contain commas, so the flag is repeated for each claim.
+		claims := make([]string, 0, len(oidc.RequiredClaims))
+		for claim := range oidc.RequiredClaims {
+			claims = append(claims, claim)
+		}
+		sort.Strings(claims)
+		for _, claim := range claims {
+			settings.repeatedArgs = append(settings.repeatedArgs, fmt.Sprintf("--oidc-required-claim=%s=%s", claim, oidc.RequiredClaims[claim]))
+		}
+
+		if ref := oidc.CASecretRef; ref != nil {
+			secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the CA of the OIDC provider: %v", err)
+			}
+			ca, ok := secret.Data[ref.Key]
+			if !ok {
+				return nil, fmt.Errorf("the secret %q of the CA of the OIDC provider has no %s key", ref.Name, ref.Key)
+			}
+			hasher.Write(ca)
+
+			settings.args["oidc-ca-file"] = filepath.Join(oidcCADir, oidcCAKey)
+			settings.volumes = append(settings.volumes, corev1.Volume{
+				Name: oidcCAVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: ref.Name,
+						Items:      []corev1.KeyToPath{{Key: ref.Key, Path: oidcCAKey}},
+					},
+				},
+			})
+			settings.volumeMounts = append(settings.volumeMounts, corev1.VolumeMount{Name: oidcCAVolumeName, MountPath: oidcCADir, ReadOnly: true})
+		}
+	}
+
+	if webhook := authn.Webhook; webhook != nil {
+		secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), webhook.ConfigSecretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the kubeconfig of the authentication webhook: %v", err)
+		}
+		kubeconfig, ok := secret.Data[authenticationWebhookKey]
+		if !ok {
+			return nil, fmt.Errorf("the secret %q of the authentication webhook has no %s key", secret.Name, authenticationWebhookKey)
+		}
+		hasher.Write(kubeconfig)
+
+		settings.args["authentication-token-webhook-config-file"] = filepath.Join(authenticationWebhookDir, authenticationWebhookKey)
+		if webhook.CacheTTL != nil {
+			settings.args["authentication-token-webhook-cache-ttl"] = webhook.CacheTTL.Duration.String()
+		}
+		if webhook.Version != "" {
+			settings.args["authentication-token-webhook-version"] = webhook.Version
+		}
+		settings.volumes = append(settings.volumes, corev1.Volume{
+			Name: authenticationWebhookVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+					Items:      []corev1.KeyToPath{{Key: authenticationWebhookKey, Path: authenticationWebhookKey}},
+				},
+			},
+		})
+		settings.volumeMounts = append(settings.volumeMounts, corev1.VolumeMount{Name: authenticationWebhookVolumeName, MountPath: authenticationWebhookDir, ReadOnly: true})
+	}
+
+	settings.hash = hex.EncodeToString(hasher.Sum(nil))[:16]
+	return settings, nil
+}
+
+// setKubeAPIServerAuthentication applies the authentication settings to the pod template of the kube-apiserver component.
+func setKubeAPIServerAuthentication(template *corev1.PodTemplateSpec, authn *kubeAPIServerAuthentication) {
+	if authn == nil {
+		return
+	}
+	container := &template.Spec.Containers[0]
+	container.Args = append(container.Args, authn.repeatedArgs...)
+	container.VolumeMounts = append(container.VolumeMounts, authn.volumeMounts...)
+	template.Spec.Volumes = append(template.Spec.Volumes, authn.volumes...)
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[constants.AuthenticationConfigHashAnnotation] = authn.hash
+}
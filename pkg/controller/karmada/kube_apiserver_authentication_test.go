@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+)
+
+// fakeSecretsClientset serves the given secrets, the other clients of the interface aren't implemented.
+type fakeSecretsClientset struct {
+	clientset.Interface
+	secrets map[string]*corev1.Secret
+}
+
+func (c *fakeSecretsClientset) CoreV1() v1core.CoreV1Interface {
+	return &fakeSecretsCoreV1{secrets: c.secrets}
+}
+
+type fakeSecretsCoreV1 struct {
+	v1core.CoreV1Interface
+	secrets map[string]*corev1.Secret
+}
+
+func (c *fakeSecretsCoreV1) Secrets(string) v1core.SecretInterface {
+	return &fakeSecrets{secrets: c.secrets}
+}
+
+type fakeSecrets struct {
+	v1core.SecretInterface
+	secrets map[string]*corev1.Secret
+}
+
+func (c *fakeSecrets) Get(_ context.Context, name string, _ metav1.GetOptions) (*corev1.Secret, error) {
+	secret, ok := c.secrets[name]
+	if !ok {
+		return nil, errors.NewNotFound(corev1.Resource("secrets"), name)
+	}
+	return secret, nil
+}
+
+func TestKubeAPIServerAuthenticationSettings(t *testing.T) {
+	secrets := map[string]*corev1.Secret{
+		"oidc-ca": {ObjectMeta: metav1.ObjectMeta{Name: "oidc-ca"}, Data: map[string][]byte{"ca.crt": []byte("ca")}},
+	}
+	tests := []struct {
+		name             string
+		oidc             *installv1alpha1.OIDCAuthentication
+		wantArgs         map[string]string
+		wantRepeatedArgs []string
+		wantVolumes      int
+		wantErr          bool
+	}{
+		{
+			name: "no authenticator",
+		},
+		{
+			name:    "issuer without https",
+			oidc:    &installv1alpha1.OIDCAuthentication{IssuerURL: "http://issuer.example.com", ClientID: "karmada"},
+			wantErr: true,
+		},
+		{
+			name: "required flags only",
+			oidc: &installv1alpha1.OIDCAuthentication{IssuerURL: "https://issuer.example.com", ClientID: "karmada"},
+			wantArgs: map[string]string{
+				"oidc-issuer-url": "https://issuer.example.com",
+				"oidc-client-id":  "karmada",
+			},
+		},
+		{
+			name: "all flags",
+			oidc: &installv1alpha1.OIDCAuthentication{
+				IssuerURL:      "https://issuer.example.com",
+				ClientID:       "karmada",
+				UsernameClaim:  "email",
+				UsernamePrefix: "oidc:",
+				GroupsClaim:    "groups",
+				GroupsPrefix:   "oidc:",
+				SigningAlgs:    []string{"RS256", "ES256"},
+				RequiredClaims: map[string]string{"tenant": "a,b", "aud": "karmada"},
+			},
+			wantArgs: map[string]string{
+				"oidc-issuer-url":      "https://issuer.example.com",
+				"oidc-client-id":       "karmada",
+				"oidc-username-claim":  "email",
+				"oidc-username-prefix": "oidc:",
+				"oidc-groups-claim":    "groups",
+				"oidc-groups-prefix":   "oidc:",
+				"oidc-signing-algs":    "RS256,ES256",
+			},
+			wantRepeatedArgs: []string{"--oidc-required-claim=aud=karmada", "--oidc-required-claim=tenant=a,b"},
+		},
+		{
+			name: "CA of the provider",
+			oidc: &installv1alpha1.OIDCAuthentication{
+				IssuerURL:   "https://issuer.example.com",
+				ClientID:    "karmada",
+				CASecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oidc-ca"}, Key: "ca.crt"},
+			},
+			wantArgs: map[string]string{
+				"oidc-issuer-url": "https://issuer.example.com",
+				"oidc-client-id":  "karmada",
+				"oidc-ca-file":    "/etc/kubernetes/oidc/ca.crt",
+			},
+			wantVolumes: 1,
+		},
+		{
+			name: "CA key missing",
+			oidc: &installv1alpha1.OIDCAuthentication{
+				IssuerURL:   "https://issuer.example.com",
+				ClientID:    "karmada",
+				CASecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oidc-ca"}, Key: "tls.crt"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &KarmadaController{client: &fakeSecretsClientset{secrets: secrets}}
+			karmada := &installv1alpha1.Karmada{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "firefly-system"}}
+			if tt.oidc != nil {
+				karmada.Spec.APIServer.KubeAPIServer.Authentication = &installv1alpha1.Authentication{OIDC: tt.oidc}
+			}
+
+			settings, err := ctrl.kubeAPIServerAuthenticationSettings(karmada)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("kubeAPIServerAuthenticationSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantArgs == nil {
+				if settings != nil {
+					t.Errorf("kubeAPIServerAuthenticationSettings() = %+v, want nil", settings)
+				}
+				return
+			}
+			if !reflect.DeepEqual(settings.args, tt.wantArgs) {
+				t.Errorf("kubeAPIServerAuthenticationSettings() args = %v, want %v", settings.args, tt.wantArgs)
+			}
+			if !reflect.DeepEqual(settings.repeatedArgs, tt.wantRepeatedArgs) {
+				t.Errorf("kubeAPIServerAuthenticationSettings() repeated args = %v, want %v", settings.repeatedArgs, tt.wantRepeatedArgs)
+			}
+			if len(settings.volumes) != tt.wantVolumes || len(settings.volumeMounts) != tt.wantVolumes {
+				t.Errorf("kubeAPIServerAuthenticationSettings() has %d volumes and %d mounts, want %d", len(settings.volumes), len(settings.volumeMounts), tt.wantVolumes)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFirstNodeAddress(t *testing.T) {
+	node := func(name string, addresses ...corev1.NodeAddress) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.NodeStatus{Addresses: addresses}}
+	}
+	tests := []struct {
+		name  string
+		nodes []corev1.Node
+		want  string
+	}{
+		{
+			name: "no nodes",
+		},
+		{
+			name: "first node by name",
+			nodes: []corev1.Node{
+				node("node-b", corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.2"}),
+				node("node-a", corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}),
+			},
+			want: "10.0.0.1",
+		},
+		{
+			name: "external address preferred",
+			nodes: []corev1.Node{
+				node("node-a", corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}),
+				node("node-b", corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.2"}, corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "192.168.0.2"}),
+			},
+			want: "192.168.0.2",
+		},
+		{
+			name: "no addresses",
+			nodes: []corev1.Node{
+				node("node-a", corev1.NodeAddress{Type: corev1.NodeHostName, Address: "node-a"}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firstNodeAddress(tt.nodes); got != tt.want {
+				t.Errorf("firstNodeAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubeAPIServerNodeAddressDiscovered(t *testing.T) {
+	tests := []struct {
+		name                 string
+		controlPlaneEndpoint string
+		serviceType          corev1.ServiceType
+		nodeAddresses        []string
+		want                 bool
+	}{
+		{
+			name:        "cluster IP",
+			serviceType: corev1.ServiceTypeClusterIP,
+		},
+		{
+			name:        "load balancer",
+			serviceType: corev1.ServiceTypeLoadBalancer,
+		},
+		{
+			name:        "node port without node addresses",
+			serviceType: corev1.ServiceTypeNodePort,
+			want:        true,
+		},
+		{
+			name:          "node port with node addresses",
+			serviceType:   corev1.ServiceTypeNodePort,
+			nodeAddresses: []string{"192.168.0.1"},
+		},
+		{
+			name:                 "node port with control plane endpoint",
+			controlPlaneEndpoint: "karmada.example.com:6443",
+			serviceType:          corev1.ServiceTypeNodePort,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			karmada := testKarmada()
+			karmada.Spec.ControlPlaneEndpoint = tt.controlPlaneEndpoint
+			karmada.Spec.APIServer.KubeAPIServer.ServiceType = tt.serviceType
+			karmada.Spec.APIServer.KubeAPIServer.NodeAddresses = tt.nodeAddresses
+			if got := kubeAPIServerNodeAddressDiscovered(karmada); got != tt.want {
+				t.Errorf("kubeAPIServerNodeAddressDiscovered() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -38,7 +38,7 @@ func (ctrl *KarmadaController) EnsureKubeControllerManager(karmada *installv1alp
 }
 
 func (ctrl *KarmadaController) EnsureKubeControllerManagerDeployment(karmada *installv1alpha1.Karmada) error {
-	componentName := constants.KarmadaComponentKubeControllerManager
+	componentName := karmadaComponentName(karmada, constants.KarmadaComponentKubeControllerManager)
 	kcm := karmada.Spec.ControllerManager.KubeControllerManager
 
 	repository := karmada.Spec.ImageRepository
@@ -79,6 +79,11 @@ func (ctrl *KarmadaController) EnsureKubeControllerManagerDeployment(karmada *in
 		"use-service-account-credentials":  "true",
 		"v":                                "4",
 	}
+	// The CA key isn't available if the certificates are issued by cert-manager.
+	if karmada.Spec.Certificates.IssuerRef != nil {
+		delete(defaultArgs, "cluster-signing-cert-file")
+		delete(defaultArgs, "cluster-signing-key-file")
+	}
 	if kcm.Controllers != nil {
 		defaultArgs["controllers"] = strings.Join(kcm.Controllers, ",")
 	}
@@ -114,8 +119,8 @@ func (ctrl *KarmadaController) EnsureKubeControllerManagerDeployment(karmada *in
 					Containers: []corev1.Container{
 						{
 							Name:            "kube-controller-manager",
-							Image:           util.ComponentImageName(repository, imageName, tag),
-							ImagePullPolicy: "IfNotPresent",
+							Image:           util.ComponentImage(karmada.Spec.ImageOverrides, constants.KarmadaComponentKubeControllerManager, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(kcm.ImagePullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
 							Command:         []string{"kube-controller-manager"},
 							Args:            args,
 							Resources:       kcm.Resources,
@@ -138,7 +143,7 @@ func (ctrl *KarmadaController) EnsureKubeControllerManagerDeployment(karmada *in
 							Name: "k8s-certs",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "karmada-cert",
+									SecretName: generateCertSecretName(karmada),
 								},
 							},
 						},
@@ -146,7 +151,7 @@ func (ctrl *KarmadaController) EnsureKubeControllerManagerDeployment(karmada *in
 							Name: "kubeconfig",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "karmada-kubeconfig",
+									SecretName: GenerateKubeConfigSecretName(karmada),
 								},
 							},
 						},
@@ -155,6 +160,12 @@ func (ctrl *KarmadaController) EnsureKubeControllerManagerDeployment(karmada *in
 			},
 		},
 	}
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, kcm.ImagePullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, kcm.Placement, karmada.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(karmada, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.client, deployment)
+	if err := clientutil.ApplyDeployment(ctrl.client, deployment); err != nil {
+		return err
+	}
+	return ctrl.EnsurePodDisruptionBudget(karmada, deployment.Name, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas, kcm.PodDisruptionBudget)
 }
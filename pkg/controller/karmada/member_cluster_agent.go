@@ -0,0 +1,532 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	workv1alpha1 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha1"
+	karmadaversioned "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientset "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	karmadautil "github.com/carlory/firefly/pkg/karmada/util"
+	"github.com/carlory/firefly/pkg/util"
+	"github.com/carlory/firefly/pkg/util/certs"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+	maputil "github.com/carlory/firefly/pkg/util/map"
+)
+
+const (
+	// agentKubeconfigSecretName is the secret in the member cluster which holds the kubeconfig the
+	// karmada-agent accesses the karmada-apiserver with.
+	agentKubeconfigSecretName = "karmada-agent-kubeconfig"
+	// agentUserPrefix is the prefix of the users which the karmada-agents access the karmada with, followed by
+	// the name of the cluster.
+	agentUserPrefix = "system:karmada:agent:"
+	// agentGroup is the group of the users of the karmada-agents.
+	agentGroup = "system:karmada:agents"
+
+	reasonKarmadaNotExposed = "KarmadaNotExposed"
+	reasonAgentNotReady     = "AgentNotReady"
+	reasonAgentReady        = "AgentReady"
+)
+
+// joinWithAgent joins the cluster in the Pull mode. The karmada-agent is deployed into the member cluster with
+// the credentials issued for it in the karmada, and the agent registers the Cluster object by itself. It
+// returns the Cluster object, or nil if it isn't registered yet.
+func (ctrl *MemberClusterController) joinWithAgent(ctx context.Context, karmada *installv1alpha1.Karmada, memberCluster *installv1alpha1.MemberCluster,
+	memberClient, karmadaClient clientset.Interface, karmadaClusterClient karmadaversioned.Interface, memberConfig *restclient.Config) (*clusterv1alpha1.Cluster, error) {
+	clusterName := memberClusterName(memberCluster)
+
+	if karmada.Spec.Certificates.IssuerRef != nil {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonCAKeyUnavailable,
+			fmt.Sprintf("The certificates of the karmada %s are issued by cert-manager, its CA key is not available to sign the certificate of the karmada-agent", karmada.Name))
+		return nil, nil
+	}
+	if err := ensureAgentCredentials(karmadaClient, clusterName); err != nil {
+		return nil, err
+	}
+	kubeconfig, err := ctrl.agentKubeconfig(ctx, karmada, memberClient, clusterName)
+	if errors.IsNotFound(err) {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonKarmadaNotExposed,
+			fmt.Sprintf("The karmada-apiserver of the karmada %s must be exposed to be reachable by the karmada-agent", karmada.Name))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctrl.ensureAgent(memberClient, karmada, memberCluster, memberConfig, kubeconfig); err != nil {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonJoinFailed, fmt.Sprintf("Failed to deploy the karmada-agent: %v", err))
+		return nil, err
+	}
+	deployment, err := memberClient.AppsV1().Deployments(constants.KarmadaSystemNamespace).Get(ctx, constants.KarmadaComponentAgent, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	version := agentVersion(karmada, memberCluster)
+	if deploymentReady(deployment) {
+		memberCluster.Status.AgentVersion = version
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionAgentReady, metav1.ConditionTrue, reasonAgentReady, fmt.Sprintf("The karmada-agent %s is available", version))
+	} else {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionAgentReady, metav1.ConditionFalse, reasonAgentNotReady, fmt.Sprintf("Waiting for the karmada-agent %s to be available", version))
+	}
+
+	cluster, err := karmadaClusterClient.ClusterV1alpha1().Clusters().Get(ctx, clusterName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonJoining, "Waiting for the karmada-agent to register the cluster")
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The karmada-agent has no flag for the zone of the cluster.
+	if zone := memberCluster.Spec.Zone; zone != "" && cluster.Spec.Zone != zone {
+		patch := []byte(fmt.Sprintf(`{"spec":{"zone":%q}}`, zone))
+		cluster, err = karmadaClusterClient.ClusterV1alpha1().Clusters().Patch(ctx, clusterName, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: constants.FieldManager})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cluster, nil
+}
+
+// ensureAgentCredentials grants the user of the karmada-agent of the cluster the permissions it needs in the
+// karmada. The agent is only allowed to register and update its own Cluster object and secrets, and to run
+// the works in the execution space of the cluster.
+func ensureAgentCredentials(karmadaClient clientset.Interface, clusterName string) error {
+	clusterLabels := map[string]string{constants.MemberClusterLabel: clusterName}
+	executionSpace := agentExecutionSpace(clusterName)
+	// The execution space is created by karmada when the cluster is registered, the role of the agent must
+	// exist in it before.
+	for _, namespace := range []string{constants.KarmadaClusterNamespace, executionSpace} {
+		if err := clientutil.ApplyNamespace(karmadaClient, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}); err != nil {
+			return err
+		}
+	}
+
+	name := agentRoleName(clusterName)
+	subjects := []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: agentUser(clusterName)}}
+	clusterRole, roles := agentRoles(clusterName)
+	clusterRole.ObjectMeta = metav1.ObjectMeta{Name: name, Labels: clusterLabels}
+	if err := clientutil.ApplyClusterRole(karmadaClient, clusterRole); err != nil {
+		return err
+	}
+	if err := clientutil.ApplyClusterRoleBinding(karmadaClient, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: clusterLabels},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		},
+		Subjects: subjects,
+	}); err != nil {
+		return err
+	}
+	for _, role := range roles {
+		role.Name = name
+		role.Labels = clusterLabels
+		if err := clientutil.ApplyRole(karmadaClient, role); err != nil {
+			return err
+		}
+		if err := clientutil.ApplyRoleBinding(karmadaClient, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: role.Namespace, Labels: clusterLabels},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     name,
+			},
+			Subjects: subjects,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// agentRoles returns the rules of the karmada-agent of the cluster, as a cluster role and the roles in the
+// namespace of the cluster credentials and in the execution space. The objects which are only created by the
+// agent can't be restricted by their names on creation.
+func agentRoles(clusterName string) (*rbacv1.ClusterRole, []*rbacv1.Role) {
+	clusterRole := &rbacv1.ClusterRole{
+		Rules: []rbacv1.PolicyRule{
+			// The agent checks that the cluster isn't registered by another agent before it registers its own.
+			{APIGroups: []string{clusterv1alpha1.GroupName}, Resources: []string{"clusters"}, Verbs: []string{"create", "get", "list", "watch"}},
+			{APIGroups: []string{clusterv1alpha1.GroupName}, Resources: []string{"clusters", "clusters/status"}, ResourceNames: []string{clusterName}, Verbs: []string{"update", "patch"}},
+			{APIGroups: []string{"config.karmada.io"}, Resources: []string{"resourceinterpreterwebhookconfigurations"}, Verbs: []string{"get", "list", "watch"}},
+			{APIGroups: []string{""}, Resources: []string{"namespaces"}, ResourceNames: []string{constants.KarmadaClusterNamespace}, Verbs: []string{"get"}},
+			// The events of the Cluster object are recorded in the default namespace, the ones of the works in
+			// the execution space.
+			{APIGroups: []string{"", "events.k8s.io"}, Resources: []string{"events"}, Verbs: []string{"create", "patch", "update"}},
+		},
+	}
+	roles := []*rbacv1.Role{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: constants.KarmadaClusterNamespace},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"create"}},
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{clusterName, impersonatorSecretName(clusterName)}, Verbs: []string{"get", "update", "patch"}},
+				{APIGroups: []string{coordinationv1.GroupName}, Resources: []string{"leases"}, Verbs: []string{"create"}},
+				{APIGroups: []string{coordinationv1.GroupName}, Resources: []string{"leases"}, ResourceNames: []string{clusterName}, Verbs: []string{"get", "update", "patch"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: agentExecutionSpace(clusterName)},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{workv1alpha1.GroupName}, Resources: []string{"works", "works/status"}, Verbs: []string{"*"}},
+				// The agent elects its leader in the execution space.
+				{APIGroups: []string{coordinationv1.GroupName}, Resources: []string{"leases"}, Verbs: []string{"create"}},
+				{APIGroups: []string{coordinationv1.GroupName}, Resources: []string{"leases"}, ResourceNames: []string{agentLeaderElectionID(clusterName)}, Verbs: []string{"get", "update", "patch"}},
+			},
+		},
+	}
+	return clusterRole, roles
+}
+
+// agentKubeconfig returns the kubeconfig which the karmada-agent of the cluster accesses the karmada-apiserver
+// with. The server and its CA are taken from the external kubeconfig of the karmada, so a NotFound error is
+// returned if the karmada-apiserver isn't exposed. The client certificate of the kubeconfig which is deployed
+// in the member cluster is kept until it must be renewed, a new one is signed by the CA of the karmada otherwise.
+func (ctrl *MemberClusterController) agentKubeconfig(ctx context.Context, karmada *installv1alpha1.Karmada, memberClient clientset.Interface, clusterName string) ([]byte, error) {
+	secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(ctx, generateExternalKubeConfigSecretName(karmada), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	external, err := clientcmd.Load(secret.Data["kubeconfig"])
+	if err != nil {
+		return nil, err
+	}
+	var server *clientcmdapi.Cluster
+	for _, cluster := range external.Clusters {
+		server = cluster
+		break
+	}
+	if server == nil {
+		return nil, fmt.Errorf("the external kubeconfig of the karmada %s has no cluster", karmada.Name)
+	}
+
+	certSecret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(ctx, generateCertSecretName(karmada), metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the CA of the karmada %s: %v", karmada.Name, err)
+	}
+	caCert, caKey, err := certs.ParseCertAndKey(certSecret.Data["ca.crt"], certSecret.Data["ca.key"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the CA of the karmada %s: %v", karmada.Name, err)
+	}
+	notAfter := time.Now().Add(certificateValidity(karmada)).UTC()
+	config := agentCertConfig(clusterName, &notAfter)
+
+	var cert *x509.Certificate
+	var key crypto.Signer
+	current, err := memberClient.CoreV1().Secrets(constants.KarmadaSystemNamespace).Get(ctx, agentKubeconfigSecretName, metav1.GetOptions{})
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+	if err == nil {
+		cert, key = kubeconfigCertAndKey(current.Data["kubeconfig"])
+	}
+	if cert != nil {
+		if reason := certs.CertRenewalReason(cert, caCert, config, certificateRenewBefore(karmada)); reason != "" {
+			klog.InfoS("Issuing the certificate of karmada-agent again", "karmada", klog.KObj(karmada), "cluster", clusterName, "reason", reason)
+			cert, key = nil, nil
+		}
+	}
+	if cert == nil {
+		cert, key, err = certs.NewCertAndKey(caCert, caKey, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign the certificate of the karmada-agent: %v", err)
+		}
+	}
+	certData, keyData, err := certs.EncodeCertAndKeyPEM(cert, key)
+	if err != nil {
+		return nil, err
+	}
+
+	name := constants.KarmadaComponentKubeAPIServer
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   server.Server,
+		CertificateAuthorityData: server.CertificateAuthorityData,
+		TLSServerName:            server.TLSServerName,
+	}
+	kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{ClientCertificateData: certData, ClientKeyData: keyData}
+	kubeconfig.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	kubeconfig.CurrentContext = name
+	return clientcmd.Write(*kubeconfig)
+}
+
+// agentCertConfig returns the config of the client certificate of the karmada-agent of the cluster.
+func agentCertConfig(clusterName string, notAfter *time.Time) *certs.CertsConfig {
+	config := certs.NewCertConfig(agentUser(clusterName), []string{agentGroup}, certutil.AltNames{}, notAfter)
+	config.Usages = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	return config
+}
+
+// ensureAgent deploys the karmada-agent of the version of the karmada into the karmada-system namespace of the
+// member cluster.
+func (ctrl *MemberClusterController) ensureAgent(memberClient clientset.Interface, karmada *installv1alpha1.Karmada, memberCluster *installv1alpha1.MemberCluster, memberConfig *restclient.Config, kubeconfig []byte) error {
+	clusterName := memberClusterName(memberCluster)
+	clusterLabels := map[string]string{constants.MemberClusterLabel: clusterName}
+	agent := memberCluster.Spec.Agent
+	namespace := constants.KarmadaSystemNamespace
+	name := constants.KarmadaComponentAgent
+
+	if err := clientutil.ApplyNamespace(memberClient, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}); err != nil {
+		return err
+	}
+	// The image pull secrets of the karmada only exist in the host cluster.
+	for _, ref := range karmada.Spec.ImagePullSecrets {
+		secret, err := ctrl.client.CoreV1().Secrets(karmada.Namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get the image pull secret %s: %v", ref.Name, err)
+		}
+		if err := clientutil.ApplySecret(memberClient, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: namespace, Labels: clusterLabels},
+			Type:       secret.Type,
+			Data:       secret.Data,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := clientutil.ApplySecret(memberClient, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: agentKubeconfigSecretName, Namespace: namespace, Labels: clusterLabels},
+		Data:       map[string][]byte{"kubeconfig": kubeconfig},
+	}); err != nil {
+		return err
+	}
+	if err := clientutil.ApplyServiceAccount(memberClient, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: clusterLabels},
+	}); err != nil {
+		return err
+	}
+	// The agent creates the credentials which karmada accesses the cluster with, in the same way as
+	// `karmadactl join`, and applies the works of the cluster.
+	if err := clientutil.ApplyClusterRole(memberClient, &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: clusterLabels},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			{NonResourceURLs: []string{"*"}, Verbs: []string{"get"}},
+		},
+	}); err != nil {
+		return err
+	}
+	if err := clientutil.ApplyClusterRoleBinding(memberClient, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: clusterLabels},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace},
+		},
+	}); err != nil {
+		return err
+	}
+
+	repository := karmada.Spec.ImageRepository
+	if agent.ImageRepository != "" {
+		repository = agent.ImageRepository
+	}
+	imageName := constants.KarmadaComponentAgent
+	if agent.ImageName != "" {
+		imageName = agent.ImageName
+	}
+
+	defaultArgs := map[string]string{
+		"karmada-kubeconfig":              "/etc/kubeconfig/kubeconfig",
+		"cluster-name":                    clusterName,
+		"cluster-api-endpoint":            memberConfig.Host,
+		"cluster-status-update-frequency": "10s",
+		"leader-elect-resource-namespace": agentExecutionSpace(clusterName),
+		"bind-address":                    "0.0.0.0",
+		"secure-port":                     "10357",
+		"v":                               "4",
+	}
+	if memberCluster.Spec.Provider != "" {
+		defaultArgs["cluster-provider"] = memberCluster.Spec.Provider
+	}
+	if memberCluster.Spec.Region != "" {
+		defaultArgs["cluster-region"] = memberCluster.Spec.Region
+	}
+	computedArgs := maputil.MergeStringMaps(defaultArgs, agent.ExtraArgs)
+	// The agent only reads the kubeconfig on start, it's restarted with a renewed certificate.
+	kubeconfigHash := sha256.Sum256(kubeconfig)
+	args := maputil.ConvertToCommandOrArgs(computedArgs)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app": name, constants.MemberClusterLabel: clusterName},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+			Replicas: agent.Replicas,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": name},
+					Annotations: map[string]string{constants.CertificatesHashAnnotation: hex.EncodeToString(kubeconfigHash[:])[:16]},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: name,
+					Containers: []corev1.Container{
+						{
+							Name:            name,
+							Image:           util.ComponentImage(karmada.Spec.ImageOverrides, constants.KarmadaComponentAgent, repository, imageName, agentVersion(karmada, memberCluster)),
+							ImagePullPolicy: util.ImagePullPolicy(agent.ImagePullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
+							Command:         []string{"/bin/karmada-agent"},
+							Args:            args,
+							Resources:       agent.Resources,
+							LivenessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path:   "/healthz",
+										Port:   intstr.FromInt(10357),
+										Scheme: corev1.URISchemeHTTP,
+									},
+								},
+								FailureThreshold:    3,
+								InitialDelaySeconds: 15,
+								PeriodSeconds:       15,
+								TimeoutSeconds:      5,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "kubeconfig",
+									MountPath: "/etc/kubeconfig",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "kubeconfig",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{SecretName: agentKubeconfigSecretName},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, agent.ImagePullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, agent.Placement, nil)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
+	return clientutil.ApplyDeployment(memberClient, deployment)
+}
+
+// removeAgent removes the karmada-agent of the cluster from the member cluster. Only the objects which are
+// labeled with the name of the cluster are removed, and the namespace of the agent is kept, as it may be
+// shared with other components.
+func removeAgent(ctx context.Context, memberClient clientset.Interface, clusterName string) error {
+	namespace := constants.KarmadaSystemNamespace
+	listOptions := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{constants.MemberClusterLabel: clusterName}).String()}
+	if err := memberClient.AppsV1().Deployments(namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err := memberClient.CoreV1().Secrets(namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err := memberClient.CoreV1().ServiceAccounts(namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	// The credentials which karmada accesses the cluster with are labeled with the name of the cluster too.
+	listOptions.FieldSelector = fields.OneTermEqualSelector("metadata.name", constants.KarmadaComponentAgent).String()
+	if err := memberClient.RbacV1().ClusterRoleBindings().DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions); err != nil {
+		return err
+	}
+	return memberClient.RbacV1().ClusterRoles().DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions)
+}
+
+// removeAgentCredentials removes the permissions of the karmada-agent of the cluster from the karmada. Its
+// certificate can't be revoked, but grants nothing without them.
+func removeAgentCredentials(ctx context.Context, karmadaClient clientset.Interface, clusterName string) error {
+	roleName := agentRoleName(clusterName)
+	for _, namespace := range []string{constants.KarmadaClusterNamespace, agentExecutionSpace(clusterName)} {
+		if err := karmadaClient.RbacV1().RoleBindings(namespace).Delete(ctx, roleName, metav1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		if err := karmadaClient.RbacV1().Roles(namespace).Delete(ctx, roleName, metav1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	if err := karmadaClient.RbacV1().ClusterRoleBindings().Delete(ctx, roleName, metav1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	err := karmadaClient.RbacV1().ClusterRoles().Delete(ctx, roleName, metav1.DeleteOptions{})
+	return client.IgnoreNotFound(err)
+}
+
+// agentVersion returns the version of the karmada-agent of the cluster, which follows the version the components
+// of the karmada are running.
+func agentVersion(karmada *installv1alpha1.Karmada, memberCluster *installv1alpha1.MemberCluster) string {
+	if tag := memberCluster.Spec.Agent.ImageTag; tag != "" {
+		return tag
+	}
+	version, _ := runningVersions(karmada)
+	return version
+}
+
+// agentUser returns the name of the user in the karmada which the karmada-agent of the cluster accesses the
+// karmada with.
+func agentUser(clusterName string) string {
+	return agentUserPrefix + clusterName
+}
+
+// agentRoleName returns the name of the roles and their bindings of the karmada-agent in the karmada.
+func agentRoleName(clusterName string) string {
+	return fmt.Sprintf("karmada-agent:%s", clusterName)
+}
+
+// agentExecutionSpace returns the namespace in the karmada which holds the works of the cluster.
+func agentExecutionSpace(clusterName string) string {
+	return karmadautil.ExecutionSpacePrefix + clusterName
+}
+
+// agentLeaderElectionID returns the name of the lease the karmada-agents of the cluster elect their leader with.
+func agentLeaderElectionID(clusterName string) string {
+	return fmt.Sprintf("karmada-agent-%s", clusterName)
+}
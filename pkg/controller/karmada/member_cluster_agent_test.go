@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/carlory/firefly/pkg/constants"
+	"github.com/carlory/firefly/pkg/util/certs"
+)
+
+func TestAgentRoles(t *testing.T) {
+	clusterRole, roles := agentRoles("member1")
+	rules := clusterRole.Rules
+	for _, role := range roles {
+		if role.Namespace != constants.KarmadaClusterNamespace && role.Namespace != "karmada-es-member1" {
+			t.Errorf("unexpected namespace of a role: %s", role.Namespace)
+		}
+		rules = append(rules, role.Rules...)
+	}
+	for _, rule := range rules {
+		for _, resource := range rule.Resources {
+			if resource == "*" || len(rule.NonResourceURLs) > 0 {
+				t.Errorf("the agent must not be granted all resources: %v", rule)
+			}
+			if resource == "clusters" || resource == "clusters/status" {
+				for _, verb := range rule.Verbs {
+					if (verb == "update" || verb == "patch" || verb == "delete" || verb == "*") && !(len(rule.ResourceNames) == 1 && rule.ResourceNames[0] == "member1") {
+						t.Errorf("the agent must only %s its own cluster: %v", verb, rule)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAgentKubeconfig(t *testing.T) {
+	karmada := testKarmada()
+	caCert, caKey, err := certs.NewCACertAndKey("karmada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCACert, otherCAKey, err := certs.NewCACertAndKey("other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	caData, caKeyData, err := certs.EncodeCertAndKeyPEM(caCert, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	external := clientcmdapi.NewConfig()
+	external.Clusters["karmada"] = &clientcmdapi.Cluster{Server: "https://192.168.0.1:32443", CertificateAuthorityData: caData}
+	externalData, err := clientcmd.Write(*external)
+	if err != nil {
+		t.Fatal(err)
+	}
+	externalSecret := &corev1.Secret{Data: map[string][]byte{"kubeconfig": externalData}}
+	certSecret := &corev1.Secret{Data: map[string][]byte{"ca.crt": caData, "ca.key": caKeyData}}
+
+	// agentSecret returns the kubeconfig secret of the agent in the member cluster with a certificate which is
+	// signed by the CA and valid for the given duration.
+	agentSecret := func(caCert *x509.Certificate, caKey crypto.Signer, validFor time.Duration) (*corev1.Secret, *x509.Certificate) {
+		notAfter := time.Now().Add(validFor).UTC()
+		cert, key, err := certs.NewCertAndKey(caCert, caKey, agentCertConfig("member1", &notAfter))
+		if err != nil {
+			t.Fatal(err)
+		}
+		certData, keyData, err := certs.EncodeCertAndKeyPEM(cert, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := clientcmd.Write(*certs.CreateWithCerts("https://192.168.0.1:32443", "agent", "karmada", caData, keyData, certData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &corev1.Secret{Data: map[string][]byte{"kubeconfig": data}}, cert
+	}
+	validSecret, validCert := agentSecret(caCert, caKey, certificateValidity(karmada))
+	expiringSecret, _ := agentSecret(caCert, caKey, time.Hour)
+	otherCASecret, _ := agentSecret(otherCACert, otherCAKey, certificateValidity(karmada))
+
+	tests := []struct {
+		name         string
+		hostSecrets  map[string]*corev1.Secret
+		agentSecret  *corev1.Secret
+		wantNotFound bool
+		// wantCert is the certificate which must be kept, a new one must be issued if it's nil.
+		wantCert *x509.Certificate
+	}{
+		{
+			name:         "karmada-apiserver not exposed",
+			hostSecrets:  map[string]*corev1.Secret{generateCertSecretName(karmada): certSecret},
+			wantNotFound: true,
+		},
+		{
+			name:        "no kubeconfig in the member cluster",
+			hostSecrets: map[string]*corev1.Secret{generateExternalKubeConfigSecretName(karmada): externalSecret, generateCertSecretName(karmada): certSecret},
+		},
+		{
+			name:        "valid certificate is kept",
+			hostSecrets: map[string]*corev1.Secret{generateExternalKubeConfigSecretName(karmada): externalSecret, generateCertSecretName(karmada): certSecret},
+			agentSecret: validSecret,
+			wantCert:    validCert,
+		},
+		{
+			name:        "expiring certificate is renewed",
+			hostSecrets: map[string]*corev1.Secret{generateExternalKubeConfigSecretName(karmada): externalSecret, generateCertSecretName(karmada): certSecret},
+			agentSecret: expiringSecret,
+		},
+		{
+			name:        "certificate of another CA is renewed",
+			hostSecrets: map[string]*corev1.Secret{generateExternalKubeConfigSecretName(karmada): externalSecret, generateCertSecretName(karmada): certSecret},
+			agentSecret: otherCASecret,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &MemberClusterController{client: &fakeSecretsClientset{secrets: tt.hostSecrets}}
+			memberSecrets := map[string]*corev1.Secret{}
+			if tt.agentSecret != nil {
+				memberSecrets[agentKubeconfigSecretName] = tt.agentSecret
+			}
+			data, err := ctrl.agentKubeconfig(context.TODO(), karmada, &fakeSecretsClientset{secrets: memberSecrets}, "member1")
+			if tt.wantNotFound {
+				if !errors.IsNotFound(err) {
+					t.Fatalf("expected a NotFound error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, err := clientcmd.Load(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if server := config.Clusters[config.Contexts[config.CurrentContext].Cluster].Server; server != "https://192.168.0.1:32443" {
+				t.Errorf("unexpected server %s", server)
+			}
+			cert, _ := kubeconfigCertAndKey(data)
+			if cert == nil {
+				t.Fatal("the kubeconfig has no client certificate")
+			}
+			if err := cert.CheckSignatureFrom(caCert); err != nil {
+				t.Errorf("the certificate isn't signed by the CA of the karmada: %v", err)
+			}
+			if cert.Subject.CommonName != "system:karmada:agent:member1" || len(cert.Subject.Organization) != 1 || cert.Subject.Organization[0] != "system:karmada:agents" {
+				t.Errorf("unexpected subject %s", cert.Subject)
+			}
+			if tt.wantCert != nil && !cert.Equal(tt.wantCert) {
+				t.Error("expected the certificate to be kept")
+			}
+			if tt.wantCert == nil && tt.agentSecret != nil && time.Until(cert.NotAfter) < certificateRenewBefore(karmada) {
+				t.Errorf("expected the certificate to be renewed, it expires at %s", cert.NotAfter)
+			}
+		})
+	}
+}
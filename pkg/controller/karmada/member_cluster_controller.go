@@ -0,0 +1,724 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaversioned "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/component-base/metrics/prometheus/ratelimiter"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	fireflyclient "github.com/carlory/firefly/pkg/generated/clientset/versioned"
+	installinformers "github.com/carlory/firefly/pkg/generated/informers/externalversions/install/v1alpha1"
+	installlisters "github.com/carlory/firefly/pkg/generated/listers/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/metrics"
+	"github.com/carlory/firefly/pkg/scheme"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+	utilresource "github.com/carlory/firefly/pkg/util/resource"
+)
+
+const (
+	// MemberClusterControllerFinalizerName is the finalizer which unjoins a member cluster from its karmada.
+	MemberClusterControllerFinalizerName = "membercluster.install.firefly.io/finalizer"
+
+	// memberClusterResyncInterval is the interval in which the conditions of a cluster are mirrored.
+	memberClusterResyncInterval = 30 * time.Second
+	// memberClusterRetryInterval is the interval in which a cluster which can't be joined yet is retried.
+	memberClusterRetryInterval = 5 * time.Second
+
+	// impersonatorServiceAccountName is the service account in the member cluster which karmada impersonates
+	// the users with, e.g. for the cluster proxy.
+	impersonatorServiceAccountName = "karmada-impersonator"
+
+	reasonKarmadaNotReady   = "KarmadaNotReady"
+	reasonKubeconfigInvalid = "KubeconfigInvalid"
+	reasonJoining           = "Joining"
+	reasonJoinFailed        = "JoinFailed"
+	reasonDuplicateCluster  = "DuplicateCluster"
+	reasonClusterJoined     = "Joined"
+)
+
+// NewMemberClusterController returns a new *MemberClusterController.
+func NewMemberClusterController(
+	client clientset.Interface,
+	fireflyClient fireflyclient.Interface,
+	memberClusterInformer installinformers.MemberClusterInformer,
+	karmadaInformer installinformers.KarmadaInformer) (*MemberClusterController, error) {
+	broadcaster := record.NewBroadcaster()
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "member-cluster-controller"})
+
+	if client != nil && client.CoreV1().RESTClient().GetRateLimiter() != nil {
+		ratelimiter.RegisterMetricAndTrackRateLimiterUsage("member_cluster_controller", client.CoreV1().RESTClient().GetRateLimiter())
+	}
+	metrics.Register()
+
+	ctrl := &MemberClusterController{
+		client:               client,
+		fireflyClient:        fireflyClient,
+		memberClustersLister: memberClusterInformer.Lister(),
+		memberClustersSynced: memberClusterInformer.Informer().HasSynced,
+		karmadasLister:       karmadaInformer.Lister(),
+		karmadasSynced:       karmadaInformer.Informer().HasSynced,
+		queue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "membercluster"),
+		workerLoopPeriod:     time.Second,
+		eventBroadcaster:     broadcaster,
+		eventRecorder:        recorder,
+	}
+
+	memberClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ctrl.enqueue,
+		UpdateFunc: func(old, cur interface{}) { ctrl.enqueue(cur) },
+	})
+	karmadaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ctrl.enqueueKarmadaMemberClusters,
+		UpdateFunc: func(old, cur interface{}) { ctrl.enqueueKarmadaMemberClusters(cur) },
+	})
+
+	return ctrl, nil
+}
+
+// MemberClusterController joins the member clusters to their karmadas, in the same way as `karmadactl join`.
+//
+// The service accounts which karmada accesses the member cluster with are created in the member cluster
+// with the kubeconfig of the MemberCluster, and the Cluster object is registered in the karmada together
+// with the secrets which hold their tokens. In the Pull mode, a karmada-agent is deployed into the member
+// cluster instead, which registers the Cluster object with the credentials issued for it in the karmada.
+// The conditions of the Cluster are mirrored in the status of the MemberCluster, and the cluster is
+// unjoined when the MemberCluster is deleted.
+type MemberClusterController struct {
+	client           clientset.Interface
+	fireflyClient    fireflyclient.Interface
+	eventBroadcaster record.EventBroadcaster
+	eventRecorder    record.EventRecorder
+
+	memberClustersLister installlisters.MemberClusterLister
+	memberClustersSynced cache.InformerSynced
+	karmadasLister       installlisters.KarmadaLister
+	karmadasSynced       cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+
+	// workerLoopPeriod is the time between worker runs.
+	workerLoopPeriod time.Duration
+}
+
+// Run will not return until stopCh is closed. workers determines how many
+// member clusters will be handled in parallel.
+func (ctrl *MemberClusterController) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+
+	ctrl.eventBroadcaster.StartStructuredLogging(0)
+	ctrl.eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: ctrl.client.CoreV1().Events("")})
+	defer ctrl.eventBroadcaster.Shutdown()
+
+	defer ctrl.queue.ShutDown()
+
+	klog.Infof("Starting member cluster controller")
+	defer klog.Infof("Shutting down member cluster controller")
+
+	if !cache.WaitForNamedCacheSync("membercluster", ctx.Done(), ctrl.memberClustersSynced, ctrl.karmadasSynced) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, ctrl.worker, ctrl.workerLoopPeriod)
+	}
+	<-ctx.Done()
+}
+
+func (ctrl *MemberClusterController) worker(ctx context.Context) {
+	for ctrl.processNextWorkItem(ctx) {
+	}
+}
+
+func (ctrl *MemberClusterController) processNextWorkItem(ctx context.Context) bool {
+	key, quit := ctrl.queue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.queue.Done(key)
+
+	startTime := time.Now()
+	err := ctrl.syncMemberCluster(ctx, key.(string))
+	metrics.ObserveReconcile("membercluster", metrics.PhaseSync, startTime, err)
+	if err == nil {
+		ctrl.queue.Forget(key)
+		return true
+	}
+	if ctrl.queue.NumRequeues(key) < maxRetries {
+		klog.V(2).InfoS("Error syncing member cluster, retrying", "memberCluster", key, "err", err)
+		ctrl.queue.AddRateLimited(key)
+		return true
+	}
+	utilruntime.HandleError(err)
+	klog.V(2).InfoS("Dropping member cluster out of the queue", "memberCluster", key, "err", err)
+	ctrl.queue.Forget(key)
+	return true
+}
+
+func (ctrl *MemberClusterController) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	ctrl.queue.Add(key)
+}
+
+// enqueueKarmadaMemberClusters enqueues the member clusters of the karmada.
+func (ctrl *MemberClusterController) enqueueKarmadaMemberClusters(obj interface{}) {
+	karmada := obj.(*installv1alpha1.Karmada)
+	memberClusters, err := ctrl.memberClustersLister.MemberClusters(karmada.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, memberCluster := range memberClusters {
+		if memberCluster.Spec.KarmadaName == karmada.Name {
+			ctrl.enqueue(memberCluster)
+		}
+	}
+}
+
+func (ctrl *MemberClusterController) syncMemberCluster(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	memberCluster, err := ctrl.memberClustersLister.MemberClusters(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		klog.V(2).InfoS("Member cluster has been deleted", "memberCluster", klog.KRef(namespace, name))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	memberCluster = memberCluster.DeepCopy()
+
+	if !memberCluster.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(memberCluster, MemberClusterControllerFinalizerName) {
+			return nil
+		}
+		done, err := ctrl.unjoin(ctx, memberCluster)
+		if err != nil {
+			return err
+		}
+		if !done {
+			ctrl.queue.AddAfter(key, memberClusterRetryInterval)
+			return nil
+		}
+		controllerutil.RemoveFinalizer(memberCluster, MemberClusterControllerFinalizerName)
+		_, err = ctrl.fireflyClient.InstallV1alpha1().MemberClusters(namespace).Update(ctx, memberCluster, metav1.UpdateOptions{})
+		return err
+	}
+	if !controllerutil.ContainsFinalizer(memberCluster, MemberClusterControllerFinalizerName) {
+		controllerutil.AddFinalizer(memberCluster, MemberClusterControllerFinalizerName)
+		memberCluster, err = ctrl.fireflyClient.InstallV1alpha1().MemberClusters(namespace).Update(ctx, memberCluster, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	old := memberCluster.DeepCopy()
+	joined, syncErr := ctrl.join(ctx, memberCluster)
+	memberCluster.Status.ObservedGeneration = memberCluster.Generation
+	if !equality.Semantic.DeepEqual(old.Status, memberCluster.Status) {
+		if _, err := ctrl.fireflyClient.InstallV1alpha1().MemberClusters(namespace).UpdateStatus(ctx, memberCluster, metav1.UpdateOptions{}); err != nil {
+			klog.ErrorS(err, "Failed to update member cluster status", "memberCluster", klog.KObj(memberCluster))
+			if syncErr == nil {
+				syncErr = err
+			}
+		}
+	}
+	if syncErr != nil {
+		return syncErr
+	}
+	if joined {
+		ctrl.queue.AddAfter(key, memberClusterResyncInterval)
+	} else {
+		ctrl.queue.AddAfter(key, memberClusterRetryInterval)
+	}
+	return nil
+}
+
+// join registers the cluster in the karmada and mirrors the conditions of the Cluster object. It returns
+// whether the cluster is joined.
+func (ctrl *MemberClusterController) join(ctx context.Context, memberCluster *installv1alpha1.MemberCluster) (bool, error) {
+	karmada, err := ctrl.karmadasLister.Karmadas(memberCluster.Namespace).Get(memberCluster.Spec.KarmadaName)
+	if errors.IsNotFound(err) {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonKarmadaNotReady, fmt.Sprintf("The karmada %s is not found", memberCluster.Spec.KarmadaName))
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !meta.IsStatusConditionTrue(karmada.Status.Conditions, installv1alpha1.KarmadaConditionReady) {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonKarmadaNotReady, fmt.Sprintf("Waiting for the karmada %s to be ready", karmada.Name))
+		return false, nil
+	}
+
+	memberConfig, err := ctrl.memberClusterConfig(memberCluster)
+	if err != nil {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonKubeconfigInvalid, err.Error())
+		return false, nil
+	}
+	memberClient, err := clientset.NewForConfig(memberConfig)
+	if err != nil {
+		return false, err
+	}
+	karmadaConfig, err := utilresource.GetClientConfigFromKubeConfigSecret(ctrl.client, karmada.Namespace, GenerateKubeConfigSecretName(karmada), userAgentName)
+	if err != nil {
+		return false, err
+	}
+	karmadaClient, err := clientset.NewForConfig(karmadaConfig)
+	if err != nil {
+		return false, err
+	}
+	karmadaClusterClient, err := karmadaversioned.NewForConfig(karmadaConfig)
+	if err != nil {
+		return false, err
+	}
+
+	clusterName := memberClusterName(memberCluster)
+	systemNamespace, err := memberClient.CoreV1().Namespaces().Get(ctx, metav1.NamespaceSystem, metav1.GetOptions{})
+	if err != nil {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonJoinFailed, fmt.Sprintf("Failed to access the cluster: %v", err))
+		return false, err
+	}
+	clusterID := string(systemNamespace.UID)
+	memberCluster.Status.ClusterID = clusterID
+
+	// karmadactl join refuses to register the same cluster twice under different names.
+	clusters, err := karmadaClusterClient.ClusterV1alpha1().Clusters().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, cluster := range clusters.Items {
+		if cluster.Name != clusterName && cluster.Spec.ID == clusterID {
+			ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonDuplicateCluster, fmt.Sprintf("The cluster is already joined to the karmada as %s", cluster.Name))
+			return false, nil
+		}
+	}
+
+	var cluster *clusterv1alpha1.Cluster
+	if memberCluster.Spec.SyncMode == installv1alpha1.ClusterSyncModePull {
+		cluster, err = ctrl.joinWithAgent(ctx, karmada, memberCluster, memberClient, karmadaClient, karmadaClusterClient, memberConfig)
+	} else {
+		cluster, err = ctrl.joinWithCredentials(ctx, karmada, memberCluster, memberClient, karmadaClient, karmadaClusterClient, memberConfig, clusterID)
+	}
+	if err != nil || cluster == nil {
+		return false, err
+	}
+
+	ownConditions := []metav1.Condition{}
+	for _, conditionType := range []string{installv1alpha1.MemberClusterConditionJoined, installv1alpha1.MemberClusterConditionAgentReady} {
+		if condition := meta.FindStatusCondition(memberCluster.Status.Conditions, conditionType); condition != nil {
+			ownConditions = append(ownConditions, *condition)
+		}
+	}
+	memberCluster.Status.Conditions = append(append([]metav1.Condition{}, cluster.Status.Conditions...), ownConditions...)
+	memberCluster.Status.KubernetesVersion = cluster.Status.KubernetesVersion
+	ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionTrue, reasonClusterJoined, fmt.Sprintf("The cluster is joined to the karmada %s as %s", karmada.Name, clusterName))
+	return true, nil
+}
+
+// joinWithCredentials joins the cluster in the Push mode. The credentials which karmada accesses the cluster with
+// are created in the member cluster, and the Cluster object is registered with them. A karmada-agent which was
+// deployed for the Pull mode before is removed first. It returns the Cluster object, or nil if it isn't
+// registered yet.
+func (ctrl *MemberClusterController) joinWithCredentials(ctx context.Context, karmada *installv1alpha1.Karmada, memberCluster *installv1alpha1.MemberCluster,
+	memberClient, karmadaClient clientset.Interface, karmadaClusterClient karmadaversioned.Interface, memberConfig *restclient.Config, clusterID string) (*clusterv1alpha1.Cluster, error) {
+	clusterName := memberClusterName(memberCluster)
+	if meta.FindStatusCondition(memberCluster.Status.Conditions, installv1alpha1.MemberClusterConditionAgentReady) != nil {
+		klog.InfoS("Removing karmada-agent of member cluster switched to the Push mode", "memberCluster", klog.KObj(memberCluster))
+		if err := removeAgent(ctx, memberClient, clusterName); err != nil {
+			return nil, err
+		}
+		if err := removeAgentCredentials(ctx, karmadaClient, clusterName); err != nil {
+			return nil, err
+		}
+		meta.RemoveStatusCondition(&memberCluster.Status.Conditions, installv1alpha1.MemberClusterConditionAgentReady)
+		memberCluster.Status.AgentVersion = ""
+	}
+
+	if err := ctrl.ensureMemberClusterCredentials(memberClient, clusterName); err != nil {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonJoinFailed, fmt.Sprintf("Failed to create the credentials in the cluster: %v", err))
+		return nil, err
+	}
+	token, caBundle, ready, err := serviceAccountToken(memberClient, memberClusterServiceAccountName(clusterName))
+	if err != nil {
+		return nil, err
+	}
+	impersonatorToken, _, impersonatorReady, err := serviceAccountToken(memberClient, impersonatorServiceAccountName)
+	if err != nil {
+		return nil, err
+	}
+	if !ready || !impersonatorReady {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonJoining, "Waiting for the tokens of the service accounts to be populated")
+		return nil, nil
+	}
+
+	cluster, err := ctrl.registerCluster(ctx, karmadaClient, karmadaClusterClient, memberCluster, memberConfig, clusterID, token, caBundle, impersonatorToken)
+	if err != nil {
+		ctrl.setCondition(memberCluster, installv1alpha1.MemberClusterConditionJoined, metav1.ConditionFalse, reasonJoinFailed, fmt.Sprintf("Failed to register the cluster in the karmada: %v", err))
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// setCondition sets the condition of the member cluster and records an event if it changes.
+func (ctrl *MemberClusterController) setCondition(memberCluster *installv1alpha1.MemberCluster, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	old := meta.FindStatusCondition(memberCluster.Status.Conditions, conditionType)
+	if old == nil || old.Status != status || old.Reason != reason {
+		eventType := corev1.EventTypeNormal
+		if status != metav1.ConditionTrue {
+			eventType = corev1.EventTypeWarning
+		}
+		ctrl.eventRecorder.Event(memberCluster, eventType, reason, message)
+	}
+	meta.SetStatusCondition(&memberCluster.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: memberCluster.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// memberClusterConfig returns the client config of the member cluster from its kubeconfig secret.
+func (ctrl *MemberClusterController) memberClusterConfig(memberCluster *installv1alpha1.MemberCluster) (*restclient.Config, error) {
+	ref := memberCluster.Spec.KubeconfigSecretRef
+	key := ref.Key
+	if key == "" {
+		key = "kubeconfig"
+	}
+	secret, err := ctrl.client.CoreV1().Secrets(memberCluster.Namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the kubeconfig secret %s: %v", ref.Name, err)
+	}
+	kubeconfig, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("the secret %s doesn't contain the %s field", ref.Name, key)
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig of the secret %s: %v", ref.Name, err)
+	}
+	return restclient.AddUserAgent(config, userAgentName), nil
+}
+
+// ensureMemberClusterCredentials creates the service accounts which karmada accesses the member cluster with.
+// The objects are labeled with the name of the cluster, so that only the objects created for it are removed
+// when it's unjoined. The namespace and the impersonator service account are shared with the other karmadas
+// and tools which join the cluster, so the namespace isn't labeled and the impersonator service account is
+// only created if it doesn't exist, keeping the label of the MemberCluster which created it.
+func (ctrl *MemberClusterController) ensureMemberClusterCredentials(memberClient clientset.Interface, clusterName string) error {
+	clusterLabels := map[string]string{constants.MemberClusterLabel: clusterName}
+	if err := clientutil.ApplyNamespace(memberClient, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.KarmadaClusterNamespace},
+	}); err != nil {
+		return err
+	}
+
+	serviceAccountName := memberClusterServiceAccountName(clusterName)
+	for _, name := range []string{serviceAccountName, impersonatorServiceAccountName} {
+		serviceAccount := &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.KarmadaClusterNamespace, Labels: clusterLabels},
+		}
+		// The tokens of the service accounts are not generated automatically since kubernetes 1.24.
+		tokenSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        serviceAccountTokenSecretName(name),
+				Namespace:   constants.KarmadaClusterNamespace,
+				Labels:      clusterLabels,
+				Annotations: map[string]string{corev1.ServiceAccountNameKey: name},
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+		}
+		if name == impersonatorServiceAccountName {
+			if err := createIfNotExists(memberClient, serviceAccount, tokenSecret); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := clientutil.ApplyServiceAccount(memberClient, serviceAccount); err != nil {
+			return err
+		}
+		if err := clientutil.ApplySecret(memberClient, tokenSecret); err != nil {
+			return err
+		}
+	}
+
+	roleName := memberClusterRoleName(clusterName)
+	if err := clientutil.ApplyClusterRole(memberClient, &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: roleName, Labels: clusterLabels},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			{NonResourceURLs: []string{"*"}, Verbs: []string{"get"}},
+		},
+	}); err != nil {
+		return err
+	}
+	if err := clientutil.ApplyClusterRoleBinding(memberClient, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: roleName, Labels: clusterLabels},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     roleName,
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.ServiceAccountKind, Name: serviceAccountName, Namespace: constants.KarmadaClusterNamespace},
+		},
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// createIfNotExists creates the service account and its token secret unless they exist.
+func createIfNotExists(memberClient clientset.Interface, serviceAccount *corev1.ServiceAccount, tokenSecret *corev1.Secret) error {
+	_, err := memberClient.CoreV1().ServiceAccounts(serviceAccount.Namespace).Create(context.TODO(), serviceAccount, metav1.CreateOptions{FieldManager: constants.FieldManager})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	_, err = memberClient.CoreV1().Secrets(tokenSecret.Namespace).Create(context.TODO(), tokenSecret, metav1.CreateOptions{FieldManager: constants.FieldManager})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// registerCluster applies the Cluster object and the secrets of its credentials in the karmada, and returns
+// the live Cluster object.
+func (ctrl *MemberClusterController) registerCluster(ctx context.Context, karmadaClient clientset.Interface, karmadaClusterClient karmadaversioned.Interface,
+	memberCluster *installv1alpha1.MemberCluster, memberConfig *restclient.Config, clusterID string, token, caBundle, impersonatorToken []byte) (*clusterv1alpha1.Cluster, error) {
+	clusterName := memberClusterName(memberCluster)
+	if err := clientutil.ApplyNamespace(karmadaClient, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.KarmadaClusterNamespace}}); err != nil {
+		return nil, err
+	}
+
+	cluster := &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		Spec: clusterv1alpha1.ClusterSpec{
+			ID:          clusterID,
+			SyncMode:    clusterv1alpha1.Push,
+			APIEndpoint: memberConfig.Host,
+			SecretRef: &clusterv1alpha1.LocalSecretReference{
+				Namespace: constants.KarmadaClusterNamespace,
+				Name:      clusterName,
+			},
+			ImpersonatorSecretRef: &clusterv1alpha1.LocalSecretReference{
+				Namespace: constants.KarmadaClusterNamespace,
+				Name:      impersonatorSecretName(clusterName),
+			},
+			InsecureSkipTLSVerification: memberConfig.TLSClientConfig.Insecure,
+			Provider:                    memberCluster.Spec.Provider,
+			Region:                      memberCluster.Spec.Region,
+			Zone:                        memberCluster.Spec.Zone,
+		},
+	}
+	if memberConfig.Proxy != nil {
+		// The proxy of a kubeconfig is a function, only its url is known to karmada.
+		if proxyURL, err := memberConfig.Proxy(nil); err == nil && proxyURL != nil {
+			cluster.Spec.ProxyURL = proxyURL.String()
+		}
+	}
+	if err := clientutil.ApplyCluster(karmadaClusterClient, cluster); err != nil {
+		return nil, err
+	}
+	cluster, err := karmadaClusterClient.ClusterV1alpha1().Clusters().Get(ctx, clusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// The secrets are garbage collected with the Cluster object, e.g. when it's unjoined with karmadactl.
+	owner := *metav1.NewControllerRef(cluster, clusterv1alpha1.SchemeGroupVersion.WithKind("Cluster"))
+	secrets := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: constants.KarmadaClusterNamespace},
+			Data: map[string][]byte{
+				clusterv1alpha1.SecretCADataKey: caBundle,
+				clusterv1alpha1.SecretTokenKey:  token,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: impersonatorSecretName(clusterName), Namespace: constants.KarmadaClusterNamespace},
+			Data: map[string][]byte{
+				clusterv1alpha1.SecretTokenKey: impersonatorToken,
+			},
+		},
+	}
+	for _, secret := range secrets {
+		secret.OwnerReferences = []metav1.OwnerReference{owner}
+		if err := clientutil.ApplySecret(karmadaClient, secret); err != nil {
+			return nil, err
+		}
+	}
+	return cluster, nil
+}
+
+// unjoin removes the cluster from the karmada and its credentials from the member cluster, the parts which
+// are no longer accessible are skipped. It returns whether the cluster is unjoined.
+func (ctrl *MemberClusterController) unjoin(ctx context.Context, memberCluster *installv1alpha1.MemberCluster) (bool, error) {
+	clusterName := memberClusterName(memberCluster)
+
+	karmada, err := ctrl.karmadasLister.Karmadas(memberCluster.Namespace).Get(memberCluster.Spec.KarmadaName)
+	if errors.IsNotFound(err) {
+		karmada = nil
+	} else if err != nil {
+		return false, err
+	}
+	if karmada != nil && karmada.DeletionTimestamp.IsZero() {
+		karmadaConfig, err := utilresource.GetClientConfigFromKubeConfigSecret(ctrl.client, karmada.Namespace, GenerateKubeConfigSecretName(karmada), userAgentName)
+		if client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+		if err == nil {
+			karmadaClusterClient, err := karmadaversioned.NewForConfig(karmadaConfig)
+			if err != nil {
+				return false, err
+			}
+			karmadaClient, err := clientset.NewForConfig(karmadaConfig)
+			if err != nil {
+				return false, err
+			}
+			cluster, err := karmadaClusterClient.ClusterV1alpha1().Clusters().Get(ctx, clusterName, metav1.GetOptions{})
+			if client.IgnoreNotFound(err) != nil {
+				return false, err
+			}
+			// Wait for karmada to remove the resources it propagated to the cluster before its credentials go.
+			if err == nil {
+				if cluster.DeletionTimestamp.IsZero() {
+					klog.InfoS("Unjoining member cluster from karmada", "memberCluster", klog.KObj(memberCluster), "cluster", clusterName)
+					if err := karmadaClusterClient.ClusterV1alpha1().Clusters().Delete(ctx, clusterName, metav1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+						return false, err
+					}
+				}
+				return false, nil
+			}
+			for _, name := range []string{clusterName, impersonatorSecretName(clusterName)} {
+				err := karmadaClient.CoreV1().Secrets(constants.KarmadaClusterNamespace).Delete(ctx, name, metav1.DeleteOptions{})
+				if client.IgnoreNotFound(err) != nil {
+					return false, err
+				}
+			}
+			if err := removeAgentCredentials(ctx, karmadaClient, clusterName); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	memberConfig, err := ctrl.memberClusterConfig(memberCluster)
+	if err != nil {
+		klog.InfoS("Skip removing the credentials from the member cluster", "memberCluster", klog.KObj(memberCluster), "err", err)
+		return true, nil
+	}
+	memberClient, err := clientset.NewForConfig(memberConfig)
+	if err != nil {
+		return false, err
+	}
+	if err := removeAgent(ctx, memberClient, clusterName); err != nil {
+		return false, err
+	}
+	if err := removeMemberClusterCredentials(ctx, memberClient, clusterName); err != nil {
+		return false, err
+	}
+	ctrl.eventRecorder.Eventf(memberCluster, corev1.EventTypeNormal, "Unjoined", "The cluster %s is unjoined from the karmada", clusterName)
+	return true, nil
+}
+
+// removeMemberClusterCredentials removes the objects which are created for the cluster from the member cluster.
+// They're matched by the label of the cluster, and the namespace is kept, as it's shared with the other karmadas
+// and tools which join the cluster.
+func removeMemberClusterCredentials(ctx context.Context, memberClient clientset.Interface, clusterName string) error {
+	listOptions := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{constants.MemberClusterLabel: clusterName}).String()}
+	if err := memberClient.RbacV1().ClusterRoleBindings().DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions); err != nil {
+		return err
+	}
+	if err := memberClient.RbacV1().ClusterRoles().DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions); err != nil {
+		return err
+	}
+	err := memberClient.CoreV1().ServiceAccounts(constants.KarmadaClusterNamespace).DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	err = memberClient.CoreV1().Secrets(constants.KarmadaClusterNamespace).DeleteCollection(ctx, metav1.DeleteOptions{}, listOptions)
+	return client.IgnoreNotFound(err)
+}
+
+// serviceAccountToken returns the token and the ca bundle of the service account in the member cluster, and
+// whether they are populated.
+func serviceAccountToken(memberClient clientset.Interface, serviceAccountName string) ([]byte, []byte, bool, error) {
+	secret, err := memberClient.CoreV1().Secrets(constants.KarmadaClusterNamespace).Get(context.TODO(), serviceAccountTokenSecretName(serviceAccountName), metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, false, err
+	}
+	token := secret.Data[corev1.ServiceAccountTokenKey]
+	return token, secret.Data[corev1.ServiceAccountRootCAKey], len(token) > 0, nil
+}
+
+// memberClusterName returns the name of the cluster in the karmada.
+func memberClusterName(memberCluster *installv1alpha1.MemberCluster) string {
+	if memberCluster.Spec.ClusterName != "" {
+		return memberCluster.Spec.ClusterName
+	}
+	return memberCluster.Name
+}
+
+// memberClusterServiceAccountName returns the name of the service account which karmada accesses the member
+// cluster with.
+func memberClusterServiceAccountName(clusterName string) string {
+	return fmt.Sprintf("karmada-%s", clusterName)
+}
+
+// memberClusterRoleName returns the name of the cluster role and its binding of the service account.
+func memberClusterRoleName(clusterName string) string {
+	return fmt.Sprintf("karmada-controller-manager:%s", memberClusterServiceAccountName(clusterName))
+}
+
+func serviceAccountTokenSecretName(serviceAccountName string) string {
+	return fmt.Sprintf("%s-token", serviceAccountName)
+}
+
+func impersonatorSecretName(clusterName string) string {
+	return fmt.Sprintf("%s-impersonator", clusterName)
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	"github.com/carlory/firefly/pkg/metrics"
+	"github.com/carlory/firefly/pkg/scheme"
+	"github.com/carlory/firefly/pkg/util"
+	"github.com/carlory/firefly/pkg/util/certs"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+)
+
+const (
+	// monitoringUser is the user of the client certificate which the metrics of the karmada-apiserver are
+	// scraped with, it's only allowed to get the metrics.
+	monitoringUser = "firefly:monitoring"
+
+	// karmadaControllerManagerMetricsPort is the port which the karmada-controller-manager serves its metrics on.
+	karmadaControllerManagerMetricsPort = 8080
+	// karmadaSchedulerMetricsPort is the port which the karmada-scheduler and the karmada-scheduler-estimator
+	// serve their metrics on.
+	karmadaSchedulerMetricsPort = 10351
+
+	reasonMonitoringCreated      = "MonitoringCreated"
+	reasonMonitoringCRDsNotFound = "MonitoringCRDsNotFound"
+)
+
+// reconcileMonitoring creates the ServiceMonitors, PodMonitors and PrometheusRules of the karmada when its
+// monitoring is enabled, and removes them otherwise. It doesn't affect the readiness of the karmada.
+func (ctrl *KarmadaController) reconcileMonitoring(karmada *installv1alpha1.Karmada) error {
+	startTime := time.Now()
+	err := ctrl.ensureMonitoring(karmada)
+	metrics.ObserveReconcile("karmada", installv1alpha1.KarmadaConditionMonitoring, startTime, err)
+	if err != nil {
+		klog.ErrorS(err, "Failed to reconcile the monitoring of karmada", "karmada", klog.KObj(karmada))
+		ctrl.setCondition(karmada, installv1alpha1.KarmadaConditionMonitoring, metav1.ConditionFalse, reasonReconcileError, err.Error())
+	}
+	return err
+}
+
+func (ctrl *KarmadaController) ensureMonitoring(karmada *installv1alpha1.Karmada) error {
+	installed, err := util.MonitoringInstalled(ctrl.dynamicClient, karmada.Namespace)
+	if err != nil {
+		return err
+	}
+	monitoring := karmada.Spec.Monitoring
+	if monitoring == nil {
+		meta.RemoveStatusCondition(&karmada.Status.Conditions, installv1alpha1.KarmadaConditionMonitoring)
+		if !installed {
+			return nil
+		}
+		return util.ApplyMonitoringObjects(ctrl.dynamicClient, karmada.Namespace, karmadaMonitoringLabels(karmada), nil)
+	}
+	if !installed {
+		ctrl.setCondition(karmada, installv1alpha1.KarmadaConditionMonitoring, metav1.ConditionFalse, reasonMonitoringCRDsNotFound, "The monitoring.coreos.com CRDs of the prometheus operator are not installed")
+		return nil
+	}
+
+	if err := ctrl.ensureMonitoringCert(karmada); err != nil {
+		return err
+	}
+	if err := ctrl.ensureMonitoringRBAC(karmada); err != nil {
+		return err
+	}
+
+	objs := karmadaMonitoringObjects(karmada)
+	for _, obj := range objs {
+		controllerutil.SetOwnerReference(karmada, obj, scheme.Scheme)
+	}
+	if err := util.ApplyMonitoringObjects(ctrl.dynamicClient, karmada.Namespace, karmadaMonitoringLabels(karmada), objs); err != nil {
+		return err
+	}
+	ctrl.setCondition(karmada, installv1alpha1.KarmadaConditionMonitoring, metav1.ConditionTrue, reasonMonitoringCreated, fmt.Sprintf("%d monitoring objects are created", len(objs)))
+	return nil
+}
+
+// ensureMonitoringCert ensures the client certificate which the metrics of the karmada-apiserver are scraped
+// with. It's requested from the cert-manager issuer of the karmada, or signed by the CA of the karmada.
+func (ctrl *KarmadaController) ensureMonitoringCert(karmada *installv1alpha1.Karmada) error {
+	name := generateMonitoringCertSecretName(karmada)
+	notAfter := time.Now().Add(certificateValidity(karmada)).UTC()
+	config := certs.NewCertConfig(monitoringUser, []string{}, certutil.AltNames{}, &notAfter)
+	config.Usages = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	if karmada.Spec.Certificates.IssuerRef != nil {
+		ready, err := ctrl.ensureIssuedCertificate(karmada, name, config)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			return fmt.Errorf("waiting for the certificate %s to be issued", name)
+		}
+		return nil
+	}
+
+	certData, err := ctrl.getSecretData(karmada.Namespace, generateCertSecretName(karmada))
+	if err != nil {
+		return err
+	}
+	caCert, caKey, err := certs.ParseCertAndKey(certData["ca.crt"], certData["ca.key"])
+	if err != nil {
+		return fmt.Errorf("failed to load the CA of the karmada: %v", err)
+	}
+
+	current, err := ctrl.getSecretData(karmada.Namespace, name)
+	if err != nil {
+		return err
+	}
+	if cert, _, err := certs.ParseCertAndKey(current[corev1.TLSCertKey], current[corev1.TLSPrivateKeyKey]); err == nil {
+		if certs.CertRenewalReason(cert, caCert, config, certificateRenewBefore(karmada)) == "" {
+			return nil
+		}
+	}
+
+	cert, key, err := certs.NewCertAndKey(caCert, caKey, config)
+	if err != nil {
+		return fmt.Errorf("failed to sign the monitoring certificate: %v", err)
+	}
+	crt, keyData, err := certs.EncodeCertAndKeyPEM(cert, key)
+	if err != nil {
+		return err
+	}
+	return ctrl.ensureCertSecret(karmada, name, map[string][]byte{
+		corev1.TLSCertKey:       crt,
+		corev1.TLSPrivateKeyKey: keyData,
+		"ca.crt":                certData["ca.crt"],
+	})
+}
+
+// ensureMonitoringRBAC allows the user of the monitoring certificate to get the metrics of the karmada-apiserver.
+func (ctrl *KarmadaController) ensureMonitoringRBAC(karmada *installv1alpha1.Karmada) error {
+	config, err := ctrl.GenerateClientConfig(karmada)
+	if err != nil {
+		return err
+	}
+	karmadaClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	if err := clientutil.ApplyClusterRole(karmadaClient, &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: monitoringUser},
+		Rules: []rbacv1.PolicyRule{
+			{NonResourceURLs: []string{"/metrics"}, Verbs: []string{"get"}},
+		},
+	}); err != nil {
+		return err
+	}
+	return clientutil.ApplyClusterRoleBinding(karmadaClient, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: monitoringUser},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     monitoringUser,
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: monitoringUser},
+		},
+	})
+}
+
+// karmadaMonitoringLabels returns the labels of the monitoring objects of the karmada.
+func karmadaMonitoringLabels(karmada *installv1alpha1.Karmada) map[string]string {
+	return map[string]string{constants.KarmadaLabel: karmada.Name}
+}
+
+// karmadaMonitoringObjects returns the monitoring objects of the components of the karmada.
+func karmadaMonitoringObjects(karmada *installv1alpha1.Karmada) []*unstructured.Unstructured {
+	monitoring := karmada.Spec.Monitoring
+	interval := util.MonitoringInterval(monitoring)
+	ownerLabels := karmadaMonitoringLabels(karmada)
+
+	apiServerName := karmadaComponentName(karmada, constants.KarmadaComponentKubeAPIServer)
+	monitoringSecret := generateMonitoringCertSecretName(karmada)
+	objs := []*unstructured.Unstructured{
+		util.MonitoringObject("ServiceMonitor", apiServerName, karmada.Namespace, monitoring, ownerLabels, map[string]interface{}{
+			"selector": util.MatchLabels(karmadaComponentLabels(karmada, constants.KarmadaComponentKubeAPIServer)),
+			"endpoints": []interface{}{
+				map[string]interface{}{
+					"port":     "server",
+					"scheme":   "https",
+					"interval": interval,
+					"tlsConfig": map[string]interface{}{
+						"serverName": fmt.Sprintf("%s.%s.svc", apiServerName, karmada.Namespace),
+						"ca":         map[string]interface{}{"secret": util.SecretKeySelector(monitoringSecret, "ca.crt")},
+						"cert":       map[string]interface{}{"secret": util.SecretKeySelector(monitoringSecret, corev1.TLSCertKey)},
+						"keySecret":  util.SecretKeySelector(monitoringSecret, corev1.TLSPrivateKeyKey),
+					},
+				},
+			},
+		}),
+		karmadaPodMonitor(karmada, constants.KarmadaComponentControllerManager, karmadaControllerManagerMetricsPort),
+		karmadaPodMonitor(karmada, constants.KarmadaComponentScheduler, karmadaSchedulerMetricsPort),
+		util.MonitoringObject("ServiceMonitor", karmadaComponentName(karmada, constants.KarmadaComponentSchedulerEstimator), karmada.Namespace, monitoring, ownerLabels, map[string]interface{}{
+			"selector": util.MatchLabels(karmadaComponentLabels(karmada, constants.KarmadaComponentSchedulerEstimator)),
+			"endpoints": []interface{}{
+				map[string]interface{}{"port": "metrics", "interval": interval},
+			},
+		}),
+	}
+
+	// The metrics of the etcd are served on its client port, which requires a client certificate.
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	if karmada.Spec.Etcd.Local != nil {
+		certSecret := generateCertSecretName(karmada)
+		objs = append(objs, util.MonitoringObject("ServiceMonitor", etcdName, karmada.Namespace, monitoring, ownerLabels, map[string]interface{}{
+			"selector": util.MatchLabels(karmadaComponentLabels(karmada, constants.KarmadaComponentEtcd)),
+			"endpoints": []interface{}{
+				map[string]interface{}{
+					"port":     "client",
+					"scheme":   "https",
+					"interval": interval,
+					"tlsConfig": map[string]interface{}{
+						"serverName": fmt.Sprintf("%s.%s.svc", etcdName, karmada.Namespace),
+						"ca":         map[string]interface{}{"secret": util.SecretKeySelector(certSecret, "etcd-ca.crt")},
+						"cert":       map[string]interface{}{"secret": util.SecretKeySelector(certSecret, "etcd-client.crt")},
+						"keySecret":  util.SecretKeySelector(certSecret, "etcd-client.key"),
+					},
+				},
+			},
+		}))
+	}
+
+	if !monitoring.DisableAlerts {
+		objs = append(objs, util.MonitoringObject("PrometheusRule", karmadaComponentName(karmada, "karmada-alerts"), karmada.Namespace, monitoring, ownerLabels, map[string]interface{}{
+			"groups": []interface{}{
+				map[string]interface{}{
+					"name":  fmt.Sprintf("karmada.%s.%s", karmada.Namespace, karmada.Name),
+					"rules": karmadaAlertingRules(karmada, apiServerName, etcdName),
+				},
+			},
+		}))
+	}
+	return objs
+}
+
+// karmadaPodMonitor returns the PodMonitor of a component of the karmada which serves its metrics on the port.
+func karmadaPodMonitor(karmada *installv1alpha1.Karmada, component string, port int) *unstructured.Unstructured {
+	name := karmadaComponentName(karmada, component)
+	return util.MonitoringObject("PodMonitor", name, karmada.Namespace, karmada.Spec.Monitoring, karmadaMonitoringLabels(karmada), map[string]interface{}{
+		"selector": util.MatchLabels(map[string]string{"app": name}),
+		"podMetricsEndpoints": []interface{}{
+			map[string]interface{}{
+				"targetPort": int64(port),
+				"path":       "/metrics",
+				"interval":   util.MonitoringInterval(karmada.Spec.Monitoring),
+			},
+		},
+	})
+}
+
+// karmadaAlertingRules returns the default alerts of the karmada. The certificates are renewed by firefly before
+// they expire, the alert of the expiry fires when half of the renewal window has passed without a renewal.
+func karmadaAlertingRules(karmada *installv1alpha1.Karmada, apiServerName, etcdName string) []interface{} {
+	rules := []interface{}{
+		util.AlertingRule("KarmadaAPIServerDown",
+			fmt.Sprintf(`absent(up{namespace=%q, job=%q} == 1)`, karmada.Namespace, apiServerName),
+			"5m", "critical",
+			fmt.Sprintf("The karmada-apiserver of the karmada %s/%s is unavailable.", karmada.Namespace, karmada.Name)),
+		util.AlertingRule("KarmadaCertificateExpiringSoon",
+			fmt.Sprintf(`firefly_karmada_certificate_expiration_timestamp_seconds{namespace=%q, name=%q} - time() < %d`,
+				karmada.Namespace, karmada.Name, int64((certificateRenewBefore(karmada)/2).Seconds())),
+			"1h", "warning",
+			fmt.Sprintf("The certificate {{ $labels.certificate }} of the karmada %s/%s expires soon and hasn't been renewed.", karmada.Namespace, karmada.Name)),
+	}
+	if karmada.Spec.Etcd.Local != nil {
+		rules = append(rules, util.AlertingRule("KarmadaEtcdNoLeader",
+			fmt.Sprintf(`etcd_server_has_leader{namespace=%q, job=%q} == 0`, karmada.Namespace, etcdName),
+			"1m", "critical",
+			fmt.Sprintf("The etcd member {{ $labels.pod }} of the karmada %s/%s has no leader.", karmada.Namespace, karmada.Name)))
+	}
+	return rules
+}
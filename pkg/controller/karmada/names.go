@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	"github.com/carlory/firefly/pkg/util"
+)
+
+// karmadaComponentName returns the name of a component of the karmada, see util.KarmadaComponentName.
+func karmadaComponentName(karmada *installv1alpha1.Karmada, component string) string {
+	return util.KarmadaComponentName(karmada, component)
+}
+
+// karmadaComponentNames returns the names of the given components of the karmada.
+func karmadaComponentNames(karmada *installv1alpha1.Karmada, components ...string) []string {
+	names := make([]string, 0, len(components))
+	for _, component := range components {
+		names = append(names, karmadaComponentName(karmada, component))
+	}
+	return names
+}
+
+// karmadaComponentLabels returns the labels of the services of a component which is monitored, they select the
+// services in the ServiceMonitor of the component.
+func karmadaComponentLabels(karmada *installv1alpha1.Karmada, component string) map[string]string {
+	return map[string]string{
+		constants.KarmadaLabel:   karmada.Name,
+		constants.ComponentLabel: component,
+	}
+}
+
+// GenerateKubeConfigSecretName returns the name of the secret which holds the admin kubeconfig of the karmada.
+func GenerateKubeConfigSecretName(karmada *installv1alpha1.Karmada) string {
+	return util.KarmadaKubeConfigSecretName(karmada)
+}
+
+// generateExternalKubeConfigSecretName returns the name of the secret which holds the kubeconfig used from
+// outside of the host cluster.
+func generateExternalKubeConfigSecretName(karmada *installv1alpha1.Karmada) string {
+	return karmadaComponentName(karmada, "karmada-external-kubeconfig")
+}
+
+// generateCertSecretName returns the name of the secret which holds the certificates of the karmada.
+func generateCertSecretName(karmada *installv1alpha1.Karmada) string {
+	return karmadaComponentName(karmada, "karmada-cert")
+}
+
+// generateMonitoringCertSecretName returns the name of the secret which holds the client certificate that the
+// metrics of the karmada-apiserver are scraped with.
+func generateMonitoringCertSecretName(karmada *installv1alpha1.Karmada) string {
+	return issuedCertificateName(karmada, "monitoring")
+}
+
+// generateAuditPolicyConfigMapName returns the name of the configmap which holds the audit policy of the
+// kube-apiserver component.
+func generateAuditPolicyConfigMapName(karmada *installv1alpha1.Karmada) string {
+	return karmadaComponentName(karmada, constants.KarmadaComponentKubeAPIServer+"-audit-policy")
+}
+
+// generateAuditLogVolumeName returns the name of the persistent volume claim which the audit logs of the
+// kube-apiserver component are written to.
+func generateAuditLogVolumeName(karmada *installv1alpha1.Karmada) string {
+	return karmadaComponentName(karmada, constants.KarmadaComponentKubeAPIServer+"-audit-logs")
+}
+
+// generateComponentCertSecretName returns the name of the secret which holds the certificates of the component.
+func generateComponentCertSecretName(karmada *installv1alpha1.Karmada, component string) string {
+	return karmadaComponentName(karmada, component+"-cert")
+}
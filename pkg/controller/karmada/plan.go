@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+)
+
+const (
+	reasonPaused    = "Paused"
+	reasonPlanning  = "Planning"
+	reasonRestoring = "Restoring"
+)
+
+// pause marks the reconciliation of the karmada as paused. If a plan is requested, the changes which the
+// reconciliation would make are computed and reported in the status of the karmada instead.
+func (ctrl *KarmadaController) pause(karmada *installv1alpha1.Karmada) error {
+	// The objects of the etcd are rebuilt by the restore, so they mustn't be reconciled until it's done.
+	if restore := karmada.Annotations[constants.RestoreAnnotation]; restore != "" {
+		karmada.Status.Plan = nil
+		ctrl.setCondition(karmada, installv1alpha1.KarmadaConditionPaused, metav1.ConditionTrue, reasonRestoring, fmt.Sprintf("The reconciliation is paused while the etcd is restored by the KarmadaRestore %s", restore))
+		return nil
+	}
+	if !karmada.Spec.Plan {
+		karmada.Status.Plan = nil
+		ctrl.setCondition(karmada, installv1alpha1.KarmadaConditionPaused, metav1.ConditionTrue, reasonPaused, "The reconciliation is paused by spec.paused")
+		return nil
+	}
+
+	ctrl.setCondition(karmada, installv1alpha1.KarmadaConditionPaused, metav1.ConditionTrue, reasonPlanning, "The reconciliation is paused by spec.plan, see status.plan for the pending changes")
+	plan, err := ctrl.planPhases(karmada)
+	if err != nil {
+		return err
+	}
+	karmada.Status.Plan = plan
+	return nil
+}
+
+// resume removes the paused condition and the plan from the status of the karmada.
+func resume(karmada *installv1alpha1.Karmada) {
+	meta.RemoveStatusCondition(&karmada.Status.Conditions, installv1alpha1.KarmadaConditionPaused)
+	karmada.Status.Plan = nil
+}
+
+// planPhases runs the phases against dry-run clients and returns the changes they would make. The phases
+// reconcile the spec of the karmada directly, regardless of the progress of an upgrade. The computation
+// stops at the first phase which fails, as the following phases may depend on its objects.
+func (ctrl *KarmadaController) planPhases(karmada *installv1alpha1.Karmada) (*installv1alpha1.PlanStatus, error) {
+	plan := clientutil.NewPlan()
+	planner, err := ctrl.planner(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	// The phases set the conditions and the certificates in the status, which mustn't be persisted by a plan.
+	planned := karmada.DeepCopy()
+	status := &installv1alpha1.PlanStatus{ObservedGeneration: karmada.Generation}
+	for _, p := range planner.phases() {
+		if err := p.ensure(planned); err != nil {
+			klog.ErrorS(err, "Failed to plan karmada", "karmada", klog.KObj(karmada), "condition", p.conditionType)
+			status.Message = fmt.Sprintf("The changes from the %s phase on can't be computed: %v", p.conditionType, err)
+			break
+		}
+	}
+	status.Changes = plan.Changes()
+	return status, nil
+}
+
+// planner returns a copy of the controller whose clients record their changes in the plan instead of
+// applying them, and whose events are dropped.
+func (ctrl *KarmadaController) planner(plan *clientutil.Plan) (*KarmadaController, error) {
+	config := plan.WrapConfig(ctrl.config, "")
+	client, err := clientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	planner := *ctrl
+	planner.client = client
+	planner.dynamicClient = dynamicClient
+	planner.plan = plan
+	planner.eventRecorder = &record.FakeRecorder{}
+	return &planner, nil
+}
@@ -0,0 +1,538 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/component-base/metrics/prometheus/ratelimiter"
+	"k8s.io/klog/v2"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	fireflyclient "github.com/carlory/firefly/pkg/generated/clientset/versioned"
+	installinformers "github.com/carlory/firefly/pkg/generated/informers/externalversions/install/v1alpha1"
+	installlisters "github.com/carlory/firefly/pkg/generated/listers/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/metrics"
+	"github.com/carlory/firefly/pkg/scheme"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+)
+
+// restoreRequeueInterval is the interval in which the progress of a restore is checked.
+const restoreRequeueInterval = 5 * time.Second
+
+// NewKarmadaRestoreController returns a new *KarmadaRestoreController.
+func NewKarmadaRestoreController(
+	client clientset.Interface,
+	fireflyClient fireflyclient.Interface,
+	restoreInformer installinformers.KarmadaRestoreInformer,
+	backupInformer installinformers.KarmadaBackupInformer,
+	karmadaInformer installinformers.KarmadaInformer) (*KarmadaRestoreController, error) {
+	broadcaster := record.NewBroadcaster()
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "karmada-restore-controller"})
+
+	if client != nil && client.CoreV1().RESTClient().GetRateLimiter() != nil {
+		ratelimiter.RegisterMetricAndTrackRateLimiterUsage("karmada_restore_controller", client.CoreV1().RESTClient().GetRateLimiter())
+	}
+	metrics.Register()
+
+	ctrl := &KarmadaRestoreController{
+		client:           client,
+		fireflyClient:    fireflyClient,
+		restoresLister:   restoreInformer.Lister(),
+		restoresSynced:   restoreInformer.Informer().HasSynced,
+		backupsLister:    backupInformer.Lister(),
+		backupsSynced:    backupInformer.Informer().HasSynced,
+		karmadasLister:   karmadaInformer.Lister(),
+		karmadasSynced:   karmadaInformer.Informer().HasSynced,
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "karmadarestore"),
+		workerLoopPeriod: time.Second,
+		eventBroadcaster: broadcaster,
+		eventRecorder:    recorder,
+	}
+
+	restoreInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ctrl.enqueue,
+		UpdateFunc: func(old, cur interface{}) { ctrl.enqueue(cur) },
+		DeleteFunc: ctrl.enqueue,
+	})
+
+	return ctrl, nil
+}
+
+// KarmadaRestoreController restores the etcd of the karmadas from the snapshots of their backups.
+//
+// While a karmada is restored, its reconciliation is paused by the restore annotation. The etcd statefulset
+// and its data volumes are deleted, then the statefulset is recreated with init containers which restore
+// the data of each member from the snapshot. When the etcd is ready, the components of the karmada are
+// restarted to drop the state they cached from the previous data, and the reconciliation is resumed.
+type KarmadaRestoreController struct {
+	client           clientset.Interface
+	fireflyClient    fireflyclient.Interface
+	eventBroadcaster record.EventBroadcaster
+	eventRecorder    record.EventRecorder
+
+	restoresLister installlisters.KarmadaRestoreLister
+	restoresSynced cache.InformerSynced
+	backupsLister  installlisters.KarmadaBackupLister
+	backupsSynced  cache.InformerSynced
+	karmadasLister installlisters.KarmadaLister
+	karmadasSynced cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+
+	// workerLoopPeriod is the time between worker runs.
+	workerLoopPeriod time.Duration
+}
+
+// Run will not return until stopCh is closed. workers determines how many
+// restores will be handled in parallel.
+func (ctrl *KarmadaRestoreController) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+
+	ctrl.eventBroadcaster.StartStructuredLogging(0)
+	ctrl.eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: ctrl.client.CoreV1().Events("")})
+	defer ctrl.eventBroadcaster.Shutdown()
+
+	defer ctrl.queue.ShutDown()
+
+	klog.Infof("Starting karmada restore controller")
+	defer klog.Infof("Shutting down karmada restore controller")
+
+	if !cache.WaitForNamedCacheSync("karmadarestore", ctx.Done(), ctrl.restoresSynced, ctrl.backupsSynced, ctrl.karmadasSynced) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, ctrl.worker, ctrl.workerLoopPeriod)
+	}
+	<-ctx.Done()
+}
+
+func (ctrl *KarmadaRestoreController) worker(ctx context.Context) {
+	for ctrl.processNextWorkItem(ctx) {
+	}
+}
+
+func (ctrl *KarmadaRestoreController) processNextWorkItem(ctx context.Context) bool {
+	key, quit := ctrl.queue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.queue.Done(key)
+
+	startTime := time.Now()
+	err := ctrl.syncRestore(ctx, key.(string))
+	metrics.ObserveReconcile("karmadarestore", metrics.PhaseSync, startTime, err)
+	if err == nil {
+		ctrl.queue.Forget(key)
+		return true
+	}
+	if ctrl.queue.NumRequeues(key) < maxRetries {
+		klog.V(2).InfoS("Error syncing karmada restore, retrying", "karmadaRestore", key, "err", err)
+		ctrl.queue.AddRateLimited(key)
+		return true
+	}
+	utilruntime.HandleError(err)
+	klog.V(2).InfoS("Dropping karmada restore out of the queue", "karmadaRestore", key, "err", err)
+	ctrl.queue.Forget(key)
+	return true
+}
+
+func (ctrl *KarmadaRestoreController) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	ctrl.queue.Add(key)
+}
+
+func (ctrl *KarmadaRestoreController) syncRestore(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	restore, err := ctrl.restoresLister.KarmadaRestores(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		klog.V(2).InfoS("Karmada restore has been deleted", "karmadaRestore", klog.KRef(namespace, name))
+		// A restore which is deleted while it's in progress mustn't keep the karmada paused.
+		return ctrl.releaseKarmadas(ctx, namespace, name)
+	}
+	if err != nil {
+		return err
+	}
+	if restore.Status.Phase == installv1alpha1.RestorePhaseSucceeded || restore.Status.Phase == installv1alpha1.RestorePhaseFailed {
+		return nil
+	}
+
+	restore = restore.DeepCopy()
+	old := restore.DeepCopy()
+	syncErr := ctrl.reconcile(ctx, restore)
+	if !equality.Semantic.DeepEqual(old.Status, restore.Status) {
+		if _, err := ctrl.fireflyClient.InstallV1alpha1().KarmadaRestores(namespace).UpdateStatus(ctx, restore, metav1.UpdateOptions{}); err != nil {
+			klog.ErrorS(err, "Failed to update karmada restore status", "karmadaRestore", klog.KObj(restore))
+			if syncErr == nil {
+				syncErr = err
+			}
+		}
+	}
+	if syncErr != nil {
+		return syncErr
+	}
+	if restore.Status.Phase != installv1alpha1.RestorePhaseSucceeded && restore.Status.Phase != installv1alpha1.RestorePhaseFailed {
+		ctrl.queue.AddAfter(key, restoreRequeueInterval)
+	}
+	return nil
+}
+
+// reconcile moves the restore forward by one step of its phase.
+func (ctrl *KarmadaRestoreController) reconcile(ctx context.Context, restore *installv1alpha1.KarmadaRestore) error {
+	backup, err := ctrl.backupsLister.KarmadaBackups(restore.Namespace).Get(restore.Spec.BackupName)
+	if errors.IsNotFound(err) {
+		return ctrl.fail(ctx, restore, fmt.Sprintf("The KarmadaBackup %s is not found", restore.Spec.BackupName))
+	}
+	if err != nil {
+		return err
+	}
+	karmada, err := ctrl.karmadasLister.Karmadas(restore.Namespace).Get(backup.Spec.KarmadaName)
+	if errors.IsNotFound(err) {
+		return ctrl.fail(ctx, restore, fmt.Sprintf("The karmada %s of the backup is not found", backup.Spec.KarmadaName))
+	}
+	if err != nil {
+		return err
+	}
+
+	switch restore.Status.Phase {
+	case "", installv1alpha1.RestorePhasePending:
+		return ctrl.start(ctx, restore, backup, karmada)
+	case installv1alpha1.RestorePhaseRestoring:
+		ready, err := ctrl.restoreEtcd(karmada, backup, restore)
+		if err != nil || !ready {
+			return err
+		}
+		klog.InfoS("Restarting karmada components after the etcd is restored", "karmadaRestore", klog.KObj(restore), "karmada", klog.KObj(karmada))
+		if err := ctrl.restartComponents(karmada); err != nil {
+			return err
+		}
+		restore.Status.Phase = installv1alpha1.RestorePhaseRestarting
+		restore.Status.Message = "The etcd is restored, waiting for the components to be restarted"
+		return nil
+	case installv1alpha1.RestorePhaseRestarting:
+		ready, err := ctrl.componentsReady(karmada)
+		if err != nil || !ready {
+			return err
+		}
+		if err := ctrl.releaseKarmada(ctx, karmada, restore.Name); err != nil {
+			return err
+		}
+		restore.Status.Phase = installv1alpha1.RestorePhaseSucceeded
+		restore.Status.Message = fmt.Sprintf("The karmada %s is restored from the snapshot %s", karmada.Name, restore.Status.Snapshot)
+		restore.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+		ctrl.eventRecorder.Event(restore, corev1.EventTypeNormal, "RestoreSucceeded", restore.Status.Message)
+		return nil
+	}
+	return nil
+}
+
+// start validates the restore and pauses the reconciliation of the karmada, unless it's already restored
+// by another restore.
+func (ctrl *KarmadaRestoreController) start(ctx context.Context, restore *installv1alpha1.KarmadaRestore, backup *installv1alpha1.KarmadaBackup, karmada *installv1alpha1.Karmada) error {
+	if karmada.Spec.Etcd.External != nil {
+		return ctrl.fail(ctx, restore, fmt.Sprintf("The karmada %s uses an external etcd which can't be restored", karmada.Name))
+	}
+	// The pods of the etcd are restarted once after the restore, the restored data would be lost with them.
+	if local := karmada.Spec.Etcd.Local; local == nil || local.DataVolume == nil {
+		return ctrl.fail(ctx, restore, fmt.Sprintf("The etcd of the karmada %s has no data volume to restore the data into", karmada.Name))
+	}
+	if err := validateBackupStorage(backup.Spec.Storage); err != nil {
+		return ctrl.fail(ctx, restore, err.Error())
+	}
+	if claimStorage := backup.Spec.Storage.PersistentVolumeClaim; claimStorage != nil {
+		claim, err := ctrl.client.CoreV1().PersistentVolumeClaims(backup.Namespace).Get(ctx, claimStorage.ClaimName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return ctrl.fail(ctx, restore, fmt.Sprintf("The persistent volume claim %s of the KarmadaBackup %s is not found", claimStorage.ClaimName, backup.Name))
+		}
+		if err != nil {
+			return err
+		}
+		if err := validateRestoreClaim(claim, etcdReplicas(karmada)); err != nil {
+			return ctrl.fail(ctx, restore, err.Error())
+		}
+	}
+	snapshot := restoreSnapshot(backup, restore.Spec.Snapshot)
+	if snapshot == "" {
+		return ctrl.fail(ctx, restore, fmt.Sprintf("No successful snapshot %q is found in the KarmadaBackup %s", restore.Spec.Snapshot, backup.Name))
+	}
+
+	if other := karmada.Annotations[constants.RestoreAnnotation]; other != "" && other != restore.Name {
+		restore.Status.Phase = installv1alpha1.RestorePhasePending
+		restore.Status.Message = fmt.Sprintf("Waiting for the KarmadaRestore %s to finish", other)
+		return nil
+	}
+	if karmada.Annotations[constants.RestoreAnnotation] == "" {
+		karmada = karmada.DeepCopy()
+		if karmada.Annotations == nil {
+			karmada.Annotations = map[string]string{}
+		}
+		karmada.Annotations[constants.RestoreAnnotation] = restore.Name
+		if _, err := ctrl.fireflyClient.InstallV1alpha1().Karmadas(karmada.Namespace).Update(ctx, karmada, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	klog.InfoS("Restoring karmada etcd", "karmadaRestore", klog.KObj(restore), "karmada", klog.KObj(karmada), "snapshot", snapshot)
+	restore.Status.Phase = installv1alpha1.RestorePhaseRestoring
+	restore.Status.Snapshot = snapshot
+	restore.Status.StartTime = &metav1.Time{Time: time.Now()}
+	restore.Status.Message = fmt.Sprintf("Restoring the etcd of the karmada %s", karmada.Name)
+	ctrl.eventRecorder.Eventf(restore, corev1.EventTypeNormal, "RestoreStarted", "Restoring the etcd of the karmada %s from the snapshot %s", karmada.Name, snapshot)
+	return nil
+}
+
+// validateRestoreClaim checks that the claim holding the snapshots can be mounted by all the members of the etcd,
+// as each of them restores its data from it and they may run on different nodes.
+func validateRestoreClaim(claim *corev1.PersistentVolumeClaim, replicas int32) error {
+	if replicas <= 1 {
+		return nil
+	}
+	modes := claim.Status.AccessModes
+	if len(modes) == 0 {
+		modes = claim.Spec.AccessModes
+	}
+	for _, mode := range modes {
+		if mode == corev1.ReadWriteMany || mode == corev1.ReadOnlyMany {
+			return nil
+		}
+	}
+	return fmt.Errorf("the persistent volume claim %s can't be mounted by the %d etcd members on different nodes, it must be ReadWriteMany or ReadOnlyMany to restore an etcd with more than one member", claim.Name, replicas)
+}
+
+// fail marks the restore as failed and resumes the reconciliation of the karmada if it's paused by the restore.
+func (ctrl *KarmadaRestoreController) fail(ctx context.Context, restore *installv1alpha1.KarmadaRestore, message string) error {
+	if err := ctrl.releaseKarmadas(ctx, restore.Namespace, restore.Name); err != nil {
+		return err
+	}
+	restore.Status.Phase = installv1alpha1.RestorePhaseFailed
+	restore.Status.Message = message
+	restore.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	ctrl.eventRecorder.Event(restore, corev1.EventTypeWarning, "RestoreFailed", message)
+	return nil
+}
+
+// releaseKarmadas resumes the reconciliation of the karmadas in the namespace which are paused by the given
+// restore. The karmada of a restore may be unknown when its backup is deleted.
+func (ctrl *KarmadaRestoreController) releaseKarmadas(ctx context.Context, namespace, restore string) error {
+	karmadas, err := ctrl.karmadasLister.Karmadas(namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, karmada := range karmadas {
+		if err := ctrl.releaseKarmada(ctx, karmada, restore); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseKarmada resumes the reconciliation of the karmada if it's paused by the given restore.
+func (ctrl *KarmadaRestoreController) releaseKarmada(ctx context.Context, karmada *installv1alpha1.Karmada, restore string) error {
+	if karmada.Annotations[constants.RestoreAnnotation] != restore {
+		return nil
+	}
+	karmada = karmada.DeepCopy()
+	delete(karmada.Annotations, constants.RestoreAnnotation)
+	_, err := ctrl.fireflyClient.InstallV1alpha1().Karmadas(karmada.Namespace).Update(ctx, karmada, metav1.UpdateOptions{})
+	return err
+}
+
+// restoreSnapshot returns the name of the successful snapshot of the backup which is restored, the latest
+// one if no name is given. It returns an empty string if there is no such snapshot.
+func restoreSnapshot(backup *installv1alpha1.KarmadaBackup, name string) string {
+	var latest string
+	for _, snapshot := range backup.Status.Snapshots {
+		if snapshot.Phase != installv1alpha1.SnapshotPhaseSucceeded {
+			continue
+		}
+		if snapshot.Name == name {
+			return name
+		}
+		latest = snapshot.Name
+	}
+	if name != "" {
+		return ""
+	}
+	return latest
+}
+
+// restoreEtcd rebuilds the etcd statefulset of the karmada from the snapshot, one step at a time. The
+// statefulset and its pods are deleted first, then the data volumes, and at last the statefulset is
+// recreated with the data restored from the snapshot. It returns whether the restored etcd is ready.
+func (ctrl *KarmadaRestoreController) restoreEtcd(karmada *installv1alpha1.Karmada, backup *installv1alpha1.KarmadaBackup, restore *installv1alpha1.KarmadaRestore) (bool, error) {
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	sts, err := ctrl.client.AppsV1().StatefulSets(karmada.Namespace).Get(context.TODO(), etcdName, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	if err == nil {
+		if sts.Annotations[constants.RestoreAnnotation] == restore.Name {
+			return statefulSetReady(sts), nil
+		}
+		klog.InfoS("Deleting etcd statefulset to restore it", "karmadaRestore", klog.KObj(restore), "statefulset", klog.KObj(sts))
+		return false, ctrl.client.AppsV1().StatefulSets(karmada.Namespace).Delete(context.TODO(), etcdName, metav1.DeleteOptions{})
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{"app": etcdName}).String()
+	pods, err := ctrl.client.CoreV1().Pods(karmada.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return false, err
+	}
+	if len(pods.Items) > 0 {
+		klog.V(2).InfoS("Waiting for etcd pods to be deleted", "karmadaRestore", klog.KObj(restore), "pods", len(pods.Items))
+		return false, nil
+	}
+	claims, err := ctrl.client.CoreV1().PersistentVolumeClaims(karmada.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return false, err
+	}
+	if len(claims.Items) > 0 {
+		klog.V(2).InfoS("Deleting etcd data volumes", "karmadaRestore", klog.KObj(restore), "claims", len(claims.Items))
+		return false, ctrl.client.CoreV1().PersistentVolumeClaims(karmada.Namespace).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: selector})
+	}
+
+	klog.InfoS("Creating etcd statefulset with restored data", "karmadaRestore", klog.KObj(restore), "snapshot", restore.Status.Snapshot)
+	if err := ensureEtcdInitialCluster(ctrl.client, karmada, etcdInitialCluster(karmada, etcdReplicas(karmada)), etcdInitialClusterStateNew); err != nil {
+		return false, err
+	}
+	sts = etcdStatefulSet(karmada, etcdReplicas(karmada))
+	setEtcdDataVolume(sts)
+	sts.Annotations[constants.RestoreAnnotation] = restore.Name
+	setEtcdRestoreContainers(sts, karmada, backup, restore.Status.Snapshot)
+	return false, clientutil.ApplyStatefulSet(ctrl.client, sts)
+}
+
+// setEtcdRestoreContainers adds the init containers which restore the data of each etcd member from the
+// snapshot. The data is only restored into an empty data volume, so the members keep their data when
+// they are restarted afterwards.
+func setEtcdRestoreContainers(sts *appsv1.StatefulSet, karmada *installv1alpha1.Karmada, backup *installv1alpha1.KarmadaBackup, snapshot string) {
+	etcdName := karmadaComponentName(karmada, constants.KarmadaComponentEtcd)
+	image, pullPolicy, _ := etcdImage(karmada)
+	podSpec := &sts.Spec.Template.Spec
+	skip := "set -e\nif [ -d /var/lib/etcd/member ]; then echo \"The data of the member exists, skip restoring\"; exit 0; fi\n"
+
+	file := fmt.Sprintf("%s/%s.db", snapshotsPath, snapshot)
+	snapshots := corev1.Volume{
+		Name:         "snapshots",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	if claim := backup.Spec.Storage.PersistentVolumeClaim; claim != nil {
+		file = fmt.Sprintf("%s/%s/%s.db", snapshotsPath, backupDirectory(backup), snapshot)
+		snapshots.VolumeSource = corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim.ClaimName, ReadOnly: true},
+		}
+	}
+	podSpec.Volumes = append(podSpec.Volumes, snapshots)
+	dataMount := corev1.VolumeMount{Name: etcdDataVolumeName, MountPath: "/var/lib/etcd"}
+
+	if backup.Spec.Storage.S3 != nil {
+		download := s3Container(karmada, backup, "download-snapshot", fmt.Sprintf("$MC cp %s/%s.db %s\n", s3Path(backup), snapshot, file))
+		download.Command[2] = skip + download.Command[2]
+		download.VolumeMounts = append(download.VolumeMounts, dataMount)
+		podSpec.InitContainers = append(podSpec.InitContainers, download)
+	}
+
+	script := skip + fmt.Sprintf(`rm -rf /var/lib/etcd/restore
+etcdctl snapshot restore %s --name "$POD_NAME" --initial-cluster %s --initial-advertise-peer-urls "https://$POD_NAME.%s.%s.svc:2380" --data-dir /var/lib/etcd/restore
+mv /var/lib/etcd/restore/member /var/lib/etcd/member
+rm -rf /var/lib/etcd/restore
+`, file, etcdInitialCluster(karmada, *sts.Spec.Replicas), etcdName, karmada.Namespace)
+	podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+		Name:            "restore-snapshot",
+		Image:           image,
+		ImagePullPolicy: pullPolicy,
+		Command:         []string{"/bin/sh", "-c", script},
+		Env: []corev1.EnvVar{
+			{Name: "ETCDCTL_API", Value: "3"},
+			{
+				Name: "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			dataMount,
+			{Name: "snapshots", MountPath: snapshotsPath, ReadOnly: true},
+		},
+	})
+}
+
+// restartComponents restarts the components of the karmada, as they cache the state of the previous data.
+func (ctrl *KarmadaRestoreController) restartComponents(karmada *installv1alpha1.Karmada) error {
+	restartedAt := time.Now().Format(time.RFC3339)
+	for _, name := range certificateDeployments(karmada) {
+		deployment, err := ctrl.client.AppsV1().Deployments(karmada.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if deployment.Spec.Template.Annotations == nil {
+			deployment.Spec.Template.Annotations = map[string]string{}
+		}
+		deployment.Spec.Template.Annotations[constants.RestartedAtAnnotation] = restartedAt
+		if _, err := ctrl.client.AppsV1().Deployments(karmada.Namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	ctrl.eventRecorder.Event(karmada, corev1.EventTypeNormal, "RestartingComponents", "Restarting the components to use the restored etcd data")
+	return nil
+}
+
+// componentsReady returns whether all the restarted components of the karmada are ready.
+func (ctrl *KarmadaRestoreController) componentsReady(karmada *installv1alpha1.Karmada) (bool, error) {
+	for _, name := range certificateDeployments(karmada) {
+		deployment, err := ctrl.client.AppsV1().Deployments(karmada.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if !deploymentReady(deployment) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
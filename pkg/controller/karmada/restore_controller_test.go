@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateRestoreClaim(t *testing.T) {
+	tests := []struct {
+		name        string
+		specModes   []corev1.PersistentVolumeAccessMode
+		statusModes []corev1.PersistentVolumeAccessMode
+		replicas    int32
+		wantErr     bool
+	}{
+		{
+			name:      "read write once with a single member",
+			specModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			replicas:  1,
+		},
+		{
+			name:      "read write once with three members",
+			specModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			replicas:  3,
+			wantErr:   true,
+		},
+		{
+			name:      "read write once pod with three members",
+			specModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod},
+			replicas:  3,
+			wantErr:   true,
+		},
+		{
+			name:      "read write many with three members",
+			specModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			replicas:  3,
+		},
+		{
+			name:      "read only many with three members",
+			specModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce, corev1.ReadOnlyMany},
+			replicas:  3,
+		},
+		{
+			name:        "access modes of the bound volume",
+			specModes:   []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			statusModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			replicas:    3,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "karmada-backups"},
+				Spec:       corev1.PersistentVolumeClaimSpec{AccessModes: tt.specModes},
+				Status:     corev1.PersistentVolumeClaimStatus{AccessModes: tt.statusModes},
+			}
+			if err := validateRestoreClaim(claim, tt.replicas); (err != nil) != tt.wantErr {
+				t.Errorf("validateRestoreClaim() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
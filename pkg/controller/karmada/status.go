@@ -0,0 +1,325 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+	"github.com/carlory/firefly/pkg/metrics"
+)
+
+const (
+	// notReadyRequeueInterval is the time after which a karmada which is not ready yet will be requeued.
+	notReadyRequeueInterval = 10 * time.Second
+
+	reasonReady          = "Ready"
+	reasonNotReady       = "NotReady"
+	reasonReconcileError = "ReconcileError"
+	reasonPending        = "Pending"
+)
+
+// phase is a step of the karmada reconciliation which is reported as a condition of the karmada.
+type phase struct {
+	// conditionType is the type of the condition which reports the result of the phase.
+	conditionType string
+	// upgradeStep is the step of an upgrade in which the components of the phase are upgraded. The phases
+	// which aren't versioned have no upgrade step.
+	upgradeStep installv1alpha1.UpgradeStep
+	// ensure creates or updates the resources managed by the phase.
+	ensure func(karmada *installv1alpha1.Karmada) error
+	// deployments returns the names of the deployments which must be available for the phase to be ready.
+	deployments func(karmada *installv1alpha1.Karmada) []string
+	// statefulsets returns the names of the statefulsets which must be available for the phase to be ready.
+	statefulsets func(karmada *installv1alpha1.Karmada) []string
+	// check returns the names of the components which are not ready for reasons other than the
+	// availability of their workloads.
+	check func(karmada *installv1alpha1.Karmada) ([]string, error)
+}
+
+// phases returns the phases of the karmada reconciliation in the order they should be executed.
+func (ctrl *KarmadaController) phases() []phase {
+	return []phase{
+		{
+			conditionType: installv1alpha1.KarmadaConditionCertsReady,
+			ensure:        ctrl.EnsureCerts,
+		},
+		{
+			conditionType: installv1alpha1.KarmadaConditionEtcdReady,
+			ensure:        ctrl.EnsureEtcd,
+			statefulsets: func(karmada *installv1alpha1.Karmada) []string {
+				if karmada.Spec.Etcd.External != nil {
+					return nil
+				}
+				return []string{karmadaComponentName(karmada, constants.KarmadaComponentEtcd)}
+			},
+			check: ctrl.checkEtcdMembers,
+		},
+		{
+			conditionType: installv1alpha1.KarmadaConditionKubeAPIServerReady,
+			upgradeStep:   installv1alpha1.UpgradeStepKubeAPIServer,
+			ensure:        ctrl.EnsureAPIServer,
+			deployments: func(karmada *installv1alpha1.Karmada) []string {
+				return []string{karmadaComponentName(karmada, constants.KarmadaComponentKubeAPIServer)}
+			},
+		},
+		{
+			conditionType: installv1alpha1.KarmadaConditionAggregatedAPIServerReady,
+			upgradeStep:   installv1alpha1.UpgradeStepAggregatedAPIServer,
+			ensure:        ctrl.EnsureKarmadaAggregatedAPIServer,
+			deployments: func(karmada *installv1alpha1.Karmada) []string {
+				return []string{karmadaComponentName(karmada, constants.KarmadaComponentAggregratedAPIServer)}
+			},
+		},
+		{
+			conditionType: installv1alpha1.KarmadaConditionCRDsReady,
+			upgradeStep:   installv1alpha1.UpgradeStepAggregatedAPIServer,
+			ensure:        ctrl.EnsureKarmadaCRDs,
+		},
+		{
+			conditionType: installv1alpha1.KarmadaConditionWebhookReady,
+			upgradeStep:   installv1alpha1.UpgradeStepAggregatedAPIServer,
+			ensure:        ctrl.EnsureKaramdaWebhook,
+			deployments: func(karmada *installv1alpha1.Karmada) []string {
+				return []string{karmadaComponentName(karmada, constants.KarmadaComponentWebhook)}
+			},
+		},
+		{
+			conditionType: installv1alpha1.KarmadaConditionControllerManagersReady,
+			upgradeStep:   installv1alpha1.UpgradeStepControllerManagers,
+			ensure:        ctrl.EnsureControllerManager,
+			deployments: func(karmada *installv1alpha1.Karmada) []string {
+				return karmadaComponentNames(karmada,
+					constants.KarmadaComponentKubeControllerManager,
+					constants.KarmadaComponentControllerManager,
+					constants.FireflyComponentKarmadaManager,
+				)
+			},
+		},
+		{
+			conditionType: installv1alpha1.KarmadaConditionSchedulerReady,
+			upgradeStep:   installv1alpha1.UpgradeStepSchedulers,
+			ensure:        ctrl.EnsureScheduler,
+			deployments: func(karmada *installv1alpha1.Karmada) []string {
+				components := []string{constants.KarmadaComponentScheduler}
+				if isKarmadaDeschedulerEnabled(karmada) {
+					components = append(components, constants.KarmadaComponentDescheduler)
+				}
+				return karmadaComponentNames(karmada, components...)
+			},
+		},
+	}
+}
+
+// reconcilePhases runs all the phases in order and records the result of each phase as a condition
+// of the karmada. The reconciliation stops at the first phase which fails, and the conditions of
+// the remaining phases are marked as pending. While the karmada is upgraded, each phase runs the
+// versions allowed by the progress of the upgrade. It returns whether all the phases are ready and
+// no upgrade is running.
+func (ctrl *KarmadaController) reconcilePhases(karmada *installv1alpha1.Karmada) (bool, error) {
+	waiting := ctrl.startUpgrade(karmada)
+
+	var syncErr error
+	allReady := true
+	stepReady := map[installv1alpha1.UpgradeStep]bool{}
+	for _, p := range ctrl.phases() {
+		if _, ok := stepReady[p.upgradeStep]; !ok {
+			stepReady[p.upgradeStep] = true
+		}
+		if syncErr != nil {
+			allReady = false
+			stepReady[p.upgradeStep] = false
+			ctrl.setCondition(karmada, p.conditionType, metav1.ConditionUnknown, reasonPending, "Waiting for the previous components to be reconciled")
+			continue
+		}
+
+		target := versionedKarmada(karmada, p.upgradeStep)
+		startTime := time.Now()
+		err := p.ensure(target)
+		metrics.ObserveReconcile("karmada", strings.TrimSuffix(p.conditionType, "Ready"), startTime, err)
+		if err != nil {
+			klog.ErrorS(err, "Failed to reconcile karmada", "karmada", klog.KObj(karmada), "condition", p.conditionType)
+			syncErr = err
+			allReady = false
+			stepReady[p.upgradeStep] = false
+			ctrl.setCondition(karmada, p.conditionType, metav1.ConditionFalse, reasonReconcileError, err.Error())
+			continue
+		}
+
+		var notReady []string
+		if p.statefulsets != nil {
+			for _, name := range p.statefulsets(target) {
+				ready, err := ctrl.isStatefulSetReady(karmada.Namespace, name)
+				if err != nil {
+					return false, err
+				}
+				if !ready {
+					notReady = append(notReady, name)
+				}
+			}
+		}
+		if p.deployments != nil {
+			for _, name := range p.deployments(target) {
+				ready, err := ctrl.isDeploymentReady(karmada.Namespace, name)
+				if err != nil {
+					return false, err
+				}
+				if !ready {
+					notReady = append(notReady, name)
+				}
+			}
+		}
+
+		if p.check != nil {
+			names, err := p.check(target)
+			if err != nil {
+				return false, err
+			}
+			notReady = append(notReady, names...)
+		}
+
+		if len(notReady) > 0 {
+			allReady = false
+			stepReady[p.upgradeStep] = false
+			ctrl.setCondition(karmada, p.conditionType, metav1.ConditionFalse, reasonNotReady, fmt.Sprintf("Waiting for %s to be ready", strings.Join(notReady, ", ")))
+			continue
+		}
+		ctrl.setCondition(karmada, p.conditionType, metav1.ConditionTrue, reasonReady, "")
+	}
+
+	if syncErr == nil {
+		done, err := ctrl.rolloutCertificates(karmada)
+		if err != nil {
+			return false, err
+		}
+		if !done {
+			allReady = false
+		}
+		syncErr = ctrl.reconcileMonitoring(karmada)
+	}
+
+	if allReady {
+		ctrl.setCondition(karmada, installv1alpha1.KarmadaConditionReady, metav1.ConditionTrue, reasonReady, "All the components of the karmada are ready")
+	} else {
+		ctrl.setCondition(karmada, installv1alpha1.KarmadaConditionReady, metav1.ConditionFalse, reasonNotReady, "Some components of the karmada are not ready")
+	}
+
+	upgrading := ctrl.progressUpgrade(karmada, stepReady, allReady)
+	if waiting {
+		ctrl.setUpgradeCondition(karmada, metav1.ConditionFalse, reasonPending, "Waiting for all the components to be ready before upgrading")
+	}
+	return allReady && !upgrading && !waiting, syncErr
+}
+
+// setCondition sets the condition of the karmada and records an event if the status of the condition changes.
+func (ctrl *KarmadaController) setCondition(karmada *installv1alpha1.Karmada, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	old := meta.FindStatusCondition(karmada.Status.Conditions, conditionType)
+	if old == nil || old.Status != status || old.Reason != reason {
+		eventType := corev1.EventTypeNormal
+		if status != metav1.ConditionTrue {
+			eventType = corev1.EventTypeWarning
+		}
+		eventMessage := fmt.Sprintf("%s is %s", conditionType, status)
+		if message != "" {
+			eventMessage = fmt.Sprintf("%s: %s", eventMessage, message)
+		}
+		// The pending status is a consequence of another condition which has its own event.
+		if reason != reasonPending {
+			ctrl.eventRecorder.Event(karmada, eventType, reason, eventMessage)
+		}
+	}
+
+	meta.SetStatusCondition(&karmada.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: karmada.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// updateStatus updates the status of the karmada if it's changed.
+func (ctrl *KarmadaController) updateStatus(ctx context.Context, old, karmada *installv1alpha1.Karmada) error {
+	karmada.Status.ObservedGeneration = karmada.Generation
+	if equality.Semantic.DeepEqual(old.Status, karmada.Status) {
+		return nil
+	}
+	_, err := ctrl.fireflyClient.InstallV1alpha1().Karmadas(karmada.Namespace).UpdateStatus(ctx, karmada, metav1.UpdateOptions{})
+	return err
+}
+
+// isDeploymentReady returns whether the latest revision of the deployment is available.
+func (ctrl *KarmadaController) isDeploymentReady(namespace, name string) (bool, error) {
+	deployment, err := ctrl.client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return deploymentReady(deployment), nil
+}
+
+// deploymentReady returns whether the latest revision of the deployment is available.
+func deploymentReady(deployment *appsv1.Deployment) bool {
+	var replicas int32 = 1
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
+
+// isStatefulSetReady returns whether all the replicas of the latest revision of the statefulset are ready.
+func (ctrl *KarmadaController) isStatefulSetReady(namespace, name string) (bool, error) {
+	statefulSet, err := ctrl.client.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return statefulSetReady(statefulSet), nil
+}
+
+// statefulSetReady returns whether all the replicas of the latest revision of the statefulset are ready.
+func statefulSetReady(statefulSet *appsv1.StatefulSet) bool {
+	var replicas int32 = 1
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	updated := statefulSet.Status.UpdatedReplicas == replicas
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		updated = true
+	}
+	return statefulSet.Status.ObservedGeneration >= statefulSet.Generation &&
+		updated &&
+		statefulSet.Status.ReadyReplicas == replicas
+}
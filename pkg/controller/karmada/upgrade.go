@@ -0,0 +1,297 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/klog/v2"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+)
+
+// upgradeStepTimeout is the time after which a step of an upgrade which isn't ready is considered as failed.
+const upgradeStepTimeout = 10 * time.Minute
+
+// upgradeSteps is the steps of an upgrade in the order they are executed. A rollback executes them in the
+// reverse order.
+var upgradeSteps = []installv1alpha1.UpgradeStep{
+	installv1alpha1.UpgradeStepKubeAPIServer,
+	installv1alpha1.UpgradeStepAggregatedAPIServer,
+	installv1alpha1.UpgradeStepControllerManagers,
+	installv1alpha1.UpgradeStepSchedulers,
+}
+
+// supportedKubernetesVersions is the range of the kubernetes v1 minor versions which can be used as the
+// karmada-apiserver by each karmada minor version. The minor versions older than the crd bundles use the
+// oldest bundle, so that they can be upgraded.
+var supportedKubernetesVersions = map[string]struct{ min, max uint }{
+	"1.2": {min: 19, max: 23},
+	"1.3": {min: 19, max: 24},
+}
+
+// upgradeStepIndex returns the position of the step in the upgrade.
+func upgradeStepIndex(step installv1alpha1.UpgradeStep) int {
+	for i, s := range upgradeSteps {
+		if s == step {
+			return i
+		}
+	}
+	return -1
+}
+
+// runningVersions returns the versions which all the components of the karmada are running. A new karmada
+// runs the versions of its spec until they are recorded in its status.
+func runningVersions(karmada *installv1alpha1.Karmada) (string, string) {
+	if karmada.Status.KarmadaVersion == "" && karmada.Status.KubernetesVersion == "" {
+		return karmada.Spec.KarmadaVersion, karmada.Spec.KubernetesVersion
+	}
+	return karmada.Status.KarmadaVersion, karmada.Status.KubernetesVersion
+}
+
+// stepVersions returns the karmada and kubernetes versions which the components of the given upgrade step
+// must run. The components which aren't versioned run the versions of the spec.
+func stepVersions(karmada *installv1alpha1.Karmada, step installv1alpha1.UpgradeStep) (string, string) {
+	if step == "" {
+		return karmada.Spec.KarmadaVersion, karmada.Spec.KubernetesVersion
+	}
+
+	upgrade := karmada.Status.Upgrade
+	if upgrade == nil {
+		return runningVersions(karmada)
+	}
+	current, index := upgradeStepIndex(upgrade.Step), upgradeStepIndex(step)
+	switch upgrade.Phase {
+	case installv1alpha1.UpgradePhaseProgressing:
+		if index <= current {
+			return upgrade.ToKarmadaVersion, upgrade.ToKubernetesVersion
+		}
+		return upgrade.FromKarmadaVersion, upgrade.FromKubernetesVersion
+	case installv1alpha1.UpgradePhaseRollingBack:
+		if index < current {
+			return upgrade.ToKarmadaVersion, upgrade.ToKubernetesVersion
+		}
+		return upgrade.FromKarmadaVersion, upgrade.FromKubernetesVersion
+	}
+	return runningVersions(karmada)
+}
+
+// versionedKarmada returns the karmada whose spec is set to the versions which the components of the given
+// upgrade step must run. The karmada is returned as is if its spec already has these versions.
+func versionedKarmada(karmada *installv1alpha1.Karmada, step installv1alpha1.UpgradeStep) *installv1alpha1.Karmada {
+	karmadaVersion, kubernetesVersion := stepVersions(karmada, step)
+	if karmadaVersion == karmada.Spec.KarmadaVersion && kubernetesVersion == karmada.Spec.KubernetesVersion {
+		return karmada
+	}
+	versioned := karmada.DeepCopy()
+	versioned.Spec.KarmadaVersion = karmadaVersion
+	versioned.Spec.KubernetesVersion = kubernetesVersion
+	return versioned
+}
+
+// startUpgrade starts an upgrade of the karmada if the versions of its spec differ from the running versions.
+// The upgrade is only started once the preflight checks pass and all the components are ready, it returns
+// whether the upgrade is waiting for the components to be ready.
+func (ctrl *KarmadaController) startUpgrade(karmada *installv1alpha1.Karmada) bool {
+	status := &karmada.Status
+	if status.KarmadaVersion == "" && status.KubernetesVersion == "" {
+		return false
+	}
+	fromKarmada, fromKubernetes := status.KarmadaVersion, status.KubernetesVersion
+	toKarmada, toKubernetes := karmada.Spec.KarmadaVersion, karmada.Spec.KubernetesVersion
+	if fromKarmada == toKarmada && fromKubernetes == toKubernetes {
+		return false
+	}
+
+	if upgrade := status.Upgrade; upgrade != nil {
+		// The versions of the spec may change while an upgrade is running, they are picked up once it's finished.
+		if upgrade.Phase == installv1alpha1.UpgradePhaseProgressing || upgrade.Phase == installv1alpha1.UpgradePhaseRollingBack {
+			return false
+		}
+		// A failed upgrade isn't retried until other versions are requested.
+		if upgrade.FromKarmadaVersion == fromKarmada && upgrade.FromKubernetesVersion == fromKubernetes &&
+			upgrade.ToKarmadaVersion == toKarmada && upgrade.ToKubernetesVersion == toKubernetes {
+			return false
+		}
+	}
+
+	upgrade := &installv1alpha1.UpgradeStatus{
+		FromKarmadaVersion:    fromKarmada,
+		FromKubernetesVersion: fromKubernetes,
+		ToKarmadaVersion:      toKarmada,
+		ToKubernetesVersion:   toKubernetes,
+	}
+	if err := upgradePreflight(upgrade); err != nil {
+		upgrade.Phase = installv1alpha1.UpgradePhaseFailed
+		upgrade.Message = fmt.Sprintf("Preflight checks failed: %v", err)
+		status.Upgrade = upgrade
+		ctrl.eventRecorder.Event(karmada, corev1.EventTypeWarning, "UpgradePreflightFailed", upgrade.Message)
+		return false
+	}
+	if !meta.IsStatusConditionTrue(status.Conditions, installv1alpha1.KarmadaConditionReady) {
+		return true
+	}
+
+	now := metav1.Now()
+	upgrade.Phase = installv1alpha1.UpgradePhaseProgressing
+	upgrade.Step = upgradeSteps[0]
+	upgrade.StepStartTime = &now
+	status.Upgrade = upgrade
+	klog.InfoS("Upgrading karmada", "karmada", klog.KObj(karmada), "karmadaVersion", toKarmada, "kubernetesVersion", toKubernetes)
+	ctrl.eventRecorder.Eventf(karmada, corev1.EventTypeNormal, "UpgradeStarted", "Upgrading from karmada %s and kubernetes %s to karmada %s and kubernetes %s",
+		fromKarmada, fromKubernetes, toKarmada, toKubernetes)
+	return false
+}
+
+// progressUpgrade moves the upgrade of the karmada to its next step when the components of the current step
+// are ready, or rolls it back when they aren't ready in time. It records the running versions of a new karmada
+// once all its components are ready, and returns whether an upgrade is still running.
+func (ctrl *KarmadaController) progressUpgrade(karmada *installv1alpha1.Karmada, stepReady map[installv1alpha1.UpgradeStep]bool, allReady bool) bool {
+	status := &karmada.Status
+	upgrade := status.Upgrade
+	if upgrade == nil {
+		if allReady && status.KarmadaVersion == "" && status.KubernetesVersion == "" {
+			status.KarmadaVersion, status.KubernetesVersion = karmada.Spec.KarmadaVersion, karmada.Spec.KubernetesVersion
+		}
+		return false
+	}
+
+	now := metav1.Now()
+	index := upgradeStepIndex(upgrade.Step)
+	switch upgrade.Phase {
+	case installv1alpha1.UpgradePhaseProgressing:
+		if stepReady[upgrade.Step] {
+			if index == len(upgradeSteps)-1 {
+				upgrade.Phase = installv1alpha1.UpgradePhaseSucceeded
+				upgrade.Step = ""
+				upgrade.StepStartTime = nil
+				upgrade.Message = ""
+				status.KarmadaVersion, status.KubernetesVersion = upgrade.ToKarmadaVersion, upgrade.ToKubernetesVersion
+				klog.InfoS("Upgraded karmada", "karmada", klog.KObj(karmada), "karmadaVersion", upgrade.ToKarmadaVersion, "kubernetesVersion", upgrade.ToKubernetesVersion)
+				ctrl.eventRecorder.Eventf(karmada, corev1.EventTypeNormal, "UpgradeSucceeded", "Upgraded to karmada %s and kubernetes %s",
+					upgrade.ToKarmadaVersion, upgrade.ToKubernetesVersion)
+				break
+			}
+			upgrade.Step = upgradeSteps[index+1]
+			upgrade.StepStartTime = &now
+			klog.InfoS("Upgrading karmada step", "karmada", klog.KObj(karmada), "step", upgrade.Step)
+			break
+		}
+		if upgrade.StepStartTime != nil && now.Sub(upgrade.StepStartTime.Time) > upgradeStepTimeout {
+			upgrade.Phase = installv1alpha1.UpgradePhaseRollingBack
+			upgrade.StepStartTime = &now
+			upgrade.Message = fmt.Sprintf("The %s step isn't ready after %s", upgrade.Step, upgradeStepTimeout)
+			ctrl.eventRecorder.Eventf(karmada, corev1.EventTypeWarning, "UpgradeFailed", "%s, rolling back to karmada %s and kubernetes %s",
+				upgrade.Message, upgrade.FromKarmadaVersion, upgrade.FromKubernetesVersion)
+		}
+	case installv1alpha1.UpgradePhaseRollingBack:
+		if !stepReady[upgrade.Step] {
+			break
+		}
+		if index <= 0 {
+			upgrade.Phase = installv1alpha1.UpgradePhaseRolledBack
+			upgrade.Step = ""
+			upgrade.StepStartTime = nil
+			klog.InfoS("Rolled back karmada", "karmada", klog.KObj(karmada), "karmadaVersion", upgrade.FromKarmadaVersion, "kubernetesVersion", upgrade.FromKubernetesVersion)
+			ctrl.eventRecorder.Eventf(karmada, corev1.EventTypeWarning, "UpgradeRolledBack", "Rolled back to karmada %s and kubernetes %s",
+				upgrade.FromKarmadaVersion, upgrade.FromKubernetesVersion)
+			break
+		}
+		upgrade.Step = upgradeSteps[index-1]
+		upgrade.StepStartTime = &now
+		klog.InfoS("Rolling back karmada step", "karmada", klog.KObj(karmada), "step", upgrade.Step)
+	}
+
+	switch upgrade.Phase {
+	case installv1alpha1.UpgradePhaseProgressing:
+		ctrl.setUpgradeCondition(karmada, metav1.ConditionTrue, string(upgrade.Phase), fmt.Sprintf("Upgrading the %s step to karmada %s and kubernetes %s",
+			upgrade.Step, upgrade.ToKarmadaVersion, upgrade.ToKubernetesVersion))
+		return true
+	case installv1alpha1.UpgradePhaseRollingBack:
+		ctrl.setUpgradeCondition(karmada, metav1.ConditionTrue, string(upgrade.Phase), fmt.Sprintf("Rolling back the %s step to karmada %s and kubernetes %s: %s",
+			upgrade.Step, upgrade.FromKarmadaVersion, upgrade.FromKubernetesVersion, upgrade.Message))
+		return true
+	default:
+		ctrl.setUpgradeCondition(karmada, metav1.ConditionFalse, string(upgrade.Phase), upgrade.Message)
+		return false
+	}
+}
+
+// setUpgradeCondition sets the upgrading condition of the karmada. Unlike the other conditions, its events
+// are recorded by the transitions of the upgrade.
+func (ctrl *KarmadaController) setUpgradeCondition(karmada *installv1alpha1.Karmada, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&karmada.Status.Conditions, metav1.Condition{
+		Type:               installv1alpha1.KarmadaConditionUpgrading,
+		Status:             status,
+		ObservedGeneration: karmada.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// upgradePreflight checks that the upgrade is supported. The versions can't be downgraded nor skip a minor
+// version, and the target karmada version must support the target kubernetes version.
+func upgradePreflight(upgrade *installv1alpha1.UpgradeStatus) error {
+	if err := checkVersionSkew("karmada", upgrade.FromKarmadaVersion, upgrade.ToKarmadaVersion); err != nil {
+		return err
+	}
+	if err := checkVersionSkew("kubernetes", upgrade.FromKubernetesVersion, upgrade.ToKubernetesVersion); err != nil {
+		return err
+	}
+
+	karmadaVersion, err := version.ParseGeneric(upgrade.ToKarmadaVersion)
+	if err != nil {
+		return fmt.Errorf("invalid karmada version %q: %v", upgrade.ToKarmadaVersion, err)
+	}
+	kubernetesVersion, err := version.ParseGeneric(upgrade.ToKubernetesVersion)
+	if err != nil {
+		return fmt.Errorf("invalid kubernetes version %q: %v", upgrade.ToKubernetesVersion, err)
+	}
+	supported, ok := supportedKubernetesVersions[fmt.Sprintf("%d.%d", karmadaVersion.Major(), karmadaVersion.Minor())]
+	if !ok {
+		return fmt.Errorf("karmada %s isn't supported", upgrade.ToKarmadaVersion)
+	}
+	if kubernetesVersion.Major() != 1 || kubernetesVersion.Minor() < supported.min || kubernetesVersion.Minor() > supported.max {
+		return fmt.Errorf("karmada %s supports kubernetes v1.%d to v1.%d, but the kubernetes version is %s",
+			upgrade.ToKarmadaVersion, supported.min, supported.max, upgrade.ToKubernetesVersion)
+	}
+	return nil
+}
+
+// checkVersionSkew checks that the component can be upgraded from the given version to the target version.
+func checkVersionSkew(component, from, to string) error {
+	fromVersion, err := version.ParseGeneric(from)
+	if err != nil {
+		return fmt.Errorf("invalid %s version %q: %v", component, from, err)
+	}
+	toVersion, err := version.ParseGeneric(to)
+	if err != nil {
+		return fmt.Errorf("invalid %s version %q: %v", component, to, err)
+	}
+	if toVersion.LessThan(fromVersion) {
+		return fmt.Errorf("downgrading %s from %s to %s isn't supported", component, from, to)
+	}
+	if toVersion.Major() != fromVersion.Major() || toVersion.Minor() > fromVersion.Minor()+1 {
+		return fmt.Errorf("upgrading %s from %s to %s skips a minor version", component, from, to)
+	}
+	return nil
+}
@@ -0,0 +1,345 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karmada
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+)
+
+// upgradingKarmada returns a karmada running karmada v1.2.0 and kubernetes v1.23.0 whose spec requests the
+// given versions.
+func upgradingKarmada(karmadaVersion, kubernetesVersion string, ready bool) *installv1alpha1.Karmada {
+	karmada := &installv1alpha1.Karmada{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "firefly-system"}}
+	karmada.Spec.KarmadaVersion, karmada.Spec.KubernetesVersion = karmadaVersion, kubernetesVersion
+	karmada.Status.KarmadaVersion, karmada.Status.KubernetesVersion = "v1.2.0", "v1.23.0"
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&karmada.Status.Conditions, metav1.Condition{Type: installv1alpha1.KarmadaConditionReady, Status: status, Reason: "Test"})
+	return karmada
+}
+
+// testUpgrade returns an upgrade from karmada v1.2.0 and kubernetes v1.23.0 to karmada v1.3.0 and kubernetes v1.24.0.
+func testUpgrade(phase installv1alpha1.UpgradePhase, step installv1alpha1.UpgradeStep, stepStartTime time.Time) *installv1alpha1.UpgradeStatus {
+	return &installv1alpha1.UpgradeStatus{
+		FromKarmadaVersion:    "v1.2.0",
+		FromKubernetesVersion: "v1.23.0",
+		ToKarmadaVersion:      "v1.3.0",
+		ToKubernetesVersion:   "v1.24.0",
+		Phase:                 phase,
+		Step:                  step,
+		StepStartTime:         &metav1.Time{Time: stepStartTime},
+	}
+}
+
+func TestStartUpgrade(t *testing.T) {
+	tests := []struct {
+		name        string
+		karmada     func() *installv1alpha1.Karmada
+		wantWaiting bool
+		wantPhase   installv1alpha1.UpgradePhase
+		wantStep    installv1alpha1.UpgradeStep
+	}{
+		{
+			name: "new karmada",
+			karmada: func() *installv1alpha1.Karmada {
+				karmada := upgradingKarmada("v1.2.0", "v1.23.0", false)
+				karmada.Status.KarmadaVersion, karmada.Status.KubernetesVersion = "", ""
+				return karmada
+			},
+		},
+		{
+			name:    "versions unchanged",
+			karmada: func() *installv1alpha1.Karmada { return upgradingKarmada("v1.2.0", "v1.23.0", true) },
+		},
+		{
+			name:      "kubernetes skips a minor version",
+			karmada:   func() *installv1alpha1.Karmada { return upgradingKarmada("v1.2.0", "v1.25.0", true) },
+			wantPhase: installv1alpha1.UpgradePhaseFailed,
+		},
+		{
+			name:      "karmada downgraded",
+			karmada:   func() *installv1alpha1.Karmada { return upgradingKarmada("v1.1.0", "v1.23.0", true) },
+			wantPhase: installv1alpha1.UpgradePhaseFailed,
+		},
+		{
+			name:      "karmada skips a minor version",
+			karmada:   func() *installv1alpha1.Karmada { return upgradingKarmada("v1.4.0", "v1.23.0", true) },
+			wantPhase: installv1alpha1.UpgradePhaseFailed,
+		},
+		{
+			name:      "kubernetes unsupported by the karmada version",
+			karmada:   func() *installv1alpha1.Karmada { return upgradingKarmada("v1.2.1", "v1.24.0", true) },
+			wantPhase: installv1alpha1.UpgradePhaseFailed,
+		},
+		{
+			name:        "components not ready",
+			karmada:     func() *installv1alpha1.Karmada { return upgradingKarmada("v1.3.0", "v1.24.0", false) },
+			wantWaiting: true,
+		},
+		{
+			name:      "started from v1.2",
+			karmada:   func() *installv1alpha1.Karmada { return upgradingKarmada("v1.3.0", "v1.24.0", true) },
+			wantPhase: installv1alpha1.UpgradePhaseProgressing,
+			wantStep:  installv1alpha1.UpgradeStepKubeAPIServer,
+		},
+		{
+			name: "failed upgrade isn't retried",
+			karmada: func() *installv1alpha1.Karmada {
+				karmada := upgradingKarmada("v1.3.0", "v1.24.0", true)
+				karmada.Status.Upgrade = testUpgrade(installv1alpha1.UpgradePhaseRolledBack, "", time.Now())
+				return karmada
+			},
+			wantPhase: installv1alpha1.UpgradePhaseRolledBack,
+		},
+		{
+			name: "running upgrade isn't restarted",
+			karmada: func() *installv1alpha1.Karmada {
+				karmada := upgradingKarmada("v1.3.1", "v1.24.0", true)
+				karmada.Status.Upgrade = testUpgrade(installv1alpha1.UpgradePhaseProgressing, installv1alpha1.UpgradeStepSchedulers, time.Now())
+				return karmada
+			},
+			wantPhase: installv1alpha1.UpgradePhaseProgressing,
+			wantStep:  installv1alpha1.UpgradeStepSchedulers,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &KarmadaController{eventRecorder: record.NewFakeRecorder(10)}
+			karmada := tt.karmada()
+			if waiting := ctrl.startUpgrade(karmada); waiting != tt.wantWaiting {
+				t.Errorf("startUpgrade() = %v, want %v", waiting, tt.wantWaiting)
+			}
+			upgrade := karmada.Status.Upgrade
+			if tt.wantPhase == "" {
+				if upgrade != nil {
+					t.Errorf("startUpgrade() set upgrade %+v, want none", upgrade)
+				}
+				return
+			}
+			if upgrade == nil {
+				t.Fatalf("startUpgrade() set no upgrade, want phase %s", tt.wantPhase)
+			}
+			if upgrade.Phase != tt.wantPhase || upgrade.Step != tt.wantStep {
+				t.Errorf("startUpgrade() set phase %s and step %q, want phase %s and step %q", upgrade.Phase, upgrade.Step, tt.wantPhase, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestProgressUpgrade(t *testing.T) {
+	timedOut := time.Now().Add(-upgradeStepTimeout - time.Minute)
+	tests := []struct {
+		name        string
+		upgrade     *installv1alpha1.UpgradeStatus
+		stepReady   map[installv1alpha1.UpgradeStep]bool
+		allReady    bool
+		wantRunning bool
+		wantPhase   installv1alpha1.UpgradePhase
+		wantStep    installv1alpha1.UpgradeStep
+		wantVersion string
+	}{
+		{
+			name:        "new karmada ready",
+			allReady:    true,
+			wantVersion: "v1.3.0",
+		},
+		{
+			name:        "step ready",
+			upgrade:     testUpgrade(installv1alpha1.UpgradePhaseProgressing, installv1alpha1.UpgradeStepKubeAPIServer, time.Now()),
+			stepReady:   map[installv1alpha1.UpgradeStep]bool{installv1alpha1.UpgradeStepKubeAPIServer: true},
+			wantRunning: true,
+			wantPhase:   installv1alpha1.UpgradePhaseProgressing,
+			wantStep:    installv1alpha1.UpgradeStepAggregatedAPIServer,
+		},
+		{
+			name:        "step not ready",
+			upgrade:     testUpgrade(installv1alpha1.UpgradePhaseProgressing, installv1alpha1.UpgradeStepKubeAPIServer, time.Now()),
+			stepReady:   map[installv1alpha1.UpgradeStep]bool{installv1alpha1.UpgradeStepKubeAPIServer: false},
+			wantRunning: true,
+			wantPhase:   installv1alpha1.UpgradePhaseProgressing,
+			wantStep:    installv1alpha1.UpgradeStepKubeAPIServer,
+		},
+		{
+			name:        "step timed out",
+			upgrade:     testUpgrade(installv1alpha1.UpgradePhaseProgressing, installv1alpha1.UpgradeStepControllerManagers, timedOut),
+			stepReady:   map[installv1alpha1.UpgradeStep]bool{installv1alpha1.UpgradeStepControllerManagers: false},
+			wantRunning: true,
+			wantPhase:   installv1alpha1.UpgradePhaseRollingBack,
+			wantStep:    installv1alpha1.UpgradeStepControllerManagers,
+		},
+		{
+			name:        "last step ready",
+			upgrade:     testUpgrade(installv1alpha1.UpgradePhaseProgressing, installv1alpha1.UpgradeStepSchedulers, time.Now()),
+			stepReady:   map[installv1alpha1.UpgradeStep]bool{installv1alpha1.UpgradeStepSchedulers: true},
+			allReady:    true,
+			wantPhase:   installv1alpha1.UpgradePhaseSucceeded,
+			wantVersion: "v1.3.0",
+		},
+		{
+			name:        "step rolled back",
+			upgrade:     testUpgrade(installv1alpha1.UpgradePhaseRollingBack, installv1alpha1.UpgradeStepControllerManagers, time.Now()),
+			stepReady:   map[installv1alpha1.UpgradeStep]bool{installv1alpha1.UpgradeStepControllerManagers: true},
+			wantRunning: true,
+			wantPhase:   installv1alpha1.UpgradePhaseRollingBack,
+			wantStep:    installv1alpha1.UpgradeStepAggregatedAPIServer,
+		},
+		{
+			name:        "step not rolled back",
+			upgrade:     testUpgrade(installv1alpha1.UpgradePhaseRollingBack, installv1alpha1.UpgradeStepControllerManagers, timedOut),
+			stepReady:   map[installv1alpha1.UpgradeStep]bool{installv1alpha1.UpgradeStepControllerManagers: false},
+			wantRunning: true,
+			wantPhase:   installv1alpha1.UpgradePhaseRollingBack,
+			wantStep:    installv1alpha1.UpgradeStepControllerManagers,
+		},
+		{
+			name:        "first step rolled back",
+			upgrade:     testUpgrade(installv1alpha1.UpgradePhaseRollingBack, installv1alpha1.UpgradeStepKubeAPIServer, time.Now()),
+			stepReady:   map[installv1alpha1.UpgradeStep]bool{installv1alpha1.UpgradeStepKubeAPIServer: true},
+			wantPhase:   installv1alpha1.UpgradePhaseRolledBack,
+			wantVersion: "v1.2.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &KarmadaController{eventRecorder: record.NewFakeRecorder(10)}
+			karmada := &installv1alpha1.Karmada{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "firefly-system"}}
+			karmada.Spec.KarmadaVersion, karmada.Spec.KubernetesVersion = "v1.3.0", "v1.24.0"
+			if tt.upgrade != nil {
+				karmada.Status.KarmadaVersion, karmada.Status.KubernetesVersion = "v1.2.0", "v1.23.0"
+				karmada.Status.Upgrade = tt.upgrade
+			}
+
+			if running := ctrl.progressUpgrade(karmada, tt.stepReady, tt.allReady); running != tt.wantRunning {
+				t.Errorf("progressUpgrade() = %v, want %v", running, tt.wantRunning)
+			}
+			if upgrade := karmada.Status.Upgrade; upgrade != nil && (upgrade.Phase != tt.wantPhase || upgrade.Step != tt.wantStep) {
+				t.Errorf("progressUpgrade() set phase %s and step %q, want phase %s and step %q", upgrade.Phase, upgrade.Step, tt.wantPhase, tt.wantStep)
+			}
+			if tt.wantVersion != "" && karmada.Status.KarmadaVersion != tt.wantVersion {
+				t.Errorf("progressUpgrade() set karmada version %s, want %s", karmada.Status.KarmadaVersion, tt.wantVersion)
+			}
+		})
+	}
+}
+
+// TestUpgradeFromV1_2 upgrades a karmada which was persisted with the former default version v1.2.0
+// through all the steps.
+func TestUpgradeFromV1_2(t *testing.T) {
+	ctrl := &KarmadaController{eventRecorder: record.NewFakeRecorder(10)}
+	karmada := upgradingKarmada("v1.3.0", "v1.23.0", true)
+	if waiting := ctrl.startUpgrade(karmada); waiting {
+		t.Fatalf("startUpgrade() is waiting for the ready components")
+	}
+	upgrade := karmada.Status.Upgrade
+	if upgrade == nil || upgrade.Phase != installv1alpha1.UpgradePhaseProgressing {
+		t.Fatalf("startUpgrade() set upgrade %+v, want a progressing upgrade", upgrade)
+	}
+
+	for _, step := range upgradeSteps {
+		if upgrade.Step != step {
+			t.Fatalf("upgrade is at step %q, want %q", upgrade.Step, step)
+		}
+		if karmadaVersion, _ := stepVersions(karmada, step); karmadaVersion != "v1.3.0" {
+			t.Errorf("step %s runs karmada %s, want v1.3.0", step, karmadaVersion)
+		}
+		ctrl.progressUpgrade(karmada, map[installv1alpha1.UpgradeStep]bool{step: true}, true)
+	}
+	if upgrade.Phase != installv1alpha1.UpgradePhaseSucceeded {
+		t.Errorf("upgrade phase = %s, want %s", upgrade.Phase, installv1alpha1.UpgradePhaseSucceeded)
+	}
+	if karmada.Status.KarmadaVersion != "v1.3.0" || karmada.Status.KubernetesVersion != "v1.23.0" {
+		t.Errorf("karmada runs karmada %s and kubernetes %s, want v1.3.0 and v1.23.0", karmada.Status.KarmadaVersion, karmada.Status.KubernetesVersion)
+	}
+}
+
+func TestStepVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		phase   installv1alpha1.UpgradePhase
+		current installv1alpha1.UpgradeStep
+		step    installv1alpha1.UpgradeStep
+		want    string
+	}{
+		{
+			name:    "upgraded step",
+			phase:   installv1alpha1.UpgradePhaseProgressing,
+			current: installv1alpha1.UpgradeStepControllerManagers,
+			step:    installv1alpha1.UpgradeStepKubeAPIServer,
+			want:    "v1.3.0",
+		},
+		{
+			name:    "upgrading step",
+			phase:   installv1alpha1.UpgradePhaseProgressing,
+			current: installv1alpha1.UpgradeStepControllerManagers,
+			step:    installv1alpha1.UpgradeStepControllerManagers,
+			want:    "v1.3.0",
+		},
+		{
+			name:    "step not upgraded yet",
+			phase:   installv1alpha1.UpgradePhaseProgressing,
+			current: installv1alpha1.UpgradeStepControllerManagers,
+			step:    installv1alpha1.UpgradeStepSchedulers,
+			want:    "v1.2.0",
+		},
+		{
+			name:    "rolling back step",
+			phase:   installv1alpha1.UpgradePhaseRollingBack,
+			current: installv1alpha1.UpgradeStepControllerManagers,
+			step:    installv1alpha1.UpgradeStepControllerManagers,
+			want:    "v1.2.0",
+		},
+		{
+			name:    "step not rolled back yet",
+			phase:   installv1alpha1.UpgradePhaseRollingBack,
+			current: installv1alpha1.UpgradeStepControllerManagers,
+			step:    installv1alpha1.UpgradeStepKubeAPIServer,
+			want:    "v1.3.0",
+		},
+		{
+			name:    "unversioned phase",
+			phase:   installv1alpha1.UpgradePhaseProgressing,
+			current: installv1alpha1.UpgradeStepKubeAPIServer,
+			want:    "v1.3.0",
+		},
+		{
+			name:  "upgrade rolled back",
+			phase: installv1alpha1.UpgradePhaseRolledBack,
+			step:  installv1alpha1.UpgradeStepSchedulers,
+			want:  "v1.2.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			karmada := &installv1alpha1.Karmada{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "firefly-system"}}
+			karmada.Spec.KarmadaVersion, karmada.Spec.KubernetesVersion = "v1.3.0", "v1.24.0"
+			karmada.Status.KarmadaVersion, karmada.Status.KubernetesVersion = "v1.2.0", "v1.23.0"
+			karmada.Status.Upgrade = testUpgrade(tt.phase, tt.current, time.Now())
+			if got, _ := stepVersions(karmada, tt.step); got != tt.want {
+				t.Errorf("stepVersions() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -36,6 +36,22 @@ func (c *FakeInstallV1alpha1) Karmadas(namespace string) v1alpha1.KarmadaInterfa
 	return &FakeKarmadas{c, namespace}
 }
 
+func (c *FakeInstallV1alpha1) KarmadaAccesses(namespace string) v1alpha1.KarmadaAccessInterface {
+	return &FakeKarmadaAccesses{c, namespace}
+}
+
+func (c *FakeInstallV1alpha1) KarmadaBackups(namespace string) v1alpha1.KarmadaBackupInterface {
+	return &FakeKarmadaBackups{c, namespace}
+}
+
+func (c *FakeInstallV1alpha1) KarmadaRestores(namespace string) v1alpha1.KarmadaRestoreInterface {
+	return &FakeKarmadaRestores{c, namespace}
+}
+
+func (c *FakeInstallV1alpha1) MemberClusters(namespace string) v1alpha1.MemberClusterInterface {
+	return &FakeMemberClusters{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeInstallV1alpha1) RESTClient() rest.Interface {
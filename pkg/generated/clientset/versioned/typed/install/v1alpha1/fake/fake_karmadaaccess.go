@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeKarmadaAccesses implements KarmadaAccessInterface
+type FakeKarmadaAccesses struct {
+	Fake *FakeInstallV1alpha1
+	ns   string
+}
+
+var karmadaaccessesResource = schema.GroupVersionResource{Group: "install.firefly.io", Version: "v1alpha1", Resource: "karmadaaccesses"}
+
+var karmadaaccessesKind = schema.GroupVersionKind{Group: "install.firefly.io", Version: "v1alpha1", Kind: "KarmadaAccess"}
+
+// Get takes name of the karmadaAccess, and returns the corresponding karmadaAccess object, and an error if there is any.
+func (c *FakeKarmadaAccesses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KarmadaAccess, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(karmadaaccessesResource, c.ns, name), &v1alpha1.KarmadaAccess{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaAccess), err
+}
+
+// List takes label and field selectors, and returns the list of KarmadaAccesses that match those selectors.
+func (c *FakeKarmadaAccesses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KarmadaAccessList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(karmadaaccessesResource, karmadaaccessesKind, c.ns, opts), &v1alpha1.KarmadaAccessList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.KarmadaAccessList{ListMeta: obj.(*v1alpha1.KarmadaAccessList).ListMeta}
+	for _, item := range obj.(*v1alpha1.KarmadaAccessList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested karmadaAccesses.
+func (c *FakeKarmadaAccesses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(karmadaaccessesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a karmadaAccess and creates it.  Returns the server's representation of the karmadaAccess, and an error, if there is any.
+func (c *FakeKarmadaAccesses) Create(ctx context.Context, karmadaAccess *v1alpha1.KarmadaAccess, opts v1.CreateOptions) (result *v1alpha1.KarmadaAccess, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(karmadaaccessesResource, c.ns, karmadaAccess), &v1alpha1.KarmadaAccess{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaAccess), err
+}
+
+// Update takes the representation of a karmadaAccess and updates it. Returns the server's representation of the karmadaAccess, and an error, if there is any.
+func (c *FakeKarmadaAccesses) Update(ctx context.Context, karmadaAccess *v1alpha1.KarmadaAccess, opts v1.UpdateOptions) (result *v1alpha1.KarmadaAccess, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(karmadaaccessesResource, c.ns, karmadaAccess), &v1alpha1.KarmadaAccess{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaAccess), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKarmadaAccesses) UpdateStatus(ctx context.Context, karmadaAccess *v1alpha1.KarmadaAccess, opts v1.UpdateOptions) (*v1alpha1.KarmadaAccess, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(karmadaaccessesResource, "status", c.ns, karmadaAccess), &v1alpha1.KarmadaAccess{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaAccess), err
+}
+
+// Delete takes name of the karmadaAccess and deletes it. Returns an error if one occurs.
+func (c *FakeKarmadaAccesses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(karmadaaccessesResource, c.ns, name, opts), &v1alpha1.KarmadaAccess{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeKarmadaAccesses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(karmadaaccessesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.KarmadaAccessList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched karmadaAccess.
+func (c *FakeKarmadaAccesses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KarmadaAccess, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(karmadaaccessesResource, c.ns, name, pt, data, subresources...), &v1alpha1.KarmadaAccess{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaAccess), err
+}
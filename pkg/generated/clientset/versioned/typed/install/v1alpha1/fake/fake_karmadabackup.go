@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeKarmadaBackups implements KarmadaBackupInterface
+type FakeKarmadaBackups struct {
+	Fake *FakeInstallV1alpha1
+	ns   string
+}
+
+var karmadabackupsResource = schema.GroupVersionResource{Group: "install.firefly.io", Version: "v1alpha1", Resource: "karmadabackups"}
+
+var karmadabackupsKind = schema.GroupVersionKind{Group: "install.firefly.io", Version: "v1alpha1", Kind: "KarmadaBackup"}
+
+// Get takes name of the karmadaBackup, and returns the corresponding karmadaBackup object, and an error if there is any.
+func (c *FakeKarmadaBackups) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KarmadaBackup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(karmadabackupsResource, c.ns, name), &v1alpha1.KarmadaBackup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaBackup), err
+}
+
+// List takes label and field selectors, and returns the list of KarmadaBackups that match those selectors.
+func (c *FakeKarmadaBackups) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KarmadaBackupList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(karmadabackupsResource, karmadabackupsKind, c.ns, opts), &v1alpha1.KarmadaBackupList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.KarmadaBackupList{ListMeta: obj.(*v1alpha1.KarmadaBackupList).ListMeta}
+	for _, item := range obj.(*v1alpha1.KarmadaBackupList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested karmadaBackups.
+func (c *FakeKarmadaBackups) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(karmadabackupsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a karmadaBackup and creates it.  Returns the server's representation of the karmadaBackup, and an error, if there is any.
+func (c *FakeKarmadaBackups) Create(ctx context.Context, karmadaBackup *v1alpha1.KarmadaBackup, opts v1.CreateOptions) (result *v1alpha1.KarmadaBackup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(karmadabackupsResource, c.ns, karmadaBackup), &v1alpha1.KarmadaBackup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaBackup), err
+}
+
+// Update takes the representation of a karmadaBackup and updates it. Returns the server's representation of the karmadaBackup, and an error, if there is any.
+func (c *FakeKarmadaBackups) Update(ctx context.Context, karmadaBackup *v1alpha1.KarmadaBackup, opts v1.UpdateOptions) (result *v1alpha1.KarmadaBackup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(karmadabackupsResource, c.ns, karmadaBackup), &v1alpha1.KarmadaBackup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaBackup), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKarmadaBackups) UpdateStatus(ctx context.Context, karmadaBackup *v1alpha1.KarmadaBackup, opts v1.UpdateOptions) (*v1alpha1.KarmadaBackup, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(karmadabackupsResource, "status", c.ns, karmadaBackup), &v1alpha1.KarmadaBackup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaBackup), err
+}
+
+// Delete takes name of the karmadaBackup and deletes it. Returns an error if one occurs.
+func (c *FakeKarmadaBackups) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(karmadabackupsResource, c.ns, name, opts), &v1alpha1.KarmadaBackup{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeKarmadaBackups) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(karmadabackupsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.KarmadaBackupList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched karmadaBackup.
+func (c *FakeKarmadaBackups) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KarmadaBackup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(karmadabackupsResource, c.ns, name, pt, data, subresources...), &v1alpha1.KarmadaBackup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaBackup), err
+}
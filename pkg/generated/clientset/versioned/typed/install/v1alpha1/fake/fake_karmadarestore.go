@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeKarmadaRestores implements KarmadaRestoreInterface
+type FakeKarmadaRestores struct {
+	Fake *FakeInstallV1alpha1
+	ns   string
+}
+
+var karmadarestoresResource = schema.GroupVersionResource{Group: "install.firefly.io", Version: "v1alpha1", Resource: "karmadarestores"}
+
+var karmadarestoresKind = schema.GroupVersionKind{Group: "install.firefly.io", Version: "v1alpha1", Kind: "KarmadaRestore"}
+
+// Get takes name of the karmadaRestore, and returns the corresponding karmadaRestore object, and an error if there is any.
+func (c *FakeKarmadaRestores) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KarmadaRestore, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(karmadarestoresResource, c.ns, name), &v1alpha1.KarmadaRestore{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaRestore), err
+}
+
+// List takes label and field selectors, and returns the list of KarmadaRestores that match those selectors.
+func (c *FakeKarmadaRestores) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KarmadaRestoreList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(karmadarestoresResource, karmadarestoresKind, c.ns, opts), &v1alpha1.KarmadaRestoreList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.KarmadaRestoreList{ListMeta: obj.(*v1alpha1.KarmadaRestoreList).ListMeta}
+	for _, item := range obj.(*v1alpha1.KarmadaRestoreList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested karmadaRestores.
+func (c *FakeKarmadaRestores) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(karmadarestoresResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a karmadaRestore and creates it.  Returns the server's representation of the karmadaRestore, and an error, if there is any.
+func (c *FakeKarmadaRestores) Create(ctx context.Context, karmadaRestore *v1alpha1.KarmadaRestore, opts v1.CreateOptions) (result *v1alpha1.KarmadaRestore, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(karmadarestoresResource, c.ns, karmadaRestore), &v1alpha1.KarmadaRestore{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaRestore), err
+}
+
+// Update takes the representation of a karmadaRestore and updates it. Returns the server's representation of the karmadaRestore, and an error, if there is any.
+func (c *FakeKarmadaRestores) Update(ctx context.Context, karmadaRestore *v1alpha1.KarmadaRestore, opts v1.UpdateOptions) (result *v1alpha1.KarmadaRestore, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(karmadarestoresResource, c.ns, karmadaRestore), &v1alpha1.KarmadaRestore{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaRestore), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKarmadaRestores) UpdateStatus(ctx context.Context, karmadaRestore *v1alpha1.KarmadaRestore, opts v1.UpdateOptions) (*v1alpha1.KarmadaRestore, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(karmadarestoresResource, "status", c.ns, karmadaRestore), &v1alpha1.KarmadaRestore{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaRestore), err
+}
+
+// Delete takes name of the karmadaRestore and deletes it. Returns an error if one occurs.
+func (c *FakeKarmadaRestores) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(karmadarestoresResource, c.ns, name, opts), &v1alpha1.KarmadaRestore{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeKarmadaRestores) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(karmadarestoresResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.KarmadaRestoreList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched karmadaRestore.
+func (c *FakeKarmadaRestores) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KarmadaRestore, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(karmadarestoresResource, c.ns, name, pt, data, subresources...), &v1alpha1.KarmadaRestore{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KarmadaRestore), err
+}
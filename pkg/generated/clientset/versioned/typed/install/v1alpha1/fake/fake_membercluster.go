@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeMemberClusters implements MemberClusterInterface
+type FakeMemberClusters struct {
+	Fake *FakeInstallV1alpha1
+	ns   string
+}
+
+var memberclustersResource = schema.GroupVersionResource{Group: "install.firefly.io", Version: "v1alpha1", Resource: "memberclusters"}
+
+var memberclustersKind = schema.GroupVersionKind{Group: "install.firefly.io", Version: "v1alpha1", Kind: "MemberCluster"}
+
+// Get takes name of the memberCluster, and returns the corresponding memberCluster object, and an error if there is any.
+func (c *FakeMemberClusters) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.MemberCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(memberclustersResource, c.ns, name), &v1alpha1.MemberCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MemberCluster), err
+}
+
+// List takes label and field selectors, and returns the list of MemberClusters that match those selectors.
+func (c *FakeMemberClusters) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.MemberClusterList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(memberclustersResource, memberclustersKind, c.ns, opts), &v1alpha1.MemberClusterList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.MemberClusterList{ListMeta: obj.(*v1alpha1.MemberClusterList).ListMeta}
+	for _, item := range obj.(*v1alpha1.MemberClusterList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested memberClusters.
+func (c *FakeMemberClusters) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(memberclustersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a memberCluster and creates it.  Returns the server's representation of the memberCluster, and an error, if there is any.
+func (c *FakeMemberClusters) Create(ctx context.Context, memberCluster *v1alpha1.MemberCluster, opts v1.CreateOptions) (result *v1alpha1.MemberCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(memberclustersResource, c.ns, memberCluster), &v1alpha1.MemberCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MemberCluster), err
+}
+
+// Update takes the representation of a memberCluster and updates it. Returns the server's representation of the memberCluster, and an error, if there is any.
+func (c *FakeMemberClusters) Update(ctx context.Context, memberCluster *v1alpha1.MemberCluster, opts v1.UpdateOptions) (result *v1alpha1.MemberCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(memberclustersResource, c.ns, memberCluster), &v1alpha1.MemberCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MemberCluster), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeMemberClusters) UpdateStatus(ctx context.Context, memberCluster *v1alpha1.MemberCluster, opts v1.UpdateOptions) (*v1alpha1.MemberCluster, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(memberclustersResource, "status", c.ns, memberCluster), &v1alpha1.MemberCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MemberCluster), err
+}
+
+// Delete takes name of the memberCluster and deletes it. Returns an error if one occurs.
+func (c *FakeMemberClusters) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(memberclustersResource, c.ns, name, opts), &v1alpha1.MemberCluster{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeMemberClusters) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(memberclustersResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.MemberClusterList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched memberCluster.
+func (c *FakeMemberClusters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.MemberCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(memberclustersResource, c.ns, name, pt, data, subresources...), &v1alpha1.MemberCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MemberCluster), err
+}
@@ -21,3 +21,11 @@ package v1alpha1
 type ClusterpediaExpansion interface{}
 
 type KarmadaExpansion interface{}
+
+type KarmadaAccessExpansion interface{}
+
+type KarmadaBackupExpansion interface{}
+
+type KarmadaRestoreExpansion interface{}
+
+type MemberClusterExpansion interface{}
@@ -30,6 +30,10 @@ type InstallV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterpediasGetter
 	KarmadasGetter
+	KarmadaAccessesGetter
+	KarmadaBackupsGetter
+	KarmadaRestoresGetter
+	MemberClustersGetter
 }
 
 // InstallV1alpha1Client is used to interact with features provided by the install.firefly.io group.
@@ -45,6 +49,22 @@ func (c *InstallV1alpha1Client) Karmadas(namespace string) KarmadaInterface {
 	return newKarmadas(c, namespace)
 }
 
+func (c *InstallV1alpha1Client) KarmadaAccesses(namespace string) KarmadaAccessInterface {
+	return newKarmadaAccesses(c, namespace)
+}
+
+func (c *InstallV1alpha1Client) KarmadaBackups(namespace string) KarmadaBackupInterface {
+	return newKarmadaBackups(c, namespace)
+}
+
+func (c *InstallV1alpha1Client) KarmadaRestores(namespace string) KarmadaRestoreInterface {
+	return newKarmadaRestores(c, namespace)
+}
+
+func (c *InstallV1alpha1Client) MemberClusters(namespace string) MemberClusterInterface {
+	return newMemberClusters(c, namespace)
+}
+
 // NewForConfig creates a new InstallV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
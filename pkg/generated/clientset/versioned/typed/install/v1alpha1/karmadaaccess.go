@@ -0,0 +1,195 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	scheme "github.com/carlory/firefly/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// KarmadaAccessesGetter has a method to return a KarmadaAccessInterface.
+// A group's client should implement this interface.
+type KarmadaAccessesGetter interface {
+	KarmadaAccesses(namespace string) KarmadaAccessInterface
+}
+
+// KarmadaAccessInterface has methods to work with KarmadaAccess resources.
+type KarmadaAccessInterface interface {
+	Create(ctx context.Context, karmadaAccess *v1alpha1.KarmadaAccess, opts v1.CreateOptions) (*v1alpha1.KarmadaAccess, error)
+	Update(ctx context.Context, karmadaAccess *v1alpha1.KarmadaAccess, opts v1.UpdateOptions) (*v1alpha1.KarmadaAccess, error)
+	UpdateStatus(ctx context.Context, karmadaAccess *v1alpha1.KarmadaAccess, opts v1.UpdateOptions) (*v1alpha1.KarmadaAccess, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.KarmadaAccess, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.KarmadaAccessList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KarmadaAccess, err error)
+	KarmadaAccessExpansion
+}
+
+// karmadaAccesses implements KarmadaAccessInterface
+type karmadaAccesses struct {
+	client rest.Interface
+	ns     string
+}
+
+// newKarmadaAccesses returns a KarmadaAccesses
+func newKarmadaAccesses(c *InstallV1alpha1Client, namespace string) *karmadaAccesses {
+	return &karmadaAccesses{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the karmadaAccess, and returns the corresponding karmadaAccess object, and an error if there is any.
+func (c *karmadaAccesses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KarmadaAccess, err error) {
+	result = &v1alpha1.KarmadaAccess{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("karmadaaccesses").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of KarmadaAccesses that match those selectors.
+func (c *karmadaAccesses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KarmadaAccessList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.KarmadaAccessList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("karmadaaccesses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested karmadaAccesses.
+func (c *karmadaAccesses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("karmadaaccesses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a karmadaAccess and creates it.  Returns the server's representation of the karmadaAccess, and an error, if there is any.
+func (c *karmadaAccesses) Create(ctx context.Context, karmadaAccess *v1alpha1.KarmadaAccess, opts v1.CreateOptions) (result *v1alpha1.KarmadaAccess, err error) {
+	result = &v1alpha1.KarmadaAccess{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("karmadaaccesses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(karmadaAccess).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a karmadaAccess and updates it. Returns the server's representation of the karmadaAccess, and an error, if there is any.
+func (c *karmadaAccesses) Update(ctx context.Context, karmadaAccess *v1alpha1.KarmadaAccess, opts v1.UpdateOptions) (result *v1alpha1.KarmadaAccess, err error) {
+	result = &v1alpha1.KarmadaAccess{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("karmadaaccesses").
+		Name(karmadaAccess.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(karmadaAccess).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *karmadaAccesses) UpdateStatus(ctx context.Context, karmadaAccess *v1alpha1.KarmadaAccess, opts v1.UpdateOptions) (result *v1alpha1.KarmadaAccess, err error) {
+	result = &v1alpha1.KarmadaAccess{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("karmadaaccesses").
+		Name(karmadaAccess.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(karmadaAccess).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the karmadaAccess and deletes it. Returns an error if one occurs.
+func (c *karmadaAccesses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("karmadaaccesses").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *karmadaAccesses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("karmadaaccesses").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched karmadaAccess.
+func (c *karmadaAccesses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KarmadaAccess, err error) {
+	result = &v1alpha1.KarmadaAccess{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("karmadaaccesses").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
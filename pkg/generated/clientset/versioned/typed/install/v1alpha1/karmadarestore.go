@@ -0,0 +1,195 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	scheme "github.com/carlory/firefly/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// KarmadaRestoresGetter has a method to return a KarmadaRestoreInterface.
+// A group's client should implement this interface.
+type KarmadaRestoresGetter interface {
+	KarmadaRestores(namespace string) KarmadaRestoreInterface
+}
+
+// KarmadaRestoreInterface has methods to work with KarmadaRestore resources.
+type KarmadaRestoreInterface interface {
+	Create(ctx context.Context, karmadaRestore *v1alpha1.KarmadaRestore, opts v1.CreateOptions) (*v1alpha1.KarmadaRestore, error)
+	Update(ctx context.Context, karmadaRestore *v1alpha1.KarmadaRestore, opts v1.UpdateOptions) (*v1alpha1.KarmadaRestore, error)
+	UpdateStatus(ctx context.Context, karmadaRestore *v1alpha1.KarmadaRestore, opts v1.UpdateOptions) (*v1alpha1.KarmadaRestore, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.KarmadaRestore, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.KarmadaRestoreList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KarmadaRestore, err error)
+	KarmadaRestoreExpansion
+}
+
+// karmadaRestores implements KarmadaRestoreInterface
+type karmadaRestores struct {
+	client rest.Interface
+	ns     string
+}
+
+// newKarmadaRestores returns a KarmadaRestores
+func newKarmadaRestores(c *InstallV1alpha1Client, namespace string) *karmadaRestores {
+	return &karmadaRestores{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the karmadaRestore, and returns the corresponding karmadaRestore object, and an error if there is any.
+func (c *karmadaRestores) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KarmadaRestore, err error) {
+	result = &v1alpha1.KarmadaRestore{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("karmadarestores").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of KarmadaRestores that match those selectors.
+func (c *karmadaRestores) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KarmadaRestoreList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.KarmadaRestoreList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("karmadarestores").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested karmadaRestores.
+func (c *karmadaRestores) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("karmadarestores").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a karmadaRestore and creates it.  Returns the server's representation of the karmadaRestore, and an error, if there is any.
+func (c *karmadaRestores) Create(ctx context.Context, karmadaRestore *v1alpha1.KarmadaRestore, opts v1.CreateOptions) (result *v1alpha1.KarmadaRestore, err error) {
+	result = &v1alpha1.KarmadaRestore{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("karmadarestores").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(karmadaRestore).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a karmadaRestore and updates it. Returns the server's representation of the karmadaRestore, and an error, if there is any.
+func (c *karmadaRestores) Update(ctx context.Context, karmadaRestore *v1alpha1.KarmadaRestore, opts v1.UpdateOptions) (result *v1alpha1.KarmadaRestore, err error) {
+	result = &v1alpha1.KarmadaRestore{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("karmadarestores").
+		Name(karmadaRestore.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(karmadaRestore).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *karmadaRestores) UpdateStatus(ctx context.Context, karmadaRestore *v1alpha1.KarmadaRestore, opts v1.UpdateOptions) (result *v1alpha1.KarmadaRestore, err error) {
+	result = &v1alpha1.KarmadaRestore{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("karmadarestores").
+		Name(karmadaRestore.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(karmadaRestore).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the karmadaRestore and deletes it. Returns an error if one occurs.
+func (c *karmadaRestores) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("karmadarestores").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *karmadaRestores) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("karmadarestores").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched karmadaRestore.
+func (c *karmadaRestores) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KarmadaRestore, err error) {
+	result = &v1alpha1.KarmadaRestore{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("karmadarestores").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
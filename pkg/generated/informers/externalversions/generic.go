@@ -57,6 +57,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Install().V1alpha1().Clusterpedias().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("karmadas"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Install().V1alpha1().Karmadas().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("karmadaaccesses"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Install().V1alpha1().KarmadaAccesses().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("karmadabackups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Install().V1alpha1().KarmadaBackups().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("karmadarestores"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Install().V1alpha1().KarmadaRestores().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("memberclusters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Install().V1alpha1().MemberClusters().Informer()}, nil
 
 	}
 
@@ -28,6 +28,14 @@ type Interface interface {
 	Clusterpedias() ClusterpediaInformer
 	// Karmadas returns a KarmadaInformer.
 	Karmadas() KarmadaInformer
+	// KarmadaAccesses returns a KarmadaAccessInformer.
+	KarmadaAccesses() KarmadaAccessInformer
+	// KarmadaBackups returns a KarmadaBackupInformer.
+	KarmadaBackups() KarmadaBackupInformer
+	// KarmadaRestores returns a KarmadaRestoreInformer.
+	KarmadaRestores() KarmadaRestoreInformer
+	// MemberClusters returns a MemberClusterInformer.
+	MemberClusters() MemberClusterInformer
 }
 
 type version struct {
@@ -50,3 +58,23 @@ func (v *version) Clusterpedias() ClusterpediaInformer {
 func (v *version) Karmadas() KarmadaInformer {
 	return &karmadaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// KarmadaAccesses returns a KarmadaAccessInformer.
+func (v *version) KarmadaAccesses() KarmadaAccessInformer {
+	return &karmadaAccessInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// KarmadaBackups returns a KarmadaBackupInformer.
+func (v *version) KarmadaBackups() KarmadaBackupInformer {
+	return &karmadaBackupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// KarmadaRestores returns a KarmadaRestoreInformer.
+func (v *version) KarmadaRestores() KarmadaRestoreInformer {
+	return &karmadaRestoreInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// MemberClusters returns a MemberClusterInformer.
+func (v *version) MemberClusters() MemberClusterInformer {
+	return &memberClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
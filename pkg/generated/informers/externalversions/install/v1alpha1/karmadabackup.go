@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	versioned "github.com/carlory/firefly/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/carlory/firefly/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/carlory/firefly/pkg/generated/listers/install/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// KarmadaBackupInformer provides access to a shared informer and lister for
+// KarmadaBackups.
+type KarmadaBackupInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.KarmadaBackupLister
+}
+
+type karmadaBackupInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewKarmadaBackupInformer constructs a new informer for KarmadaBackup type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewKarmadaBackupInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredKarmadaBackupInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredKarmadaBackupInformer constructs a new informer for KarmadaBackup type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredKarmadaBackupInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.InstallV1alpha1().KarmadaBackups(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.InstallV1alpha1().KarmadaBackups(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&installv1alpha1.KarmadaBackup{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *karmadaBackupInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredKarmadaBackupInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *karmadaBackupInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&installv1alpha1.KarmadaBackup{}, f.defaultInformer)
+}
+
+func (f *karmadaBackupInformer) Lister() v1alpha1.KarmadaBackupLister {
+	return v1alpha1.NewKarmadaBackupLister(f.Informer().GetIndexer())
+}
@@ -33,3 +33,35 @@ type KarmadaListerExpansion interface{}
 // KarmadaNamespaceListerExpansion allows custom methods to be added to
 // KarmadaNamespaceLister.
 type KarmadaNamespaceListerExpansion interface{}
+
+// KarmadaAccessListerExpansion allows custom methods to be added to
+// KarmadaAccessLister.
+type KarmadaAccessListerExpansion interface{}
+
+// KarmadaAccessNamespaceListerExpansion allows custom methods to be added to
+// KarmadaAccessNamespaceLister.
+type KarmadaAccessNamespaceListerExpansion interface{}
+
+// KarmadaBackupListerExpansion allows custom methods to be added to
+// KarmadaBackupLister.
+type KarmadaBackupListerExpansion interface{}
+
+// KarmadaBackupNamespaceListerExpansion allows custom methods to be added to
+// KarmadaBackupNamespaceLister.
+type KarmadaBackupNamespaceListerExpansion interface{}
+
+// KarmadaRestoreListerExpansion allows custom methods to be added to
+// KarmadaRestoreLister.
+type KarmadaRestoreListerExpansion interface{}
+
+// KarmadaRestoreNamespaceListerExpansion allows custom methods to be added to
+// KarmadaRestoreNamespaceLister.
+type KarmadaRestoreNamespaceListerExpansion interface{}
+
+// MemberClusterListerExpansion allows custom methods to be added to
+// MemberClusterLister.
+type MemberClusterListerExpansion interface{}
+
+// MemberClusterNamespaceListerExpansion allows custom methods to be added to
+// MemberClusterNamespaceLister.
+type MemberClusterNamespaceListerExpansion interface{}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// KarmadaAccessLister helps list KarmadaAccesses.
+// All objects returned here must be treated as read-only.
+type KarmadaAccessLister interface {
+	// List lists all KarmadaAccesses in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.KarmadaAccess, err error)
+	// KarmadaAccesses returns an object that can list and get KarmadaAccesses.
+	KarmadaAccesses(namespace string) KarmadaAccessNamespaceLister
+	KarmadaAccessListerExpansion
+}
+
+// karmadaAccessLister implements the KarmadaAccessLister interface.
+type karmadaAccessLister struct {
+	indexer cache.Indexer
+}
+
+// NewKarmadaAccessLister returns a new KarmadaAccessLister.
+func NewKarmadaAccessLister(indexer cache.Indexer) KarmadaAccessLister {
+	return &karmadaAccessLister{indexer: indexer}
+}
+
+// List lists all KarmadaAccesses in the indexer.
+func (s *karmadaAccessLister) List(selector labels.Selector) (ret []*v1alpha1.KarmadaAccess, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.KarmadaAccess))
+	})
+	return ret, err
+}
+
+// KarmadaAccesses returns an object that can list and get KarmadaAccesses.
+func (s *karmadaAccessLister) KarmadaAccesses(namespace string) KarmadaAccessNamespaceLister {
+	return karmadaAccessNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// KarmadaAccessNamespaceLister helps list and get KarmadaAccesses.
+// All objects returned here must be treated as read-only.
+type KarmadaAccessNamespaceLister interface {
+	// List lists all KarmadaAccesses in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.KarmadaAccess, err error)
+	// Get retrieves the KarmadaAccess from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.KarmadaAccess, error)
+	KarmadaAccessNamespaceListerExpansion
+}
+
+// karmadaAccessNamespaceLister implements the KarmadaAccessNamespaceLister
+// interface.
+type karmadaAccessNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all KarmadaAccesses in the indexer for a given namespace.
+func (s karmadaAccessNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.KarmadaAccess, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.KarmadaAccess))
+	})
+	return ret, err
+}
+
+// Get retrieves the KarmadaAccess from the indexer for a given namespace and name.
+func (s karmadaAccessNamespaceLister) Get(name string) (*v1alpha1.KarmadaAccess, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("karmadaaccess"), name)
+	}
+	return obj.(*v1alpha1.KarmadaAccess), nil
+}
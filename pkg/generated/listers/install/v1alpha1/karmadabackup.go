@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// KarmadaBackupLister helps list KarmadaBackups.
+// All objects returned here must be treated as read-only.
+type KarmadaBackupLister interface {
+	// List lists all KarmadaBackups in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.KarmadaBackup, err error)
+	// KarmadaBackups returns an object that can list and get KarmadaBackups.
+	KarmadaBackups(namespace string) KarmadaBackupNamespaceLister
+	KarmadaBackupListerExpansion
+}
+
+// karmadaBackupLister implements the KarmadaBackupLister interface.
+type karmadaBackupLister struct {
+	indexer cache.Indexer
+}
+
+// NewKarmadaBackupLister returns a new KarmadaBackupLister.
+func NewKarmadaBackupLister(indexer cache.Indexer) KarmadaBackupLister {
+	return &karmadaBackupLister{indexer: indexer}
+}
+
+// List lists all KarmadaBackups in the indexer.
+func (s *karmadaBackupLister) List(selector labels.Selector) (ret []*v1alpha1.KarmadaBackup, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.KarmadaBackup))
+	})
+	return ret, err
+}
+
+// KarmadaBackups returns an object that can list and get KarmadaBackups.
+func (s *karmadaBackupLister) KarmadaBackups(namespace string) KarmadaBackupNamespaceLister {
+	return karmadaBackupNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// KarmadaBackupNamespaceLister helps list and get KarmadaBackups.
+// All objects returned here must be treated as read-only.
+type KarmadaBackupNamespaceLister interface {
+	// List lists all KarmadaBackups in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.KarmadaBackup, err error)
+	// Get retrieves the KarmadaBackup from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.KarmadaBackup, error)
+	KarmadaBackupNamespaceListerExpansion
+}
+
+// karmadaBackupNamespaceLister implements the KarmadaBackupNamespaceLister
+// interface.
+type karmadaBackupNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all KarmadaBackups in the indexer for a given namespace.
+func (s karmadaBackupNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.KarmadaBackup, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.KarmadaBackup))
+	})
+	return ret, err
+}
+
+// Get retrieves the KarmadaBackup from the indexer for a given namespace and name.
+func (s karmadaBackupNamespaceLister) Get(name string) (*v1alpha1.KarmadaBackup, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("karmadabackup"), name)
+	}
+	return obj.(*v1alpha1.KarmadaBackup), nil
+}
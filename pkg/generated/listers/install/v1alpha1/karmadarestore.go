@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// KarmadaRestoreLister helps list KarmadaRestores.
+// All objects returned here must be treated as read-only.
+type KarmadaRestoreLister interface {
+	// List lists all KarmadaRestores in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.KarmadaRestore, err error)
+	// KarmadaRestores returns an object that can list and get KarmadaRestores.
+	KarmadaRestores(namespace string) KarmadaRestoreNamespaceLister
+	KarmadaRestoreListerExpansion
+}
+
+// karmadaRestoreLister implements the KarmadaRestoreLister interface.
+type karmadaRestoreLister struct {
+	indexer cache.Indexer
+}
+
+// NewKarmadaRestoreLister returns a new KarmadaRestoreLister.
+func NewKarmadaRestoreLister(indexer cache.Indexer) KarmadaRestoreLister {
+	return &karmadaRestoreLister{indexer: indexer}
+}
+
+// List lists all KarmadaRestores in the indexer.
+func (s *karmadaRestoreLister) List(selector labels.Selector) (ret []*v1alpha1.KarmadaRestore, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.KarmadaRestore))
+	})
+	return ret, err
+}
+
+// KarmadaRestores returns an object that can list and get KarmadaRestores.
+func (s *karmadaRestoreLister) KarmadaRestores(namespace string) KarmadaRestoreNamespaceLister {
+	return karmadaRestoreNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// KarmadaRestoreNamespaceLister helps list and get KarmadaRestores.
+// All objects returned here must be treated as read-only.
+type KarmadaRestoreNamespaceLister interface {
+	// List lists all KarmadaRestores in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.KarmadaRestore, err error)
+	// Get retrieves the KarmadaRestore from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.KarmadaRestore, error)
+	KarmadaRestoreNamespaceListerExpansion
+}
+
+// karmadaRestoreNamespaceLister implements the KarmadaRestoreNamespaceLister
+// interface.
+type karmadaRestoreNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all KarmadaRestores in the indexer for a given namespace.
+func (s karmadaRestoreNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.KarmadaRestore, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.KarmadaRestore))
+	})
+	return ret, err
+}
+
+// Get retrieves the KarmadaRestore from the indexer for a given namespace and name.
+func (s karmadaRestoreNamespaceLister) Get(name string) (*v1alpha1.KarmadaRestore, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("karmadarestore"), name)
+	}
+	return obj.(*v1alpha1.KarmadaRestore), nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// MemberClusterLister helps list MemberClusters.
+// All objects returned here must be treated as read-only.
+type MemberClusterLister interface {
+	// List lists all MemberClusters in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.MemberCluster, err error)
+	// MemberClusters returns an object that can list and get MemberClusters.
+	MemberClusters(namespace string) MemberClusterNamespaceLister
+	MemberClusterListerExpansion
+}
+
+// memberClusterLister implements the MemberClusterLister interface.
+type memberClusterLister struct {
+	indexer cache.Indexer
+}
+
+// NewMemberClusterLister returns a new MemberClusterLister.
+func NewMemberClusterLister(indexer cache.Indexer) MemberClusterLister {
+	return &memberClusterLister{indexer: indexer}
+}
+
+// List lists all MemberClusters in the indexer.
+func (s *memberClusterLister) List(selector labels.Selector) (ret []*v1alpha1.MemberCluster, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.MemberCluster))
+	})
+	return ret, err
+}
+
+// MemberClusters returns an object that can list and get MemberClusters.
+func (s *memberClusterLister) MemberClusters(namespace string) MemberClusterNamespaceLister {
+	return memberClusterNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// MemberClusterNamespaceLister helps list and get MemberClusters.
+// All objects returned here must be treated as read-only.
+type MemberClusterNamespaceLister interface {
+	// List lists all MemberClusters in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.MemberCluster, err error)
+	// Get retrieves the MemberCluster from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.MemberCluster, error)
+	MemberClusterNamespaceListerExpansion
+}
+
+// memberClusterNamespaceLister implements the MemberClusterNamespaceLister
+// interface.
+type memberClusterNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all MemberClusters in the indexer for a given namespace.
+func (s memberClusterNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.MemberCluster, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.MemberCluster))
+	})
+	return ret, err
+}
+
+// Get retrieves the MemberCluster from the indexer for a given namespace and name.
+func (s memberClusterNamespaceLister) Get(name string) (*v1alpha1.MemberCluster, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("membercluster"), name)
+	}
+	return obj.(*v1alpha1.MemberCluster), nil
+}
@@ -47,6 +47,7 @@ import (
 	installinformers "github.com/carlory/firefly/pkg/generated/informers/externalversions/install/v1alpha1"
 	installlisters "github.com/carlory/firefly/pkg/generated/listers/install/v1alpha1"
 	"github.com/carlory/firefly/pkg/karmada/scheme"
+	"github.com/carlory/firefly/pkg/metrics"
 )
 
 const (
@@ -76,6 +77,7 @@ func NewEstimatorController(
 	if karmadaKubeClient != nil && karmadaKubeClient.CoreV1().RESTClient().GetRateLimiter() != nil {
 		ratelimiter.RegisterMetricAndTrackRateLimiterUsage("estimator_controller", karmadaKubeClient.CoreV1().RESTClient().GetRateLimiter())
 	}
+	metrics.Register()
 
 	ctrl := &EstimatorController{
 		karmadaKubeClient:    karmadaKubeClient,
@@ -173,7 +175,10 @@ func (ctrl *EstimatorController) processNextWorkItem(ctx context.Context) bool {
 	}
 	defer ctrl.queue.Done(key)
 
+	startTime := time.Now()
 	err := ctrl.syncEstimator(ctx, key.(string))
+	metrics.ObserveReconcile("estimator", metrics.PhaseSync, startTime, err)
+	ctrl.recordEstimators()
 	ctrl.handleErr(err, key)
 
 	return true
@@ -221,7 +226,21 @@ func (ctrl *EstimatorController) syncKarmada(old, cur interface{}) {
 	oldEstimator := oldKarmada.Spec.Scheduler.KarmadaSchedulerEstimator
 	curEstimator := curKarmada.Spec.Scheduler.KarmadaSchedulerEstimator
 	if !reflect.DeepEqual(oldEstimator, curEstimator) ||
-		(oldEstimator.ImageTag == "" && oldKarmada.Spec.KarmadaVersion != curKarmada.Spec.KarmadaVersion) {
+		(oldEstimator.ImageTag == "" && karmadaVersion(oldKarmada) != karmadaVersion(curKarmada)) {
+		needUpdate = true
+	}
+
+	// The estimators are skipped while the karmada is paused, so they are synced again when it's resumed.
+	if oldKarmada.Spec.Paused != curKarmada.Spec.Paused || oldKarmada.Spec.Plan != curKarmada.Spec.Plan {
+		needUpdate = true
+	}
+
+	// The defaults of the karmada which the estimators fall back to.
+	if !reflect.DeepEqual(oldKarmada.Spec.Placement, curKarmada.Spec.Placement) ||
+		!reflect.DeepEqual(oldKarmada.Spec.ImagePullSecrets, curKarmada.Spec.ImagePullSecrets) ||
+		!reflect.DeepEqual(oldKarmada.Spec.ImageOverrides, curKarmada.Spec.ImageOverrides) ||
+		oldKarmada.Spec.ImagePullPolicy != curKarmada.Spec.ImagePullPolicy ||
+		oldKarmada.Spec.ImageRepository != curKarmada.Spec.ImageRepository {
 		needUpdate = true
 	}
 
@@ -346,24 +365,60 @@ func (ctrl *EstimatorController) syncEstimator(ctx context.Context, key string)
 		return nil
 	}
 
-	if cluster.Spec.SyncMode == clusterv1alpha1.Pull {
-		schedulerArgs := karmada.Spec.Scheduler.KarmadaScheduler.ExtraArgs
-		disableEstimatorVal, ok := schedulerArgs["disable-scheduler-estimator-in-pull-mode"]
-		if ok {
-			disableEstimator, err := strconv.ParseBool(disableEstimatorVal)
-			if err != nil {
-				return err
-			}
-			if disableEstimator {
-				return nil
-			}
-		}
+	if karmada.Spec.Paused || karmada.Spec.Plan {
+		klog.V(2).InfoS("Karmada is paused", "karmada", klog.KRef(ctrl.estimatorNamespace, ctrl.karmadaName), "cluster", cluster.Name)
+		return nil
+	}
+
+	disabled, err := estimatorDisabled(karmada, cluster)
+	if err != nil {
+		return err
+	}
+	if disabled {
+		return nil
 	}
 
 	klog.InfoS("Syncing estimator", "cluster", cluster.Name)
 	return ctrl.EnsureEstimator(ctx, karmada, cluster)
 }
 
+// estimatorDisabled returns whether the estimator of the cluster is disabled by the scheduler of the karmada,
+// which happens to the clusters in the Pull mode.
+func estimatorDisabled(karmada *installv1alpha1.Karmada, cluster *clusterv1alpha1.Cluster) (bool, error) {
+	if cluster.Spec.SyncMode != clusterv1alpha1.Pull {
+		return false, nil
+	}
+	schedulerArgs := karmada.Spec.Scheduler.KarmadaScheduler.ExtraArgs
+	disableEstimatorVal, ok := schedulerArgs["disable-scheduler-estimator-in-pull-mode"]
+	if !ok {
+		return false, nil
+	}
+	return strconv.ParseBool(disableEstimatorVal)
+}
+
+// recordEstimators records the number of the estimators of the karmada, which are deployed for the clusters
+// that are not being deleted.
+func (ctrl *EstimatorController) recordEstimators() {
+	karmada, err := ctrl.fireflyKarmadaLister.Karmadas(ctrl.estimatorNamespace).Get(ctrl.karmadaName)
+	if err != nil {
+		return
+	}
+	clusters, err := ctrl.clustersLister.List(labels.Everything())
+	if err != nil {
+		return
+	}
+	count := 0
+	for _, cluster := range clusters {
+		if !cluster.DeletionTimestamp.IsZero() || !controllerutil.ContainsFinalizer(cluster, EstimatorControllerFinalizerName) {
+			continue
+		}
+		if disabled, err := estimatorDisabled(karmada, cluster); err == nil && !disabled {
+			count++
+		}
+	}
+	metrics.SchedulerEstimators.WithLabelValues(ctrl.estimatorNamespace, ctrl.karmadaName).Set(float64(count))
+}
+
 func (ctrl *EstimatorController) EnsureEstimator(ctx context.Context, karmada *installv1alpha1.Karmada, cluster *clusterv1alpha1.Cluster) error {
 	if err := ctrl.EnsureEstimatorKubeconfigSecret(ctx, karmada, cluster); err != nil {
 		return err
@@ -376,11 +431,15 @@ func (ctrl *EstimatorController) EnsureEstimator(ctx context.Context, karmada *i
 	if err := ctrl.EnsureEstimatorDeployment(ctx, karmada, cluster); err != nil {
 		return err
 	}
+
+	if err := ctrl.EnsureEstimatorPodDisruptionBudget(ctx, karmada, cluster); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (ctrl *EstimatorController) RemoveEstimator(ctx context.Context, karmada *installv1alpha1.Karmada, cluster *clusterv1alpha1.Cluster) error {
-	estimatorName := GenerateEstimatorName(karmada.Name, defaultEstimatorServicePrefix, cluster.Name)
+	estimatorName := GenerateEstimatorName(karmada, defaultEstimatorServicePrefix, cluster.Name)
 	err := ctrl.fireflyKubeClient.CoreV1().Services(karmada.Namespace).Delete(ctx, estimatorName, metav1.DeleteOptions{})
 	if err != nil && !errors.IsNotFound(err) {
 		return err
@@ -389,7 +448,11 @@ func (ctrl *EstimatorController) RemoveEstimator(ctx context.Context, karmada *i
 	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
-	secretName := GenerateEstimatorKubeConfigSecretName(karmada.Name, defaultEstimatorServicePrefix, cluster.Name)
+	err = ctrl.fireflyKubeClient.PolicyV1().PodDisruptionBudgets(karmada.Namespace).Delete(ctx, estimatorName, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	secretName := GenerateEstimatorKubeConfigSecretName(karmada, defaultEstimatorServicePrefix, cluster.Name)
 	err = ctrl.fireflyKubeClient.CoreV1().Secrets(karmada.Namespace).Delete(ctx, secretName, metav1.DeleteOptions{})
 	return client.IgnoreNotFound(err)
 }
@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/yaml"
 
@@ -91,7 +92,7 @@ func (ctrl *EstimatorController) EnsureEstimatorKubeconfigSecret(ctx context.Con
 	if err != nil {
 		return err
 	}
-	secretName := GenerateEstimatorKubeConfigSecretName(karmada.Name, defaultEstimatorServicePrefix, cluster.Name)
+	secretName := GenerateEstimatorKubeConfigSecretName(karmada, defaultEstimatorServicePrefix, cluster.Name)
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      secretName,
@@ -102,17 +103,20 @@ func (ctrl *EstimatorController) EnsureEstimatorKubeconfigSecret(ctx context.Con
 		},
 	}
 	controllerutil.SetOwnerReference(karmada, secret, scheme.Scheme)
-	return clientutil.CreateOrUpdateSecret(ctrl.fireflyKubeClient, secret)
+	return clientutil.ApplySecret(ctrl.fireflyKubeClient, secret)
 }
 
 func (ctrl *EstimatorController) EnsureEstimatorService(ctx context.Context, karmada *installv1alpha1.Karmada, cluster *clusterv1alpha1.Cluster) error {
-	estimatorName := GenerateEstimatorName(karmada.Name, defaultEstimatorServicePrefix, cluster.Name)
+	estimatorName := GenerateEstimatorName(karmada, defaultEstimatorServicePrefix, cluster.Name)
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      estimatorName,
 			Namespace: karmada.Namespace,
 			Labels: map[string]string{
 				"app": estimatorName,
+				// The labels select the estimators of the karmada in its ServiceMonitor.
+				constants.KarmadaLabel:   karmada.Name,
+				constants.ComponentLabel: constants.KarmadaComponentSchedulerEstimator,
 			},
 		},
 		Spec: corev1.ServiceSpec{
@@ -123,6 +127,12 @@ func (ctrl *EstimatorController) EnsureEstimatorService(ctx context.Context, kar
 					TargetPort: intstr.FromInt(10352),
 					Protocol:   corev1.ProtocolTCP,
 				},
+				{
+					Name:       "metrics",
+					Port:       10351,
+					TargetPort: intstr.FromInt(10351),
+					Protocol:   corev1.ProtocolTCP,
+				},
 			},
 			Selector: map[string]string{
 				"app": estimatorName,
@@ -130,14 +140,33 @@ func (ctrl *EstimatorController) EnsureEstimatorService(ctx context.Context, kar
 		},
 	}
 	controllerutil.SetOwnerReference(karmada, svc, scheme.Scheme)
-	return clientutil.CreateOrUpdateService(ctrl.fireflyKubeClient, svc)
+	return clientutil.ApplyService(ctrl.fireflyKubeClient, svc)
+}
+
+// karmadaVersion returns the karmada version of the estimators. They are upgraded once all the other
+// components of the karmada run the target version.
+func karmadaVersion(karmada *installv1alpha1.Karmada) string {
+	if karmada.Status.KarmadaVersion != "" {
+		return karmada.Status.KarmadaVersion
+	}
+	return karmada.Spec.KarmadaVersion
 }
 
 func (ctrl *EstimatorController) EnsureEstimatorDeployment(ctx context.Context, karmada *installv1alpha1.Karmada, cluster *clusterv1alpha1.Cluster) error {
-	estimatorName := GenerateEstimatorName(karmada.Name, defaultEstimatorServicePrefix, cluster.Name)
-	repository := karmada.Spec.ImageRepository
-	version := karmada.Spec.KarmadaVersion
+	estimatorName := GenerateEstimatorName(karmada, defaultEstimatorServicePrefix, cluster.Name)
 	estimator := karmada.Spec.Scheduler.KarmadaSchedulerEstimator
+	repository := karmada.Spec.ImageRepository
+	if estimator.ImageRepository != "" {
+		repository = estimator.ImageRepository
+	}
+	imageName := constants.KarmadaComponentSchedulerEstimator
+	if estimator.ImageName != "" {
+		imageName = estimator.ImageName
+	}
+	tag := karmadaVersion(karmada)
+	if estimator.ImageTag != "" {
+		tag = estimator.ImageTag
+	}
 
 	defaultArgs := map[string]string{
 		"kubeconfig":   "/etc/kuberentes/kubeconfig",
@@ -180,10 +209,11 @@ func (ctrl *EstimatorController) EnsureEstimatorDeployment(ctx context.Context,
 					},
 					Containers: []corev1.Container{
 						{
-							Name:    estimatorName,
-							Image:   util.ComponentImageName(repository, constants.KarmadaComponentSchedulerEstimator, version),
-							Command: []string{"/bin/karmada-scheduler-estimator"},
-							Args:    args,
+							Name:            estimatorName,
+							Image:           util.ComponentImage(karmada.Spec.ImageOverrides, constants.KarmadaComponentSchedulerEstimator, repository, imageName, tag),
+							ImagePullPolicy: util.ImagePullPolicy(estimator.ImagePullPolicy, karmada.Spec.ImagePullPolicy, corev1.PullIfNotPresent),
+							Command:         []string{"/bin/karmada-scheduler-estimator"},
+							Args:            args,
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									Name:      "kubeconfig",
@@ -217,16 +247,35 @@ func (ctrl *EstimatorController) EnsureEstimatorDeployment(ctx context.Context,
 			},
 		},
 	}
+	util.SetPodImagePullSecrets(&deployment.Spec.Template.Spec, estimator.ImagePullSecrets, karmada.Spec.ImagePullSecrets)
+	util.SetPodPlacement(&deployment.Spec.Template.Spec, estimator.Placement, karmada.Spec.Placement)
+	util.SetDefaultPodAntiAffinity(&deployment.Spec.Template.Spec, deployment.Spec.Selector.MatchLabels, deployment.Spec.Replicas)
 	controllerutil.SetOwnerReference(karmada, deployment, scheme.Scheme)
-	return clientutil.CreateOrUpdateDeployment(ctrl.fireflyKubeClient, deployment)
+	return clientutil.ApplyDeployment(ctrl.fireflyKubeClient, deployment)
+}
+
+// EnsureEstimatorPodDisruptionBudget ensures the PodDisruptionBudget of the estimator of the cluster exists when
+// the estimator has more than one replica, and removes it otherwise.
+func (ctrl *EstimatorController) EnsureEstimatorPodDisruptionBudget(ctx context.Context, karmada *installv1alpha1.Karmada, cluster *clusterv1alpha1.Cluster) error {
+	estimatorName := GenerateEstimatorName(karmada, defaultEstimatorServicePrefix, cluster.Name)
+	estimator := karmada.Spec.Scheduler.KarmadaSchedulerEstimator
+	pdb := util.PodDisruptionBudget(estimatorName, karmada.Namespace, map[string]string{"app": estimatorName}, estimator.Replicas, estimator.PodDisruptionBudget)
+	if pdb == nil {
+		err := ctrl.fireflyKubeClient.PolicyV1().PodDisruptionBudgets(karmada.Namespace).Delete(ctx, estimatorName, metav1.DeleteOptions{})
+		return client.IgnoreNotFound(err)
+	}
+	controllerutil.SetOwnerReference(karmada, pdb, scheme.Scheme)
+	return clientutil.ApplyPodDisruptionBudget(ctrl.fireflyKubeClient, pdb)
 }
 
 // GenerateEstimatorName generates the gRPC scheduler estimator service name which belongs to a cluster.
-func GenerateEstimatorName(karmadaName, estimatorServicePrefix, clusterName string) string {
-	return fmt.Sprintf("%s-%s", estimatorServicePrefix, clusterName)
+// The service prefix is named like a component of the karmada, the karmada-scheduler is started with the
+// same prefix to look up the estimator of a cluster.
+func GenerateEstimatorName(karmada *installv1alpha1.Karmada, estimatorServicePrefix, clusterName string) string {
+	return fmt.Sprintf("%s-%s", util.KarmadaComponentName(karmada, estimatorServicePrefix), clusterName)
 }
 
 // GenerateEstimatorKubeConfigName generates the secret name which holds kubeconfig content.
-func GenerateEstimatorKubeConfigSecretName(karmadaName, estimatorServicePrefix, clusterName string) string {
-	return fmt.Sprintf("%s-%s-kubeconfig", estimatorServicePrefix, clusterName)
+func GenerateEstimatorKubeConfigSecretName(karmada *installv1alpha1.Karmada, estimatorServicePrefix, clusterName string) string {
+	return fmt.Sprintf("%s-kubeconfig", GenerateEstimatorName(karmada, estimatorServicePrefix, clusterName))
 }
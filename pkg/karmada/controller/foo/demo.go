@@ -31,6 +31,7 @@ import (
 
 	toolkitv1alpha1 "github.com/carlory/firefly/pkg/karmada/apis/toolkit/v1alpha1"
 	"github.com/carlory/firefly/pkg/karmada/util"
+	"github.com/carlory/firefly/pkg/metrics"
 )
 
 func (ctrl *FooController) buildWorks(ctx context.Context, foo *toolkitv1alpha1.Foo, clusters []*clusterv1alpha1.Cluster) error {
@@ -165,7 +166,13 @@ func (ctrl *FooController) buildWork(ctx context.Context, foo *toolkitv1alpha1.F
 			},
 		},
 	}
-	return ctrl.createOrUpdateWork(ctx, work)
+	err = ctrl.createOrUpdateWork(ctx, work)
+	if err != nil {
+		metrics.WorkPropagations.WithLabelValues(metrics.PropagationResultError).Inc()
+		return err
+	}
+	metrics.WorkPropagations.WithLabelValues(metrics.PropagationResultSuccess).Inc()
+	return nil
 }
 
 func (ctrl *FooController) createOrUpdateWork(ctx context.Context, work *workv1alpha1.Work) error {
@@ -48,6 +48,7 @@ import (
 	fireflyclient "github.com/carlory/firefly/pkg/karmada/generated/clientset/versioned"
 	toolkitinformers "github.com/carlory/firefly/pkg/karmada/generated/informers/externalversions/toolkit/v1alpha1"
 	toolkitlisters "github.com/carlory/firefly/pkg/karmada/generated/listers/toolkit/v1alpha1"
+	"github.com/carlory/firefly/pkg/metrics"
 	"github.com/carlory/firefly/pkg/scheme"
 )
 
@@ -79,6 +80,8 @@ func NewFooController(
 	if client != nil && client.CoreV1().RESTClient().GetRateLimiter() != nil {
 		ratelimiter.RegisterMetricAndTrackRateLimiterUsage("foo_controller", client.CoreV1().RESTClient().GetRateLimiter())
 	}
+	metrics.Register()
+	metrics.RegisterFooCollector(workInformer.Lister())
 
 	ctrl := &FooController{
 		restMapper:           restMapper,
@@ -175,7 +178,9 @@ func (ctrl *FooController) processNextWorkItem(ctx context.Context) bool {
 	}
 	defer ctrl.queue.Done(key)
 
+	startTime := time.Now()
 	err := ctrl.syncFoo(ctx, key.(string))
+	metrics.ObserveReconcile("foo", metrics.PhaseSync, startTime, err)
 	ctrl.handleErr(err, key)
 
 	return true
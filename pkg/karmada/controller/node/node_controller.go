@@ -38,6 +38,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/carlory/firefly/pkg/karmada/scheme"
+	"github.com/carlory/firefly/pkg/metrics"
 )
 
 const (
@@ -61,6 +62,7 @@ func NewNodeController(
 	if karmadaKubeClient != nil && karmadaKubeClient.CoreV1().RESTClient().GetRateLimiter() != nil {
 		ratelimiter.RegisterMetricAndTrackRateLimiterUsage("node_controller", karmadaKubeClient.CoreV1().RESTClient().GetRateLimiter())
 	}
+	metrics.Register()
 
 	ctrl := &NodeController{
 		karmadaKubeClient:  karmadaKubeClient,
@@ -153,7 +155,9 @@ func (ctrl *NodeController) processNextWorkItem(ctx context.Context) bool {
 	}
 	defer ctrl.queue.Done(key)
 
+	startTime := time.Now()
 	err := ctrl.syncNode(ctx, key.(string))
+	metrics.ObserveReconcile("node", metrics.PhaseSync, startTime, err)
 	ctrl.handleErr(err, key)
 
 	return true
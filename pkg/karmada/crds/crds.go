@@ -14,34 +14,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package util
+// Package crds holds the crds of the firefly-karmada-manager which are installed into the karmada-apiserver.
+package crds
 
-import (
-	"io/ioutil"
-	"net"
-	"net/http"
+import "embed"
 
-	netutils "k8s.io/utils/net"
-)
-
-const (
-	// Query the IP address of the current host accessing the Internet
-	getInternetIPUrl = "https://myexternalip.com/raw"
-)
-
-// InternetIP Current host Internet IP.
-func InternetIP() (net.IP, error) {
-	resp, err := http.Get(getInternetIPUrl)
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	content, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	return netutils.ParseIPSloppy(string(content)), nil
-}
+// CRDs holds the generated crd manifests.
+//
+//go:embed *.yaml
+var CRDs embed.FS
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strconv"
+	"sync"
+
+	workv1alpha1 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha1"
+	worklisters "github.com/karmada-io/karmada/pkg/generated/listers/work/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+
+	toolkitv1alpha1 "github.com/carlory/firefly/pkg/karmada/apis/toolkit/v1alpha1"
+)
+
+var fooWorksDesc = metrics.NewDesc(
+	fireflyNamespace+"_foo_works",
+	"Number of the works of a foo in the karmada by whether they are applied to the member clusters.",
+	[]string{"namespace", "name", "applied"}, nil,
+	metrics.ALPHA, "")
+
+var registerFooCollector sync.Once
+
+// RegisterFooCollector registers the collector of the works of the foos in the lister, which are counted when
+// the metrics are scraped.
+func RegisterFooCollector(lister worklisters.WorkLister) {
+	registerFooCollector.Do(func() {
+		legacyregistry.CustomMustRegister(&fooCollector{lister: lister})
+	})
+}
+
+type fooCollector struct {
+	metrics.BaseStableCollector
+
+	lister worklisters.WorkLister
+}
+
+var _ metrics.StableCollector = &fooCollector{}
+
+func (c *fooCollector) DescribeWithStability(ch chan<- *metrics.Desc) {
+	ch <- fooWorksDesc
+}
+
+func (c *fooCollector) CollectWithStability(ch chan<- metrics.Metric) {
+	requirement, err := labels.NewRequirement(toolkitv1alpha1.FooNameLabel, selection.Exists, nil)
+	if err != nil {
+		klog.ErrorS(err, "Failed to build the selector of the works of the foos")
+		return
+	}
+	works, err := c.lister.List(labels.NewSelector().Add(*requirement))
+	if err != nil {
+		klog.ErrorS(err, "Failed to list works for metrics")
+		return
+	}
+
+	type fooWorks struct {
+		namespace, name string
+		applied         bool
+	}
+	counts := map[fooWorks]int{}
+	for _, work := range works {
+		key := fooWorks{
+			namespace: work.Labels[toolkitv1alpha1.FooNamespaceLabel],
+			name:      work.Labels[toolkitv1alpha1.FooNameLabel],
+			applied:   meta.IsStatusConditionTrue(work.Status.Conditions, workv1alpha1.WorkApplied),
+		}
+		counts[key]++
+	}
+	for key, count := range counts {
+		ch <- metrics.NewLazyConstMetric(fooWorksDesc, metrics.GaugeValue, float64(count), key.namespace, key.name, strconv.FormatBool(key.applied))
+	}
+}
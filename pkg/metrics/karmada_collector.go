@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	installlisters "github.com/carlory/firefly/pkg/generated/listers/install/v1alpha1"
+)
+
+var (
+	karmadaReadyDesc = metrics.NewDesc(
+		fireflyNamespace+"_karmada_ready",
+		"Whether all the components of a karmada are ready.",
+		[]string{"namespace", "name"}, nil,
+		metrics.ALPHA, "")
+
+	karmadaComponentReadyDesc = metrics.NewDesc(
+		fireflyNamespace+"_karmada_component_ready",
+		"Whether the components of a karmada are ready, by the Ready conditions of the karmada.",
+		[]string{"namespace", "name", "component"}, nil,
+		metrics.ALPHA, "")
+
+	karmadaCertificateExpirationDesc = metrics.NewDesc(
+		fireflyNamespace+"_karmada_certificate_expiration_timestamp_seconds",
+		"Unix timestamp in seconds at which a certificate of a karmada expires.",
+		[]string{"namespace", "name", "certificate"}, nil,
+		metrics.ALPHA, "")
+)
+
+var registerKarmadaCollector sync.Once
+
+// RegisterKarmadaCollector registers the collector of the state of the karmadas in the lister. The state is
+// collected from the status of the karmadas when the metrics are scraped, so the metrics of a deleted karmada
+// go away with it.
+func RegisterKarmadaCollector(lister installlisters.KarmadaLister) {
+	registerKarmadaCollector.Do(func() {
+		legacyregistry.CustomMustRegister(&karmadaCollector{lister: lister})
+	})
+}
+
+type karmadaCollector struct {
+	metrics.BaseStableCollector
+
+	lister installlisters.KarmadaLister
+}
+
+var _ metrics.StableCollector = &karmadaCollector{}
+
+func (c *karmadaCollector) DescribeWithStability(ch chan<- *metrics.Desc) {
+	ch <- karmadaReadyDesc
+	ch <- karmadaComponentReadyDesc
+	ch <- karmadaCertificateExpirationDesc
+}
+
+func (c *karmadaCollector) CollectWithStability(ch chan<- metrics.Metric) {
+	karmadas, err := c.lister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list karmadas for metrics")
+		return
+	}
+	for _, karmada := range karmadas {
+		for _, condition := range karmada.Status.Conditions {
+			value := boolValue(condition.Status == metav1.ConditionTrue)
+			if condition.Type == installv1alpha1.KarmadaConditionReady {
+				ch <- metrics.NewLazyConstMetric(karmadaReadyDesc, metrics.GaugeValue, value, karmada.Namespace, karmada.Name)
+				continue
+			}
+			// The conditions of the components are named after them, e.g. EtcdReady.
+			if component := strings.TrimSuffix(condition.Type, "Ready"); component != condition.Type {
+				ch <- metrics.NewLazyConstMetric(karmadaComponentReadyDesc, metrics.GaugeValue, value, karmada.Namespace, karmada.Name, component)
+			}
+		}
+		for _, certificate := range karmada.Status.Certificates {
+			ch <- metrics.NewLazyConstMetric(karmadaCertificateExpirationDesc, metrics.GaugeValue, float64(certificate.NotAfter.Unix()), karmada.Namespace, karmada.Name, certificate.Name)
+		}
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics holds the prometheus metrics of the firefly controllers. They are registered into the
+// legacy registry, which is served on the /metrics endpoint of the secure serving of the managers.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	fireflyNamespace = "firefly"
+
+	// PhaseSync is the phase of a whole sync of an object by a controller.
+	PhaseSync = "sync"
+
+	// PropagationResultSuccess is the result of a work which is propagated.
+	PropagationResultSuccess = "success"
+	// PropagationResultError is the result of a work which fails to be propagated.
+	PropagationResultError = "error"
+)
+
+var (
+	// reconcileDuration tracks the duration of the reconciliations of the controllers.
+	reconcileDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Namespace:      fireflyNamespace,
+			Name:           "reconcile_duration_seconds",
+			Help:           "Duration in seconds of the reconciliations by controller and phase.",
+			Buckets:        metrics.ExponentialBuckets(0.005, 2, 14),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"controller", "phase"},
+	)
+
+	// reconcileErrors tracks the number of the reconciliations which fail.
+	reconcileErrors = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      fireflyNamespace,
+			Name:           "reconcile_errors_total",
+			Help:           "Number of the reconciliations which fail by controller and phase.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"controller", "phase"},
+	)
+
+	// SchedulerEstimators tracks the number of the karmada-scheduler-estimators of a karmada.
+	SchedulerEstimators = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      fireflyNamespace,
+			Name:           "karmada_scheduler_estimators",
+			Help:           "Number of the karmada-scheduler-estimators which are deployed for the member clusters of a karmada.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"namespace", "karmada"},
+	)
+
+	// AppliedObjectDrifts tracks the number of the times the objects applied by firefly drifted from their
+	// applied configuration.
+	AppliedObjectDrifts = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      fireflyNamespace,
+			Name:           "applied_object_drifts_total",
+			Help:           "Number of the times the objects applied by firefly were changed by another actor by kind.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"kind"},
+	)
+
+	// WorkPropagations tracks the number of the works which are propagated for the foos.
+	WorkPropagations = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      fireflyNamespace,
+			Name:           "foo_work_propagations_total",
+			Help:           "Number of the works which are created or updated for the foos by result.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"result"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register registers the metrics of the controllers.
+func Register() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(reconcileDuration)
+		legacyregistry.MustRegister(reconcileErrors)
+		legacyregistry.MustRegister(SchedulerEstimators)
+		legacyregistry.MustRegister(WorkPropagations)
+		legacyregistry.MustRegister(AppliedObjectDrifts)
+	})
+}
+
+// ObserveReconcile records the duration of a reconciliation which started at the given time, and its error.
+func ObserveReconcile(controller, phase string, startTime time.Time, err error) {
+	reconcileDuration.WithLabelValues(controller, phase).Observe(time.Since(startTime).Seconds())
+	if err != nil {
+		reconcileErrors.WithLabelValues(controller, phase).Inc()
+	}
+}
@@ -224,106 +224,70 @@ func NewCertConfig(cn string, org []string, altNames certutil.AltNames, notAfter
 	}
 }
 
-// GenCerts Create CA certificate and sign etcd karmada certificate.
-func GenCerts(etcdServerCertCfg, etcdClientCertCfg, karmadaCertCfg, apiserverCertCfg, frontProxyClientCertCfg *CertsConfig) (map[string][]byte, error) {
-	caCert, caKey, err := NewCACertAndKey("karmada")
-	if err != nil {
-		return nil, err
-	}
-
-	data := make(map[string][]byte)
-
-	encodedCAKey, err := keyutil.MarshalPrivateKeyToPEM(caKey)
-	if err != nil {
-		return nil, err
-	}
-	encodedCACert := EncodeCertPEM(caCert)
-	data["ca.key"] = encodedCAKey
-	data["ca.crt"] = encodedCACert
-
-	karmadaCert, karmadaKey, err := NewCertAndKey(caCert, caKey, karmadaCertCfg)
-	if err != nil {
-		return nil, err
+// ParseCertAndKey parses the PEM encoded certificate and private key.
+func ParseCertAndKey(certData, keyData []byte) (*x509.Certificate, crypto.Signer, error) {
+	if len(certData) == 0 || len(keyData) == 0 {
+		return nil, nil, errors.New("certificate or key is empty")
 	}
-	encodedKarmadaKey, err := keyutil.MarshalPrivateKeyToPEM(karmadaKey)
+	certs, err := certutil.ParseCertsPEM(certData)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	encodedKarmadaCert := EncodeCertPEM(karmadaCert)
-	data["karmada.key"] = encodedKarmadaKey
-	data["karmada.crt"] = encodedKarmadaCert
-
-	apiserverCert, apiserverKey, err := NewCertAndKey(caCert, caKey, apiserverCertCfg)
+	key, err := keyutil.ParsePrivateKeyPEM(keyData)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	encodedApiserverKey, err := keyutil.MarshalPrivateKeyToPEM(apiserverKey)
-	if err != nil {
-		return nil, err
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.New("private key is not a crypto.Signer")
 	}
-	encodedApiserverCert := EncodeCertPEM(apiserverCert)
-	data["apiserver.key"] = encodedApiserverKey
-	data["apiserver.crt"] = encodedApiserverCert
+	return certs[0], signer, nil
+}
 
-	frontProxyCaCert, frontProxyCaKey, err := NewCACertAndKey("front-proxy-ca")
+// EncodeCertAndKeyPEM returns the PEM encoded certificate and private key.
+func EncodeCertAndKeyPEM(cert *x509.Certificate, key crypto.Signer) ([]byte, []byte, error) {
+	encodedKey, err := keyutil.MarshalPrivateKeyToPEM(key)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	encodedFrontProxyCaKey, err := keyutil.MarshalPrivateKeyToPEM(frontProxyCaKey)
-	if err != nil {
-		return nil, err
-	}
-	encodedFrontProxyCaCert := EncodeCertPEM(frontProxyCaCert)
-	data["front-proxy-ca.key"] = encodedFrontProxyCaKey
-	data["front-proxy-ca.crt"] = encodedFrontProxyCaCert
+	return EncodeCertPEM(cert), encodedKey, nil
+}
 
-	frontProxyClientCert, frontProxyClientKey, err := NewCertAndKey(frontProxyCaCert, frontProxyCaKey, frontProxyClientCertCfg)
-	if err != nil {
-		return nil, err
+// CertRenewalReason returns the reason why the certificate must be issued again by the CA with the given
+// config, or an empty string if the certificate is still valid for more than renewBefore and covers all
+// the alternative names of the config.
+func CertRenewalReason(cert *x509.Certificate, caCert *x509.Certificate, config *CertsConfig, renewBefore time.Duration) string {
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		return "the certificate is not signed by the current CA"
 	}
-	encodedFrontProxyClientKey, err := keyutil.MarshalPrivateKeyToPEM(frontProxyClientKey)
-	if err != nil {
-		return nil, err
+	if time.Until(cert.NotAfter) < renewBefore {
+		return fmt.Sprintf("the certificate expires at %s", cert.NotAfter.Format(time.RFC3339))
 	}
-	encodedFrontProxyClientCert := EncodeCertPEM(frontProxyClientCert)
-	data["front-proxy-client.key"] = encodedFrontProxyClientKey
-	data["front-proxy-client.crt"] = encodedFrontProxyClientCert
-
-	etcdCaCert, etcdCaKey, err := NewCACertAndKey("etcd-ca")
-	if err != nil {
-		return nil, err
+	if cert.Subject.CommonName != config.CommonName || !equalStrings(cert.Subject.Organization, config.Organization) {
+		return "the subject of the certificate is changed"
 	}
-	encodedEtcdCaKey, err := keyutil.MarshalPrivateKeyToPEM(etcdCaKey)
-	if err != nil {
-		return nil, err
-	}
-	encodedEtcdCaCert := EncodeCertPEM(etcdCaCert)
-	data["etcd-ca.key"] = encodedEtcdCaKey
-	data["etcd-ca.crt"] = encodedEtcdCaCert
 
-	etcdServerCert, etcdServerKey, err := NewCertAndKey(etcdCaCert, etcdCaKey, etcdServerCertCfg)
-	if err != nil {
-		return nil, err
+	altNames := config.AltNames
+	RemoveDuplicateAltNames(&altNames)
+	if !sets.NewString(cert.DNSNames...).HasAll(altNames.DNSNames...) {
+		return "the certificate doesn't cover all the desired DNS names"
 	}
-	encodedEtcdServerKey, err := keyutil.MarshalPrivateKeyToPEM(etcdServerKey)
-	if err != nil {
-		return nil, err
+	certIPs := sets.NewString()
+	for _, ip := range cert.IPAddresses {
+		certIPs.Insert(ip.String())
 	}
-	encodedEtcdServerCert := EncodeCertPEM(etcdServerCert)
-	data["etcd-server.key"] = encodedEtcdServerKey
-	data["etcd-server.crt"] = encodedEtcdServerCert
-
-	etcdClientCert, etcdClientKey, err := NewCertAndKey(etcdCaCert, etcdCaKey, etcdClientCertCfg)
-	if err != nil {
-		return nil, err
-	}
-	encodedEtcdClientKey, err := keyutil.MarshalPrivateKeyToPEM(etcdClientKey)
-	if err != nil {
-		return nil, err
+	for _, ip := range altNames.IPs {
+		if !certIPs.Has(ip.String()) {
+			return "the certificate doesn't cover all the desired IP addresses"
+		}
 	}
-	encodedEtcdClientCert := EncodeCertPEM(etcdClientCert)
-	data["etcd-client.key"] = encodedEtcdClientKey
-	data["etcd-client.crt"] = encodedEtcdClientCert
+	return ""
+}
 
-	return data, nil
+// equalStrings returns whether the two lists contain the same non-empty strings regardless of the order and duplicates.
+func equalStrings(a, b []string) bool {
+	sa, sb := sets.NewString(a...), sets.NewString(b...)
+	sa.Delete("")
+	sb.Delete("")
+	return sa.Len() == sb.Len() && sa.HasAll(sb.List()...)
 }
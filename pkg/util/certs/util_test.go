@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"crypto"
+	"crypto/x509"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	certutil "k8s.io/client-go/util/cert"
+)
+
+func newTestCA(t *testing.T) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+	caCert, caKey, err := NewCertificateAuthority(&CertsConfig{Config: certutil.Config{CommonName: "karmada"}, PublicKeyAlgorithm: x509.ECDSA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return caCert, caKey
+}
+
+// newTestCertConfig returns the config of a certificate which is valid for the given duration.
+func newTestCertConfig(validFor time.Duration) *CertsConfig {
+	notAfter := time.Now().Add(validFor)
+	config := NewCertConfig("karmada-apiserver", []string{"system:masters"}, certutil.AltNames{
+		DNSNames: []string{"localhost", "karmada-apiserver.firefly-system.svc"},
+		IPs:      []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("10.0.0.1")},
+	}, &notAfter)
+	config.PublicKeyAlgorithm = x509.ECDSA
+	return config
+}
+
+func TestCertRenewalReason(t *testing.T) {
+	caCert, caKey := newTestCA(t)
+	otherCACert, _ := newTestCA(t)
+	renewBefore := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		validFor time.Duration
+		caCert   *x509.Certificate
+		// mutate changes the desired config after the certificate is issued.
+		mutate func(config *CertsConfig)
+		want   string
+	}{
+		{
+			name:     "valid",
+			validFor: Duration365d,
+		},
+		{
+			name:     "signed by another CA",
+			validFor: Duration365d,
+			caCert:   otherCACert,
+			want:     "not signed by the current CA",
+		},
+		{
+			name:     "expiring",
+			validFor: 7 * 24 * time.Hour,
+			want:     "expires at",
+		},
+		{
+			name:     "common name changed",
+			validFor: Duration365d,
+			mutate:   func(config *CertsConfig) { config.CommonName = "karmada-admin" },
+			want:     "subject of the certificate is changed",
+		},
+		{
+			name:     "organization changed",
+			validFor: Duration365d,
+			mutate:   func(config *CertsConfig) { config.Organization = []string{"karmada"} },
+			want:     "subject of the certificate is changed",
+		},
+		{
+			name:     "duplicated organization",
+			validFor: Duration365d,
+			mutate:   func(config *CertsConfig) { config.Organization = []string{"system:masters", "system:masters"} },
+		},
+		{
+			name:     "DNS name added",
+			validFor: Duration365d,
+			mutate: func(config *CertsConfig) {
+				config.AltNames.DNSNames = append(config.AltNames.DNSNames, "karmada.example.com")
+			},
+			want: "DNS names",
+		},
+		{
+			name:     "IP address added",
+			validFor: Duration365d,
+			mutate: func(config *CertsConfig) {
+				config.AltNames.IPs = append(config.AltNames.IPs, net.ParseIP("192.168.0.1"))
+			},
+			want: "IP addresses",
+		},
+		{
+			name:     "alternative names removed and duplicated",
+			validFor: Duration365d,
+			mutate: func(config *CertsConfig) {
+				config.AltNames.DNSNames = []string{"localhost", "localhost"}
+				config.AltNames.IPs = []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("127.0.0.1")}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert, _, err := NewCertAndKey(caCert, caKey, newTestCertConfig(tt.validFor))
+			if err != nil {
+				t.Fatal(err)
+			}
+			config := newTestCertConfig(tt.validFor)
+			if tt.mutate != nil {
+				tt.mutate(config)
+			}
+			currentCA := caCert
+			if tt.caCert != nil {
+				currentCA = tt.caCert
+			}
+
+			got := CertRenewalReason(cert, currentCA, config, renewBefore)
+			if tt.want == "" && got != "" {
+				t.Errorf("CertRenewalReason() = %q, want no renewal", got)
+			}
+			if tt.want != "" && !strings.Contains(got, tt.want) {
+				t.Errorf("CertRenewalReason() = %q, want a reason containing %q", got, tt.want)
+			}
+		})
+	}
+}
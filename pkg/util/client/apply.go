@@ -0,0 +1,253 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+
+	"github.com/carlory/firefly/pkg/constants"
+	"github.com/carlory/firefly/pkg/metrics"
+)
+
+// applyScheme resolves the kinds of the typed objects which are applied.
+var applyScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(applyScheme))
+	utilruntime.Must(apiregistrationv1.AddToScheme(applyScheme))
+	utilruntime.Must(clusterv1alpha1.AddToScheme(applyScheme))
+}
+
+// writeOnlyFields are the fields of the applied configuration which are never returned by the server, so
+// they aren't compared with the live object.
+var writeOnlyFields = map[string]bool{
+	// The string data of a secret is merged into its data by the server.
+	".stringData": true,
+}
+
+// driftRecorder records the events of the drift of the applied objects, see SetDriftEventRecorder.
+var driftRecorder record.EventRecorder
+
+// SetDriftEventRecorder sets the recorder which the drift of the applied objects is reported with. The events
+// are recorded on the controller owners of the objects, or on the objects themselves if they have no owner.
+// It must be called before the objects are applied.
+func SetDriftEventRecorder(recorder record.EventRecorder) {
+	driftRecorder = recorder
+}
+
+// resourceClient is the part of a typed client of a resource which is needed to apply its objects.
+type resourceClient[T runtime.Object] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (T, error)
+}
+
+// Apply applies the object through the client with server-side apply as the firefly field manager, so only
+// the fields set in the object are owned by firefly and the fields set by other actors are kept.
+//
+// The hash of the applied configuration is recorded in an annotation of the object, and the apply is skipped
+// when the object already has the hash and its fields still match the configuration. If the fields managed by
+// firefly were changed by another actor, the drift is reported and the configuration is applied again.
+func Apply[T runtime.Object](client resourceClient[T], obj T) error {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		gvks, _, err := applyScheme.ObjectKinds(obj)
+		if err != nil {
+			return err
+		}
+		gvk = gvks[0]
+	}
+
+	desired, err := appliedConfiguration(obj)
+	if err != nil {
+		return err
+	}
+	desired.SetAPIVersion(gvk.GroupVersion().String())
+	desired.SetKind(gvk.Kind)
+	hash, err := configurationHash(desired)
+	if err != nil {
+		return err
+	}
+	annotations := desired.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constants.AppliedHashAnnotation] = hash
+	desired.SetAnnotations(annotations)
+
+	got, err := client.Get(context.TODO(), accessor.GetName(), metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		live, err := runtime.DefaultUnstructuredConverter.ToUnstructured(got)
+		if err != nil {
+			return err
+		}
+		liveAccessor, err := meta.Accessor(got)
+		if err != nil {
+			return err
+		}
+		if liveAccessor.GetAnnotations()[constants.AppliedHashAnnotation] == hash {
+			drifted := driftedFields(live, desired.Object, "")
+			if len(drifted) == 0 {
+				return nil
+			}
+			sort.Strings(drifted)
+			reportDrift(gvk, liveAccessor, drifted)
+		}
+	}
+
+	data, err := json.Marshal(desired.Object)
+	if err != nil {
+		return err
+	}
+	force := true
+	_, err = client.Patch(context.TODO(), accessor.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{FieldManager: constants.FieldManager, Force: &force})
+	if err != nil {
+		return fmt.Errorf("failed to apply %s %q: %v", gvk.Kind, accessor.GetName(), err)
+	}
+	return nil
+}
+
+// reportDrift reports that the fields of the live object drifted from the applied configuration.
+func reportDrift(gvk schema.GroupVersionKind, live metav1.Object, drifted []string) {
+	klog.InfoS("Object drifted from the applied configuration, applying it again", "kind", gvk.Kind,
+		"object", klog.KRef(live.GetNamespace(), live.GetName()), "fields", drifted)
+	metrics.AppliedObjectDrifts.WithLabelValues(gvk.Kind).Inc()
+	if driftRecorder == nil {
+		return
+	}
+
+	ref := &corev1.ObjectReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  live.GetNamespace(),
+		Name:       live.GetName(),
+		UID:        live.GetUID(),
+	}
+	if owner := metav1.GetControllerOf(live); owner != nil {
+		// The owner of a namespaced object is in the same namespace.
+		ref = &corev1.ObjectReference{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Namespace:  live.GetNamespace(),
+			Name:       owner.Name,
+			UID:        owner.UID,
+		}
+	}
+	driftRecorder.Eventf(ref, corev1.EventTypeWarning, "ObjectDrifted", "%s %s drifted from the applied configuration in %s, applying it again",
+		gvk.Kind, live.GetName(), strings.Join(drifted, ", "))
+}
+
+// ApplyUnstructured applies the unstructured object through the dynamic client of its resource, see Apply.
+func ApplyUnstructured(client dynamic.ResourceInterface, obj *unstructured.Unstructured) error {
+	return Apply[*unstructured.Unstructured](dynamicResourceClient{client}, obj)
+}
+
+// dynamicResourceClient adapts a dynamic client to the client of a resource.
+type dynamicResourceClient struct {
+	dynamic.ResourceInterface
+}
+
+func (c dynamicResourceClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*unstructured.Unstructured, error) {
+	return c.ResourceInterface.Get(ctx, name, opts)
+}
+
+// appliedConfiguration returns the configuration of the object which is applied, without its status and the
+// metadata which is set by the server.
+func appliedConfiguration(obj runtime.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	desired := &unstructured.Unstructured{Object: content}
+	delete(desired.Object, "status")
+	for _, field := range []string{"creationTimestamp", "resourceVersion", "uid", "generation", "managedFields"} {
+		unstructured.RemoveNestedField(desired.Object, "metadata", field)
+	}
+	return desired, nil
+}
+
+// configurationHash returns the hash of the applied configuration.
+func configurationHash(desired *unstructured.Unstructured) (string, error) {
+	data, err := json.Marshal(desired.Object)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// driftedFields returns the paths of the fields of the desired configuration whose values differ in the
+// live object. The fields which are only set in the live object are ignored, as they are defaulted by the
+// server or owned by other actors.
+func driftedFields(live, desired interface{}, path string) []string {
+	switch desiredValue := desired.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		liveValue, _ := live.(map[string]interface{})
+		var drifted []string
+		for key, value := range desiredValue {
+			if writeOnlyFields[path+"."+key] {
+				continue
+			}
+			drifted = append(drifted, driftedFields(liveValue[key], value, path+"."+key)...)
+		}
+		return drifted
+	case []interface{}:
+		liveValue, _ := live.([]interface{})
+		if len(liveValue) < len(desiredValue) {
+			return []string{path}
+		}
+		var drifted []string
+		for i, value := range desiredValue {
+			drifted = append(drifted, driftedFields(liveValue[i], value, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return drifted
+	default:
+		if !reflect.DeepEqual(live, desired) {
+			return []string{path}
+		}
+		return nil
+	}
+}
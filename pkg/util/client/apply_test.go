@@ -0,0 +1,247 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+
+	"github.com/carlory/firefly/pkg/constants"
+)
+
+// fakeSecretClient serves a single live secret and records the patches.
+type fakeSecretClient struct {
+	live    *corev1.Secret
+	patches int
+}
+
+func (c *fakeSecretClient) Get(_ context.Context, name string, _ metav1.GetOptions) (*corev1.Secret, error) {
+	if c.live == nil {
+		return nil, errors.NewNotFound(corev1.Resource("secrets"), name)
+	}
+	return c.live.DeepCopy(), nil
+}
+
+func (c *fakeSecretClient) Patch(_ context.Context, _ string, _ types.PatchType, _ []byte, _ metav1.PatchOptions, _ ...string) (*corev1.Secret, error) {
+	c.patches++
+	return c.live, nil
+}
+
+func testSecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "karmada-cert",
+			Namespace: "firefly-system",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "install.firefly.io/v1alpha1", Kind: "Karmada", Name: "karmada", UID: "uid", Controller: utilpointer.Bool(true)},
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{"ca.crt": []byte("ca")},
+	}
+}
+
+// appliedSecret returns the live secret which the desired one was applied as.
+func appliedSecret(t *testing.T, desired *corev1.Secret) *corev1.Secret {
+	t.Helper()
+	configuration, err := appliedConfiguration(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := configurationHash(configuration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := desired.DeepCopy()
+	live.UID = "live-uid"
+	live.ResourceVersion = "1"
+	live.Annotations = map[string]string{constants.AppliedHashAnnotation: hash}
+	// The server merges the string data into the data.
+	for key, value := range live.StringData {
+		if live.Data == nil {
+			live.Data = map[string][]byte{}
+		}
+		live.Data[key] = []byte(value)
+	}
+	live.StringData = nil
+	return live
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		desired     func() *corev1.Secret
+		live        func(t *testing.T, desired *corev1.Secret) *corev1.Secret
+		wantPatches int
+		wantEvent   bool
+	}{
+		{
+			name:        "object doesn't exist",
+			desired:     testSecret,
+			live:        func(*testing.T, *corev1.Secret) *corev1.Secret { return nil },
+			wantPatches: 1,
+		},
+		{
+			name:        "object is unchanged",
+			desired:     testSecret,
+			live:        appliedSecret,
+			wantPatches: 0,
+		},
+		{
+			name: "string data is not compared",
+			desired: func() *corev1.Secret {
+				secret := testSecret()
+				secret.Data = nil
+				secret.StringData = map[string]string{"ca.crt": "ca"}
+				return secret
+			},
+			live:        appliedSecret,
+			wantPatches: 0,
+		},
+		{
+			name:    "fields set by other actors are ignored",
+			desired: testSecret,
+			live: func(t *testing.T, desired *corev1.Secret) *corev1.Secret {
+				live := appliedSecret(t, desired)
+				live.Labels = map[string]string{"foo": "bar"}
+				live.Data["extra"] = []byte("extra")
+				return live
+			},
+			wantPatches: 0,
+		},
+		{
+			name:    "object drifted",
+			desired: testSecret,
+			live: func(t *testing.T, desired *corev1.Secret) *corev1.Secret {
+				live := appliedSecret(t, desired)
+				live.Data["ca.crt"] = []byte("changed")
+				return live
+			},
+			wantPatches: 1,
+			wantEvent:   true,
+		},
+		{
+			name: "configuration changed",
+			desired: func() *corev1.Secret {
+				secret := testSecret()
+				secret.Data["ca.key"] = []byte("key")
+				return secret
+			},
+			live: func(t *testing.T, _ *corev1.Secret) *corev1.Secret {
+				return appliedSecret(t, testSecret())
+			},
+			wantPatches: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			SetDriftEventRecorder(recorder)
+			defer SetDriftEventRecorder(nil)
+
+			desired := tt.desired()
+			client := &fakeSecretClient{live: tt.live(t, desired)}
+			if err := Apply[*corev1.Secret](client, desired); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if client.patches != tt.wantPatches {
+				t.Errorf("Apply() patched %d times, want %d", client.patches, tt.wantPatches)
+			}
+			select {
+			case event := <-recorder.Events:
+				if !tt.wantEvent {
+					t.Errorf("Apply() recorded unexpected event %q", event)
+				} else if !strings.Contains(event, "ObjectDrifted") || !strings.Contains(event, ".data.ca.crt") {
+					t.Errorf("Apply() recorded event %q, want a drift of .data.ca.crt", event)
+				}
+			default:
+				if tt.wantEvent {
+					t.Errorf("Apply() recorded no drift event")
+				}
+			}
+		})
+	}
+}
+
+func TestDriftedFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		live    interface{}
+		desired interface{}
+		want    []string
+	}{
+		{
+			name:    "equal",
+			live:    map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			desired: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+		},
+		{
+			name:    "changed value",
+			live:    map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(2)}},
+			desired: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			want:    []string{".spec.replicas"},
+		},
+		{
+			name:    "missing field",
+			live:    map[string]interface{}{"spec": map[string]interface{}{}},
+			desired: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			want:    []string{".spec.replicas"},
+		},
+		{
+			name:    "fields only set in the live object",
+			live:    map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1), "paused": true}},
+			desired: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+		},
+		{
+			name:    "shorter list",
+			live:    map[string]interface{}{"args": []interface{}{"a"}},
+			desired: map[string]interface{}{"args": []interface{}{"a", "b"}},
+			want:    []string{".args"},
+		},
+		{
+			name:    "changed list item",
+			live:    map[string]interface{}{"args": []interface{}{"a", "c"}},
+			desired: map[string]interface{}{"args": []interface{}{"a", "b"}},
+			want:    []string{".args[1]"},
+		},
+		{
+			name:    "write-only fields",
+			live:    map[string]interface{}{"data": map[string]interface{}{"key": "dmFsdWU="}},
+			desired: map[string]interface{}{"stringData": map[string]interface{}{"key": "value"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := driftedFields(tt.live, tt.desired, "")
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("driftedFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,103 +17,100 @@ limitations under the License.
 package client
 
 import (
-	"context"
-
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaversioned "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/client-go/kubernetes"
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	aggregator "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 )
 
-// CreateOrUpdateService creates or updates a service
-func CreateOrUpdateService(client kubernetes.Interface, svc *corev1.Service) error {
-	got, err := client.CoreV1().Services(svc.Namespace).Get(context.TODO(), svc.Name, metav1.GetOptions{})
-	if err != nil {
-		if !errors.IsNotFound(err) {
-			return err
-		}
-		_, err = client.CoreV1().Services(svc.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
-		return err
-	}
-	svc.ResourceVersion = got.ResourceVersion
-	_, err = client.CoreV1().Services(svc.Namespace).Update(context.TODO(), svc, metav1.UpdateOptions{})
-	return err
-}
-
-// CreateOrUpdateDeployment creates or updates a deployment
-func CreateOrUpdateDeployment(client kubernetes.Interface, deployment *appsv1.Deployment) error {
-	got, err := client.AppsV1().Deployments(deployment.Namespace).Get(context.TODO(), deployment.Name, metav1.GetOptions{})
-	if err != nil {
-		if !errors.IsNotFound(err) {
-			return err
-		}
-		_, err = client.AppsV1().Deployments(deployment.Namespace).Create(context.TODO(), deployment, metav1.CreateOptions{})
-		return err
-	}
-	deployment.ResourceVersion = got.ResourceVersion
-	_, err = client.AppsV1().Deployments(deployment.Namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
-	return err
-}
-
-// CreateOrUpdateStatefulSet creates or updates a statefulset
-func CreateOrUpdateStatefulSet(client kubernetes.Interface, statefulset *appsv1.StatefulSet) error {
-	got, err := client.AppsV1().StatefulSets(statefulset.Namespace).Get(context.TODO(), statefulset.Name, metav1.GetOptions{})
-	if err != nil {
-		if !errors.IsNotFound(err) {
-			return err
-		}
-		_, err = client.AppsV1().StatefulSets(statefulset.Namespace).Create(context.TODO(), statefulset, metav1.CreateOptions{})
-		return err
-	}
-	statefulset.ResourceVersion = got.ResourceVersion
-	_, err = client.AppsV1().StatefulSets(statefulset.Namespace).Update(context.TODO(), statefulset, metav1.UpdateOptions{})
-	return err
-}
-
-// CreateOrUpdateSecret creates or updates a secret
-func CreateOrUpdateSecret(client kubernetes.Interface, secret *corev1.Secret) error {
-	got, err := client.CoreV1().Secrets(secret.Namespace).Get(context.TODO(), secret.Name, metav1.GetOptions{})
-	if err != nil {
-		if !errors.IsNotFound(err) {
-			return err
-		}
-		_, err = client.CoreV1().Secrets(secret.Namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
-		return err
-	}
-	secret.ResourceVersion = got.ResourceVersion
-	_, err = client.CoreV1().Secrets(secret.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
-	return err
-}
-
-// CreateOrUpdateConfigMap creates or updates a configmap
-func CreateOrUpdateConfigMap(client kubernetes.Interface, cm *corev1.ConfigMap) error {
-	got, err := client.CoreV1().ConfigMaps(cm.Namespace).Get(context.TODO(), cm.Name, metav1.GetOptions{})
-	if err != nil {
-		if !errors.IsNotFound(err) {
-			return err
-		}
-		_, err = client.CoreV1().ConfigMaps(cm.Namespace).Create(context.TODO(), cm, metav1.CreateOptions{})
-		return err
-	}
-	cm.ResourceVersion = got.ResourceVersion
-	_, err = client.CoreV1().ConfigMaps(cm.Namespace).Update(context.TODO(), cm, metav1.UpdateOptions{})
-	return err
-}
-
-// CreateOrUpdateAPIService creates or updates an apiservice
-func CreateOrUpdateAPIService(client aggregator.Interface, apisvc *apiregistrationv1.APIService) error {
-	got, err := client.ApiregistrationV1().APIServices().Get(context.TODO(), apisvc.Name, metav1.GetOptions{})
-	if err != nil {
-		if !errors.IsNotFound(err) {
-			return err
-		}
-		_, err = client.ApiregistrationV1().APIServices().Create(context.TODO(), apisvc, metav1.CreateOptions{})
-		return err
-	}
-	apisvc.ResourceVersion = got.ResourceVersion
-	_, err = client.ApiregistrationV1().APIServices().Update(context.TODO(), apisvc, metav1.UpdateOptions{})
-	return err
+// ApplyService applies a service
+func ApplyService(client kubernetes.Interface, svc *corev1.Service) error {
+	return Apply[*corev1.Service](client.CoreV1().Services(svc.Namespace), svc)
+}
+
+// ApplyDeployment applies a deployment
+func ApplyDeployment(client kubernetes.Interface, deployment *appsv1.Deployment) error {
+	return Apply[*appsv1.Deployment](client.AppsV1().Deployments(deployment.Namespace), deployment)
+}
+
+// ApplyStatefulSet applies a statefulset
+func ApplyStatefulSet(client kubernetes.Interface, statefulset *appsv1.StatefulSet) error {
+	return Apply[*appsv1.StatefulSet](client.AppsV1().StatefulSets(statefulset.Namespace), statefulset)
+}
+
+// ApplyCronJob applies a cronjob
+func ApplyCronJob(client kubernetes.Interface, cronJob *batchv1.CronJob) error {
+	return Apply[*batchv1.CronJob](client.BatchV1().CronJobs(cronJob.Namespace), cronJob)
+}
+
+// ApplyPodDisruptionBudget applies a pod disruption budget
+func ApplyPodDisruptionBudget(client kubernetes.Interface, pdb *policyv1.PodDisruptionBudget) error {
+	return Apply[*policyv1.PodDisruptionBudget](client.PolicyV1().PodDisruptionBudgets(pdb.Namespace), pdb)
+}
+
+// ApplySecret applies a secret
+func ApplySecret(client kubernetes.Interface, secret *corev1.Secret) error {
+	return Apply[*corev1.Secret](client.CoreV1().Secrets(secret.Namespace), secret)
+}
+
+// ApplyConfigMap applies a configmap
+func ApplyConfigMap(client kubernetes.Interface, cm *corev1.ConfigMap) error {
+	return Apply[*corev1.ConfigMap](client.CoreV1().ConfigMaps(cm.Namespace), cm)
+}
+
+// ApplyServiceAccount applies a service account
+func ApplyServiceAccount(client kubernetes.Interface, sa *corev1.ServiceAccount) error {
+	return Apply[*corev1.ServiceAccount](client.CoreV1().ServiceAccounts(sa.Namespace), sa)
+}
+
+// ApplyNamespace applies a namespace
+func ApplyNamespace(client kubernetes.Interface, ns *corev1.Namespace) error {
+	return Apply[*corev1.Namespace](client.CoreV1().Namespaces(), ns)
+}
+
+// ApplyRole applies a role
+func ApplyRole(client kubernetes.Interface, role *rbacv1.Role) error {
+	return Apply[*rbacv1.Role](client.RbacV1().Roles(role.Namespace), role)
+}
+
+// ApplyRoleBinding applies a role binding
+func ApplyRoleBinding(client kubernetes.Interface, rb *rbacv1.RoleBinding) error {
+	return Apply[*rbacv1.RoleBinding](client.RbacV1().RoleBindings(rb.Namespace), rb)
+}
+
+// ApplyClusterRole applies a cluster role
+func ApplyClusterRole(client kubernetes.Interface, cr *rbacv1.ClusterRole) error {
+	return Apply[*rbacv1.ClusterRole](client.RbacV1().ClusterRoles(), cr)
+}
+
+// ApplyClusterRoleBinding applies a cluster role binding
+func ApplyClusterRoleBinding(client kubernetes.Interface, crb *rbacv1.ClusterRoleBinding) error {
+	return Apply[*rbacv1.ClusterRoleBinding](client.RbacV1().ClusterRoleBindings(), crb)
+}
+
+// ApplyValidatingWebhookConfiguration applies a validating webhook configuration
+func ApplyValidatingWebhookConfiguration(client kubernetes.Interface, config *admissionregistrationv1.ValidatingWebhookConfiguration) error {
+	return Apply[*admissionregistrationv1.ValidatingWebhookConfiguration](client.AdmissionregistrationV1().ValidatingWebhookConfigurations(), config)
+}
+
+// ApplyMutatingWebhookConfiguration applies a mutating webhook configuration
+func ApplyMutatingWebhookConfiguration(client kubernetes.Interface, config *admissionregistrationv1.MutatingWebhookConfiguration) error {
+	return Apply[*admissionregistrationv1.MutatingWebhookConfiguration](client.AdmissionregistrationV1().MutatingWebhookConfigurations(), config)
+}
+
+// ApplyAPIService applies an apiservice
+func ApplyAPIService(client aggregator.Interface, apisvc *apiregistrationv1.APIService) error {
+	return Apply[*apiregistrationv1.APIService](client.ApiregistrationV1().APIServices(), apisvc)
+}
+
+// ApplyCluster applies a cluster of karmada
+func ApplyCluster(client karmadaversioned.Interface, cluster *clusterv1alpha1.Cluster) error {
+	return Apply[*clusterv1alpha1.Cluster](client.ClusterV1alpha1().Clusters(), cluster)
 }
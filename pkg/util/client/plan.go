@@ -0,0 +1,231 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	restclient "k8s.io/client-go/rest"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+)
+
+// Plan records the changes which would be made by the requests sent with the configs wrapped by it. The
+// mutating requests are sent to the API servers as dry runs, so nothing is changed but the API servers
+// still validate and default the objects, and the results are compared with the live objects.
+type Plan struct {
+	lock    sync.Mutex
+	changes []installv1alpha1.PlannedChange
+}
+
+// NewPlan returns an empty plan.
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// WrapConfig returns a copy of the config whose mutating requests are recorded in the plan instead of being
+// applied. The server is the address of the API server which is reported in the changes, empty for the host
+// cluster.
+func (p *Plan) WrapConfig(config *restclient.Config, server string) *restclient.Config {
+	config = restclient.CopyConfig(config)
+	// The objects are decoded to compare them, so they must not be encoded as protobuf.
+	config.ContentType = runtime.ContentTypeJSON
+	config.AcceptContentTypes = runtime.ContentTypeJSON
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &dryRunRoundTripper{plan: p, server: server, next: rt}
+	})
+	return config
+}
+
+// Changes returns the changes recorded in the plan, in the order they were made.
+func (p *Plan) Changes() []installv1alpha1.PlannedChange {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return append([]installv1alpha1.PlannedChange(nil), p.changes...)
+}
+
+// record adds the change to the plan. The changes of the same object are merged.
+func (p *Plan) record(change installv1alpha1.PlannedChange) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i := range p.changes {
+		c := &p.changes[i]
+		if c.Server != change.Server || c.Resource != change.Resource || c.Namespace != change.Namespace || c.Name != change.Name {
+			continue
+		}
+		if c.Action == installv1alpha1.PlannedActionUpdate {
+			c.Action = change.Action
+		}
+		c.Fields = sets.NewString(append(c.Fields, change.Fields...)...).List()
+		return
+	}
+	p.changes = append(p.changes, change)
+}
+
+// dryRunRoundTripper sends the mutating requests as dry runs and records their changes in the plan.
+type dryRunRoundTripper struct {
+	plan   *Plan
+	server string
+	next   http.RoundTripper
+}
+
+func (rt *dryRunRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var action installv1alpha1.PlannedAction
+	switch req.Method {
+	case http.MethodPost:
+		action = installv1alpha1.PlannedActionCreate
+	case http.MethodPut, http.MethodPatch:
+		action = installv1alpha1.PlannedActionUpdate
+	case http.MethodDelete:
+		action = installv1alpha1.PlannedActionDelete
+	default:
+		return rt.next.RoundTrip(req)
+	}
+
+	var live map[string]interface{}
+	if action == installv1alpha1.PlannedActionUpdate {
+		var err error
+		if live, err = rt.get(req); err != nil {
+			return nil, err
+		}
+		// An object which is applied but doesn't exist yet is created.
+		if live == nil {
+			action = installv1alpha1.PlannedActionCreate
+		}
+	}
+
+	req = req.Clone(req.Context())
+	query := req.URL.Query()
+	query.Set("dryRun", "All")
+	req.URL.RawQuery = query.Encode()
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	change := installv1alpha1.PlannedChange{Action: action, Server: rt.server}
+	change.Resource, change.Namespace, change.Name = requestObject(req.URL.Path)
+	if action == installv1alpha1.PlannedActionCreate {
+		// The name of a created object is only known from the object.
+		result := &unstructured.Unstructured{}
+		if err := json.Unmarshal(body, &result.Object); err == nil {
+			change.Name = result.GetName()
+		}
+	}
+	if action == installv1alpha1.PlannedActionUpdate {
+		var result map[string]interface{}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+		change.Fields = changedFields(live, result)
+		// The request doesn't change the object, e.g. it only sets the values which are defaulted.
+		if len(change.Fields) == 0 {
+			return resp, nil
+		}
+	}
+	rt.plan.record(change)
+	return resp, nil
+}
+
+// get returns the live object which is changed by the request, or nil if it doesn't exist.
+func (rt *dryRunRoundTripper) get(req *http.Request) (map[string]interface{}, error) {
+	get, err := http.NewRequestWithContext(req.Context(), http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	get.Header = req.Header.Clone()
+	get.Header.Del("Content-Type")
+	resp, err := rt.next.RoundTrip(get)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+	var live map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&live); err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+// requestObject returns the resource, namespace and name of the object of the request path, e.g.
+// /apis/apps/v1/namespaces/default/deployments/foo/status.
+func requestObject(path string) (resource, namespace, name string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	var group string
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		group = parts[1]
+		parts = parts[3:]
+	default:
+		return path, "", ""
+	}
+	// A namespace itself is addressed as namespaces/<name>, so a namespaced object has a resource after it.
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		namespace = parts[1]
+		parts = parts[2:]
+	}
+	if len(parts) > 0 {
+		resource = parts[0]
+	}
+	if group != "" {
+		resource = resource + "." + group
+	}
+	if len(parts) > 1 {
+		name = parts[1]
+	}
+	if len(parts) > 2 {
+		resource = resource + "/" + strings.Join(parts[2:], "/")
+	}
+	return resource, namespace, name
+}
+
+// changedFields returns the paths of the fields which are changed by an update, without the fields which
+// are changed by every write and the status.
+func changedFields(live, result map[string]interface{}) []string {
+	for _, obj := range []map[string]interface{}{live, result} {
+		delete(obj, "status")
+		for _, field := range []string{"resourceVersion", "generation", "managedFields"} {
+			unstructured.RemoveNestedField(obj, "metadata", field)
+		}
+		unstructured.RemoveNestedField(obj, "metadata", "annotations", constants.AppliedHashAnnotation)
+	}
+	fields := driftedFields(live, result, "")
+	// The fields which are removed by the update are only set in the live object.
+	fields = append(fields, driftedFields(result, live, "")...)
+	return sets.NewString(fields...).List()
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+)
+
+// PodDisruptionBudget returns the PodDisruptionBudget of a component whose pods are selected by the selector,
+// or nil if the component has a single replica or the PodDisruptionBudget is disabled by the policy.
+func PodDisruptionBudget(name, namespace string, selector map[string]string, replicas *int32, policy *installv1alpha1.PodDisruptionBudgetPolicy) *policyv1.PodDisruptionBudget {
+	if replicas == nil || *replicas <= 1 {
+		return nil
+	}
+	if policy != nil && policy.Enable != nil && !*policy.Enable {
+		return nil
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "policy/v1",
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    selector,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+		},
+	}
+	switch {
+	case policy != nil && policy.MinAvailable != nil:
+		pdb.Spec.MinAvailable = policy.MinAvailable
+	case policy != nil && policy.MaxUnavailable != nil:
+		pdb.Spec.MaxUnavailable = policy.MaxUnavailable
+	default:
+		maxUnavailable := intstr.FromInt(1)
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+	return pdb
+}
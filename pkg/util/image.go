@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ComponentImage returns the image of a component. The override of the component takes precedence over
+// the image built from the repository, name and tag.
+func ComponentImage(overrides map[string]string, component, repository, imageName, tag string) string {
+	if image := overrides[component]; image != "" {
+		return image
+	}
+	return ComponentImageName(repository, imageName, tag)
+}
+
+// ImagePullPolicy returns the first pull policy which is set, so the pull policy of a component can fall
+// back to the default pull policy.
+func ImagePullPolicy(policies ...corev1.PullPolicy) corev1.PullPolicy {
+	for _, policy := range policies {
+		if policy != "" {
+			return policy
+		}
+	}
+	return ""
+}
+
+// SetPodImagePullSecrets sets the pull secrets of the pod spec to the default secrets and the secrets of
+// the component.
+func SetPodImagePullSecrets(spec *corev1.PodSpec, secrets, defaults []corev1.LocalObjectReference) {
+	seen := map[string]bool{}
+	var merged []corev1.LocalObjectReference
+	for _, secret := range append(append([]corev1.LocalObjectReference{}, defaults...), secrets...) {
+		if secret.Name == "" || seen[secret.Name] {
+			continue
+		}
+		seen[secret.Name] = true
+		merged = append(merged, secret)
+	}
+	spec.ImagePullSecrets = merged
+}
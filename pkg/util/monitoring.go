@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	clientutil "github.com/carlory/firefly/pkg/util/client"
+	maputil "github.com/carlory/firefly/pkg/util/map"
+)
+
+// The resources of the prometheus operator which the components are monitored with.
+var (
+	ServiceMonitorGVR = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "servicemonitors"}
+	PodMonitorGVR     = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "podmonitors"}
+	PrometheusRuleGVR = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "prometheusrules"}
+)
+
+// monitoringResources maps the kinds of the monitoring objects to their resources.
+var monitoringResources = map[string]schema.GroupVersionResource{
+	"ServiceMonitor": ServiceMonitorGVR,
+	"PodMonitor":     PodMonitorGVR,
+	"PrometheusRule": PrometheusRuleGVR,
+}
+
+// defaultMonitoringInterval is the interval at which the metrics are scraped if the monitoring doesn't set it.
+const defaultMonitoringInterval = 30 * time.Second
+
+// MonitoringInstalled returns whether the CRDs of the prometheus operator are installed in the cluster.
+func MonitoringInstalled(client dynamic.Interface, namespace string) (bool, error) {
+	_, err := client.Resource(ServiceMonitorGVR).Namespace(namespace).List(context.TODO(), metav1.ListOptions{Limit: 1})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// MonitoringInterval returns the interval at which the metrics are scraped.
+func MonitoringInterval(monitoring *installv1alpha1.Monitoring) string {
+	if monitoring.Interval != nil {
+		return monitoring.Interval.Duration.String()
+	}
+	return defaultMonitoringInterval.String()
+}
+
+// MonitoringObject returns a monitoring object of the given kind. It's labeled with the labels of the monitoring,
+// so that it's selected by a Prometheus, and with the given owner labels, so that it's found when it's
+// no longer desired.
+func MonitoringObject(kind, name, namespace string, monitoring *installv1alpha1.Monitoring, ownerLabels map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetAPIVersion(monitoringResources[kind].GroupVersion().String())
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(maputil.MergeStringMaps(monitoring.Labels, ownerLabels))
+	return obj
+}
+
+// MatchLabels returns the selector of a monitoring object which matches the labels.
+func MatchLabels(set map[string]string) map[string]interface{} {
+	matchLabels := make(map[string]interface{}, len(set))
+	for k, v := range set {
+		matchLabels[k] = v
+	}
+	return map[string]interface{}{"matchLabels": matchLabels}
+}
+
+// SecretKeySelector returns the reference to a key of a secret in a monitoring object.
+func SecretKeySelector(name, key string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "key": key}
+}
+
+// AlertingRule returns an alerting rule of a PrometheusRule.
+func AlertingRule(alert, expr, pending, severity, summary string) map[string]interface{} {
+	return map[string]interface{}{
+		"alert":       alert,
+		"expr":        expr,
+		"for":         pending,
+		"labels":      map[string]interface{}{"severity": severity},
+		"annotations": map[string]interface{}{"summary": summary},
+	}
+}
+
+// ApplyMonitoringObjects applies the desired monitoring objects, and removes the ones in the namespace which
+// are labeled with the owner labels but aren't desired anymore.
+func ApplyMonitoringObjects(client dynamic.Interface, namespace string, ownerLabels map[string]string, objs []*unstructured.Unstructured) error {
+	desired := map[string]bool{}
+	for _, obj := range objs {
+		if err := clientutil.ApplyUnstructured(client.Resource(monitoringResources[obj.GetKind()]).Namespace(namespace), obj); err != nil {
+			return err
+		}
+		desired[obj.GetKind()+"/"+obj.GetName()] = true
+	}
+
+	selector := labels.SelectorFromSet(ownerLabels).String()
+	for kind, gvr := range monitoringResources {
+		list, err := client.Resource(gvr).Namespace(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			if desired[kind+"/"+item.GetName()] {
+				continue
+			}
+			err := client.Resource(gvr).Namespace(namespace).Delete(context.TODO(), item.GetName(), metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %s %q: %v", kind, item.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
@@ -16,12 +16,32 @@ limitations under the License.
 
 package util
 
-import "fmt"
+import (
+	"fmt"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+)
 
 func ComponentName(component, name string) string {
 	return fmt.Sprintf("%s-%s", name, component)
 }
 
+// KarmadaComponentName returns the name of a component of the karmada. It's prefixed with the name of the
+// karmada, so that the components of multiple karmadas in the same namespace don't conflict. A karmada
+// which was installed before the names were prefixed keeps the bare names of its components.
+func KarmadaComponentName(karmada *installv1alpha1.Karmada, component string) string {
+	if karmada.Annotations[constants.NamingAnnotation] == constants.NamingLegacy {
+		return component
+	}
+	return ComponentName(component, karmada.Name)
+}
+
+// KarmadaKubeConfigSecretName returns the name of the secret which holds the admin kubeconfig of the karmada.
+func KarmadaKubeConfigSecretName(karmada *installv1alpha1.Karmada) string {
+	return KarmadaComponentName(karmada, "karmada-kubeconfig")
+}
+
 func ComponentImageName(repository, component, version string) string {
 	return fmt.Sprintf("%s/%s:%s", repository, component, version)
 }
@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+	"github.com/carlory/firefly/pkg/constants"
+)
+
+func TestKarmadaComponentName(t *testing.T) {
+	tests := []struct {
+		name   string
+		naming string
+		want   string
+	}{
+		{
+			name: "naming isn't recorded yet",
+			want: "demo-etcd",
+		},
+		{
+			name:   "instance naming",
+			naming: constants.NamingInstance,
+			want:   "demo-etcd",
+		},
+		{
+			name:   "legacy naming",
+			naming: constants.NamingLegacy,
+			want:   "etcd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			karmada := &installv1alpha1.Karmada{ObjectMeta: metav1.ObjectMeta{Name: "demo"}}
+			if tt.naming != "" {
+				karmada.Annotations = map[string]string{constants.NamingAnnotation: tt.naming}
+			}
+			if got := KarmadaComponentName(karmada, constants.KarmadaComponentEtcd); got != tt.want {
+				t.Errorf("KarmadaComponentName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
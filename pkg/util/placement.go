@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	installv1alpha1 "github.com/carlory/firefly/pkg/apis/install/v1alpha1"
+)
+
+// SetPodPlacement sets the scheduling constraints of the pod spec from the placement of a component.
+// Each constraint which isn't set in the placement of the component is taken from the default placement.
+func SetPodPlacement(spec *corev1.PodSpec, placement, defaults *installv1alpha1.Placement) {
+	for _, p := range []*installv1alpha1.Placement{defaults, placement} {
+		if p == nil {
+			continue
+		}
+		if len(p.NodeSelector) > 0 {
+			spec.NodeSelector = p.NodeSelector
+		}
+		if len(p.Tolerations) > 0 {
+			spec.Tolerations = p.Tolerations
+		}
+		if p.Affinity != nil {
+			spec.Affinity = p.Affinity
+		}
+		if p.PriorityClassName != "" {
+			spec.PriorityClassName = p.PriorityClassName
+		}
+		if len(p.TopologySpreadConstraints) > 0 {
+			spec.TopologySpreadConstraints = p.TopologySpreadConstraints
+		}
+	}
+}
+
+// SetDefaultPodAntiAffinity prefers to schedule the replicas of a component onto different nodes, so a node
+// failure or drain doesn't take down all of them at once. It is a no-op for a single replica or when the
+// pod anti-affinity is already set by the placement of the component.
+func SetDefaultPodAntiAffinity(spec *corev1.PodSpec, selector map[string]string, replicas *int32) {
+	if replicas == nil || *replicas <= 1 {
+		return
+	}
+	if spec.Affinity != nil && spec.Affinity.PodAntiAffinity != nil {
+		return
+	}
+
+	affinity := &corev1.Affinity{}
+	if spec.Affinity != nil {
+		// The affinity may be shared with the placement, so it is copied before it is changed.
+		affinity = spec.Affinity.DeepCopy()
+	}
+	affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+			{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: selector},
+					TopologyKey:   corev1.LabelHostname,
+				},
+			},
+		},
+	}
+	spec.Affinity = affinity
+}
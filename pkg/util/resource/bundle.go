@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// SelectBundle returns the directory of the bundle in the file system which serves the given version.
+// The bundles are directories named after the minor version they are pinned to, e.g. v1.3. A version
+// older than all the bundles falls back to the oldest bundle, so that the instances created with an older
+// version keep working and can be upgraded. Other versions without a bundle of their own minor version aren't supported. A
+// version which can't be parsed, such as latest, uses the latest bundle.
+func SelectBundle(bundles fs.FS, v string) (string, error) {
+	entries, err := fs.ReadDir(bundles, ".")
+	if err != nil {
+		return "", err
+	}
+
+	type bundle struct {
+		dir     string
+		version *version.Version
+	}
+	var sorted []bundle
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		bundleVersion, err := version.ParseGeneric(entry.Name())
+		if err != nil {
+			continue
+		}
+		sorted = append(sorted, bundle{dir: entry.Name(), version: bundleVersion})
+	}
+	if len(sorted) == 0 {
+		return "", fmt.Errorf("no bundles found")
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].version.LessThan(sorted[j].version)
+	})
+
+	target, err := version.ParseGeneric(v)
+	if err != nil {
+		return sorted[len(sorted)-1].dir, nil
+	}
+	for _, b := range sorted {
+		if b.version.Major() == target.Major() && b.version.Minor() == target.Minor() {
+			return b.dir, nil
+		}
+	}
+	if oldest := sorted[0]; target.LessThan(oldest.version) {
+		return oldest.dir, nil
+	}
+	return "", fmt.Errorf("no bundle supports the version %s", v)
+}
+
+// ApplyBundle applies the manifests of the bundle directory with server-side apply, so the objects
+// which already exist are updated to the manifests too.
+func ApplyBundle(builder *resource.Builder, bundles fs.FS, dir, fieldManager string) error {
+	builder, err := streamBundle(builder, bundles, dir)
+	if err != nil {
+		return err
+	}
+
+	force := true
+	return builder.Flatten().Do().Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+		data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, info.Object)
+		if err != nil {
+			return err
+		}
+		_, err = resource.NewHelper(info.Client, info.Mapping).
+			Patch(info.Namespace, info.Name, types.ApplyPatchType, data, &metav1.PatchOptions{FieldManager: fieldManager, Force: &force})
+		if err != nil {
+			return fmt.Errorf("failed to apply %s %q: %v", info.Mapping.GroupVersionKind.Kind, info.Name, err)
+		}
+		return nil
+	})
+}
+
+// DeleteBundle deletes the objects of the manifests of the bundle directory.
+func DeleteBundle(builder *resource.Builder, bundles fs.FS, dir string) error {
+	builder, err := streamBundle(builder, bundles, dir)
+	if err != nil {
+		return err
+	}
+
+	return builder.Flatten().Do().Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+		_, err = resource.NewHelper(info.Client, info.Mapping).Delete(info.Namespace, info.Name)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	})
+}
+
+// streamBundle adds the yaml manifests of the bundle directory to the builder.
+func streamBundle(builder *resource.Builder, bundles fs.FS, dir string) (*resource.Builder, error) {
+	entries, err := fs.ReadDir(bundles, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	builder = builder.Unstructured()
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+		data, err := fs.ReadFile(bundles, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.Stream(bytes.NewReader(data), entry.Name())
+	}
+	return builder, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Firefly Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestSelectBundle(t *testing.T) {
+	bundles := fstest.MapFS{
+		"v1.2/crd.yaml":  {Data: []byte("kind: CustomResourceDefinition")},
+		"v1.10/crd.yaml": {Data: []byte("kind: CustomResourceDefinition")},
+		"v1.3/crd.yaml":  {Data: []byte("kind: CustomResourceDefinition")},
+		"README.md":      {Data: []byte("bundles")},
+		"unversioned/a":  {Data: []byte("a")},
+	}
+	tests := []struct {
+		name    string
+		bundles fstest.MapFS
+		version string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "patch version",
+			bundles: bundles,
+			version: "v1.3.0",
+			want:    "v1.3",
+		},
+		{
+			name:    "version without v prefix",
+			bundles: bundles,
+			version: "1.2.5",
+			want:    "v1.2",
+		},
+		{
+			name:    "minor versions are compared as numbers",
+			bundles: bundles,
+			version: "v1.10.1",
+			want:    "v1.10",
+		},
+		{
+			name:    "older minor version falls back to the oldest bundle",
+			bundles: bundles,
+			version: "v1.1.0",
+			want:    "v1.2",
+		},
+		{
+			name:    "minor version between the bundles",
+			bundles: bundles,
+			version: "v1.5.0",
+			wantErr: true,
+		},
+		{
+			name:    "newer minor version",
+			bundles: bundles,
+			version: "v1.11.0",
+			wantErr: true,
+		},
+		{
+			name:    "latest",
+			bundles: bundles,
+			version: "latest",
+			want:    "v1.10",
+		},
+		{
+			name:    "no bundles",
+			bundles: fstest.MapFS{"README.md": {Data: []byte("bundles")}},
+			version: "v1.3.0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectBundle(tt.bundles, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectBundle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SelectBundle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}